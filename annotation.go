@@ -2,9 +2,13 @@ package phoenix
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/agentplexus/go-phoenix/internal/api"
+	"github.com/go-faster/jx"
 )
 
 // Annotation represents a Phoenix annotation on a span or trace.
@@ -17,10 +21,31 @@ type Annotation struct {
 	Label       string
 	Explanation string
 	Source      AnnotatorKind
+	UserID      string // ID of the Phoenix user who created the annotation, if known
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+
+	// ConfidenceInterval is the uncertainty of Score, if the annotator
+	// reported one.
+	ConfidenceInterval *ScoreConfidenceInterval
+}
+
+// ScoreConfidenceInterval is a confidence interval around an annotation
+// score, such as a Bayesian credible interval. Confidence is the interval's
+// level, e.g. 0.95.
+//
+// Phoenix annotations have no field for it, so it is stored in the
+// annotation metadata under AnnotationMetadataKeyConfidenceInterval.
+type ScoreConfidenceInterval struct {
+	Lower      float64 `json:"lower"`
+	Upper      float64 `json:"upper"`
+	Confidence float64 `json:"confidence"`
 }
 
+// AnnotationMetadataKeyConfidenceInterval is the annotation metadata key
+// holding a ScoreConfidenceInterval.
+const AnnotationMetadataKeyConfidenceInterval = "score_confidence_interval"
+
 // AnnotatorKind indicates who created the annotation.
 type AnnotatorKind string
 
@@ -37,6 +62,9 @@ func (c *Client) CreateSpanAnnotation(ctx context.Context, spanID, name string,
 		opt(options)
 	}
 
+	if options.score != nil {
+		score = *options.score
+	}
 	result := api.AnnotationResult{}
 	result.SetScore(api.OptNilFloat64{Value: score, Set: true})
 	if options.explanation != "" {
@@ -54,13 +82,22 @@ func (c *Client) CreateSpanAnnotation(ctx context.Context, spanID, name string,
 		annotatorKind = api.SpanAnnotationDataAnnotatorKindCODE
 	}
 
+	data := api.SpanAnnotationData{
+		SpanID:        spanID,
+		Name:          name,
+		AnnotatorKind: annotatorKind,
+		Result:        api.OptAnnotationResult{Value: result, Set: true},
+	}
+	if options.interval != nil {
+		metadata, err := confidenceIntervalMetadata[api.SpanAnnotationDataMetadata](options.interval)
+		if err != nil {
+			return err
+		}
+		data.Metadata.SetTo(metadata)
+	}
+
 	_, err := c.apiClient.AnnotateSpans(ctx, &api.AnnotateSpansRequestBody{
-		Data: []api.SpanAnnotationData{{
-			SpanID:        spanID,
-			Name:          name,
-			AnnotatorKind: annotatorKind,
-			Result:        api.OptAnnotationResult{Value: result, Set: true},
-		}},
+		Data: []api.SpanAnnotationData{data},
 	}, api.AnnotateSpansParams{})
 
 	return err
@@ -73,6 +110,9 @@ func (c *Client) CreateTraceAnnotation(ctx context.Context, traceID, name string
 		opt(options)
 	}
 
+	if options.score != nil {
+		score = *options.score
+	}
 	result := api.AnnotationResult{}
 	result.SetScore(api.OptNilFloat64{Value: score, Set: true})
 	if options.explanation != "" {
@@ -90,60 +130,211 @@ func (c *Client) CreateTraceAnnotation(ctx context.Context, traceID, name string
 		annotatorKind = api.TraceAnnotationDataAnnotatorKindCODE
 	}
 
+	data := api.TraceAnnotationData{
+		TraceID:       traceID,
+		Name:          name,
+		AnnotatorKind: annotatorKind,
+		Result:        api.OptAnnotationResult{Value: result, Set: true},
+	}
+	if options.interval != nil {
+		metadata, err := confidenceIntervalMetadata[api.TraceAnnotationDataMetadata](options.interval)
+		if err != nil {
+			return err
+		}
+		data.Metadata.SetTo(metadata)
+	}
+
 	_, err := c.apiClient.AnnotateTraces(ctx, &api.AnnotateTracesRequestBody{
-		Data: []api.TraceAnnotationData{{
-			TraceID:       traceID,
-			Name:          name,
+		Data: []api.TraceAnnotationData{data},
+	}, api.AnnotateTracesParams{})
+
+	return err
+}
+
+// CreateSpanAnnotations creates multiple span annotations in a single request.
+// Each annotation must have SpanID and Name set; ID, TraceID and timestamps
+// are ignored.
+func (c *Client) CreateSpanAnnotations(ctx context.Context, annotations []*Annotation) error {
+	if len(annotations) == 0 {
+		return nil
+	}
+
+	data := make([]api.SpanAnnotationData, 0, len(annotations))
+	for _, a := range annotations {
+		result := api.AnnotationResult{}
+		result.SetScore(api.OptNilFloat64{Value: a.Score, Set: true})
+		if a.Explanation != "" {
+			result.SetExplanation(api.OptNilString{Value: a.Explanation, Set: true})
+		}
+		if a.Label != "" {
+			result.SetLabel(api.OptNilString{Value: a.Label, Set: true})
+		}
+
+		annotatorKind := api.SpanAnnotationDataAnnotatorKindHUMAN
+		switch a.Source {
+		case AnnotatorKindLLM:
+			annotatorKind = api.SpanAnnotationDataAnnotatorKindLLM
+		case AnnotatorKindCode:
+			annotatorKind = api.SpanAnnotationDataAnnotatorKindCODE
+		}
+
+		d := api.SpanAnnotationData{
+			SpanID:        a.SpanID,
+			Name:          a.Name,
 			AnnotatorKind: annotatorKind,
 			Result:        api.OptAnnotationResult{Value: result, Set: true},
-		}},
-	}, api.AnnotateTracesParams{})
+		}
+		if a.ConfidenceInterval != nil {
+			metadata, err := confidenceIntervalMetadata[api.SpanAnnotationDataMetadata](a.ConfidenceInterval)
+			if err != nil {
+				return err
+			}
+			d.Metadata.SetTo(metadata)
+		}
+		data = append(data, d)
+	}
+
+	_, err := c.apiClient.AnnotateSpans(ctx, &api.AnnotateSpansRequestBody{
+		Data: data,
+	}, api.AnnotateSpansParams{})
 
 	return err
 }
 
-// ListSpanAnnotations lists annotations for the given span IDs.
-func (c *Client) ListSpanAnnotations(ctx context.Context, spanIDs []string) ([]*Annotation, error) {
-	res, err := c.apiClient.ListSpanAnnotationsBySpanIds(ctx, api.ListSpanAnnotationsBySpanIdsParams{
-		SpanIds: spanIDs,
-	})
+// ListSpanAnnotations lists annotations for the given span IDs, one page at
+// a time. Paging is done by Phoenix: pass the returned cursor to WithCursor
+// to fetch the next page. The cursor is empty on the last page.
+func (c *Client) ListSpanAnnotations(ctx context.Context, spanIDs []string, opts ...ListOption) ([]*Annotation, string, error) {
+	options := defaultListOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	data, nextCursor, err := c.spanAnnotationsPage(ctx, spanIDs, options)
+	if err != nil {
+		return nil, "", err
+	}
+
+	annotations := make([]*Annotation, 0, len(data))
+	for i := range data {
+		annotations = append(annotations, convertSpanAnnotation(&data[i]))
+	}
+
+	return annotations, nextCursor, nil
+}
+
+// spanAnnotationsPage fetches one page of the annotations for the given
+// span IDs as returned by the API.
+func (c *Client) spanAnnotationsPage(ctx context.Context, spanIDs []string, options *listOptions) ([]api.SpanAnnotation, string, error) {
+	ctx = withRequestIDRecorder(ctx)
+	params := api.ListSpanAnnotationsBySpanIdsParams{
+		ProjectIdentifier: c.config.ProjectName,
+		SpanIds:           spanIDs,
+	}
+	if options.cursor != "" {
+		params.Cursor.SetTo(options.cursor)
+	}
+	if options.limit > 0 {
+		params.Limit.SetTo(options.limit)
+	}
+
+	res, err := c.apiClient.ListSpanAnnotationsBySpanIds(ctx, params)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	resp, ok := res.(*api.SpanAnnotationsResponseBody)
 	if !ok {
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, "", c.unexpectedResponseError(ctx)
 	}
 
-	annotations := make([]*Annotation, 0, len(resp.Data))
-	for i := range resp.Data {
-		annotations = append(annotations, convertSpanAnnotation(&resp.Data[i]))
+	var nextCursor string
+	if !resp.NextCursor.Null {
+		nextCursor = resp.NextCursor.Value
 	}
 
-	return annotations, nil
+	return resp.Data, nextCursor, nil
 }
 
-// ListTraceAnnotations lists annotations for the given trace IDs.
-func (c *Client) ListTraceAnnotations(ctx context.Context, traceIDs []string) ([]*Annotation, error) {
-	res, err := c.apiClient.ListTraceAnnotationsByTraceIds(ctx, api.ListTraceAnnotationsByTraceIdsParams{
-		TraceIds: traceIDs,
-	})
-	if err != nil {
-		return nil, err
+// listAllSpanAnnotations pages through all annotations for the given span IDs.
+func (c *Client) listAllSpanAnnotations(ctx context.Context, spanIDs []string) ([]*Annotation, error) {
+	var all []*Annotation
+	cursor := ""
+	for {
+		annotations, next, err := c.ListSpanAnnotations(ctx, spanIDs, WithCursor(cursor))
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, annotations...)
+		if next == "" {
+			return all, nil
+		}
+		cursor = next
 	}
+}
 
-	resp, ok := res.(*api.TraceAnnotationsResponseBody)
-	if !ok {
-		return nil, &APIError{Message: "unexpected response type"}
+// AttachSpanAnnotations loads the annotations of each span into its
+// Annotations field, replacing any previously attached annotations.
+func (c *Client) AttachSpanAnnotations(ctx context.Context, spans []*Span) error {
+	bySpanID := make(map[string][]*Span, len(spans))
+	ids := make([]string, 0, len(spans))
+	for _, s := range spans {
+		s.Annotations = nil
+		if _, ok := bySpanID[s.SpanID]; !ok {
+			ids = append(ids, s.SpanID)
+		}
+		bySpanID[s.SpanID] = append(bySpanID[s.SpanID], s)
 	}
 
-	annotations := make([]*Annotation, 0, len(resp.Data))
-	for i := range resp.Data {
-		annotations = append(annotations, convertTraceAnnotation(&resp.Data[i]))
+	for chunk := range slices.Chunk(ids, annotationExportChunkSize) {
+		annotations, err := c.listAllSpanAnnotations(ctx, chunk)
+		if err != nil {
+			return err
+		}
+		for _, a := range annotations {
+			for _, s := range bySpanID[a.SpanID] {
+				s.Annotations = append(s.Annotations, a)
+			}
+		}
 	}
+	return nil
+}
 
-	return annotations, nil
+// ListTraceAnnotations lists annotations for the given trace IDs.
+func (c *Client) ListTraceAnnotations(ctx context.Context, traceIDs []string) ([]*Annotation, error) {
+	return c.listTraceAnnotations(ctx, c.config.ProjectName, traceIDs)
+}
+
+// listTraceAnnotations pages through all annotations for the given trace
+// IDs in a project.
+func (c *Client) listTraceAnnotations(ctx context.Context, projectIdentifier string, traceIDs []string) ([]*Annotation, error) {
+	ctx = withRequestIDRecorder(ctx)
+	params := api.ListTraceAnnotationsByTraceIdsParams{
+		ProjectIdentifier: projectIdentifier,
+		TraceIds:          traceIDs,
+	}
+	params.Limit.SetTo(defaultListOptions().limit)
+
+	var annotations []*Annotation
+	for {
+		res, err := c.apiClient.ListTraceAnnotationsByTraceIds(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, ok := res.(*api.TraceAnnotationsResponseBody)
+		if !ok {
+			return nil, c.unexpectedResponseError(ctx)
+		}
+
+		for i := range resp.Data {
+			annotations = append(annotations, convertTraceAnnotation(&resp.Data[i]))
+		}
+		if resp.NextCursor.Null || resp.NextCursor.Value == "" {
+			return annotations, nil
+		}
+		params.Cursor.SetTo(resp.NextCursor.Value)
+	}
 }
 
 // AnnotationOption configures annotation creation.
@@ -153,6 +344,8 @@ type annotationOptions struct {
 	explanation string
 	label       string
 	source      AnnotatorKind
+	score       *float64
+	interval    *ScoreConfidenceInterval
 }
 
 // WithAnnotationExplanation sets the explanation for the annotation.
@@ -169,6 +362,16 @@ func WithAnnotationLabel(label string) AnnotationOption {
 	}
 }
 
+// WithAnnotationScore sets the annotation score, overriding the score passed
+// to the create method, together with a confidence interval of the given
+// level, e.g. 0.95, between lower and upper.
+func WithAnnotationScore(score, lower, upper, confidence float64) AnnotationOption {
+	return func(o *annotationOptions) {
+		o.score = &score
+		o.interval = &ScoreConfidenceInterval{Lower: lower, Upper: upper, Confidence: confidence}
+	}
+}
+
 // WithAnnotationSource sets the source (annotator kind) for the annotation.
 func WithAnnotationSource(source AnnotatorKind) AnnotationOption {
 	return func(o *annotationOptions) {
@@ -187,6 +390,9 @@ func convertSpanAnnotation(a *api.SpanAnnotation) *Annotation { //nolint:dupl //
 		CreatedAt: a.CreatedAt,
 		UpdatedAt: a.UpdatedAt,
 	}
+	if !a.UserID.Null {
+		ann.UserID = a.UserID.Value
+	}
 
 	// Map AnnotatorKind
 	switch a.AnnotatorKind {
@@ -211,6 +417,9 @@ func convertSpanAnnotation(a *api.SpanAnnotation) *Annotation { //nolint:dupl //
 			ann.Explanation = result.Explanation.Value
 		}
 	}
+	if a.Metadata.Set && !a.Metadata.Null {
+		ann.ConfidenceInterval = parseConfidenceInterval(a.Metadata.Value)
+	}
 
 	return ann
 }
@@ -226,6 +435,9 @@ func convertTraceAnnotation(a *api.TraceAnnotation) *Annotation { //nolint:dupl
 		CreatedAt: a.CreatedAt,
 		UpdatedAt: a.UpdatedAt,
 	}
+	if !a.UserID.Null {
+		ann.UserID = a.UserID.Value
+	}
 
 	// Map AnnotatorKind
 	switch a.AnnotatorKind {
@@ -250,6 +462,32 @@ func convertTraceAnnotation(a *api.TraceAnnotation) *Annotation { //nolint:dupl
 			ann.Explanation = result.Explanation.Value
 		}
 	}
+	if a.Metadata.Set && !a.Metadata.Null {
+		ann.ConfidenceInterval = parseConfidenceInterval(a.Metadata.Value)
+	}
 
 	return ann
 }
+
+// confidenceIntervalMetadata encodes ci as annotation metadata.
+func confidenceIntervalMetadata[M ~map[string]jx.Raw](ci *ScoreConfidenceInterval) (M, error) {
+	raw, err := json.Marshal(ci)
+	if err != nil {
+		return nil, fmt.Errorf("phoenix: encoding confidence interval: %w", err)
+	}
+	return M{AnnotationMetadataKeyConfidenceInterval: jx.Raw(raw)}, nil
+}
+
+// parseConfidenceInterval decodes the confidence interval stored in
+// annotation metadata, or returns nil if there is none.
+func parseConfidenceInterval[M ~map[string]jx.Raw](metadata M) *ScoreConfidenceInterval {
+	raw, ok := metadata[AnnotationMetadataKeyConfidenceInterval]
+	if !ok {
+		return nil
+	}
+	var ci ScoreConfidenceInterval
+	if err := json.Unmarshal(raw, &ci); err != nil {
+		return nil
+	}
+	return &ci
+}
@@ -0,0 +1,100 @@
+package phoenix
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// annotationDeleteBatchSize is the number of spans whose annotations are
+// listed per API call.
+const annotationDeleteBatchSize = 100
+
+// DeleteAnnotationOption is a functional option for DeleteAnnotationsByName.
+// Besides the options below, the AnnotationAuditOptions WithTimeRange and
+// WithCreatedBy narrow the annotations matched.
+type DeleteAnnotationOption = AnnotationAuditOption
+
+// WithSpanIDFilter only deletes annotations on the given spans. By default,
+// the annotations on every span of the client's project are considered. It
+// is ignored by ListAnnotationAudit.
+func WithSpanIDFilter(spanIDs []string) DeleteAnnotationOption {
+	return func(f *annotationAuditFilter) {
+		f.spanIDs = spanIDs
+	}
+}
+
+// WithDryRun reports how many annotations would be deleted without
+// deleting them. It is ignored by ListAnnotationAudit.
+func WithDryRun(dryRun bool) DeleteAnnotationOption {
+	return func(f *annotationAuditFilter) {
+		f.dryRun = dryRun
+	}
+}
+
+// DeleteAnnotationsByName deletes the span annotations with the given name
+// in the client's project, such as the scores of an evaluation metric, and
+// returns the number deleted.
+//
+// Phoenix does not currently expose an endpoint for deleting annotations,
+// so unless WithDryRun is set this returns ErrNotSupported without making
+// any requests.
+//
+// With WithDryRun, it returns the number of annotations that would be
+// deleted. Phoenix lists annotations by span, so without WithSpanIDFilter
+// every span of the project is listed first. Annotations are then listed
+// 100 spans at a time and matched on the client.
+func (c *Client) DeleteAnnotationsByName(ctx context.Context, name string, opts ...DeleteAnnotationOption) (int, error) {
+	if name == "" {
+		return 0, fmt.Errorf("%w: annotation name is required", ErrInvalidInput)
+	}
+	filter := &annotationAuditFilter{}
+	for _, opt := range opts {
+		opt(filter)
+	}
+	filter.name = name
+	if !filter.dryRun {
+		return 0, fmt.Errorf("%w: deleting annotations named %q", ErrNotSupported, name)
+	}
+
+	spanIDs := filter.spanIDs
+	if spanIDs == nil {
+		var err error
+		if spanIDs, err = c.projectSpanIDs(ctx); err != nil {
+			return 0, err
+		}
+	}
+
+	count := 0
+	for batch := range slices.Chunk(spanIDs, annotationDeleteBatchSize) {
+		annotations, err := c.listAllSpanAnnotations(ctx, batch)
+		if err != nil {
+			return 0, err
+		}
+		for _, a := range annotations {
+			if filter.matches(a) {
+				count++
+			}
+		}
+	}
+	return count, nil
+}
+
+// projectSpanIDs returns the IDs of every span in the client's project.
+func (c *Client) projectSpanIDs(ctx context.Context) ([]string, error) {
+	var ids []string
+	cursor := ""
+	for {
+		spans, next, err := c.GetSpans(ctx, c.config.ProjectName, WithSpanCursor(cursor))
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range spans {
+			ids = append(ids, s.SpanID)
+		}
+		if next == "" {
+			return ids, nil
+		}
+		cursor = next
+	}
+}
@@ -0,0 +1,74 @@
+package phoenix
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDeleteAnnotationsByName(t *testing.T) {
+	annotations := []map[string]any{
+		spanAnnotationJSON("a1", "s1", "quality", 1, "2025-01-01T00:00:00Z"),
+		spanAnnotationJSON("a2", "s1", "relevance", 1, "2025-01-01T00:00:00Z"),
+		spanAnnotationJSON("a3", "s2", "quality", 0, "2025-02-01T00:00:00Z"),
+		spanAnnotationJSON("a4", "s3", "quality", 0, "2025-03-01T00:00:00Z"),
+	}
+	requests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/projects/test/spans", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("cursor") == "" {
+			writeJSON(t, w, pageJSON([]map[string]any{spanJSON("t1", "s1", nil), spanJSON("t1", "s2", nil)}, "next"))
+			return
+		}
+		writeJSON(t, w, pageJSON([]map[string]any{spanJSON("t2", "s3", nil)}, ""))
+	})
+	mux.HandleFunc("GET /v1/projects/test/span_annotations", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		spanIDs := r.URL.Query()["span_ids"]
+		var page []map[string]any
+		for _, a := range annotations {
+			if slices.Contains(spanIDs, a["span_id"].(string)) {
+				page = append(page, a)
+			}
+		}
+		writeJSON(t, w, pageJSON(page, ""))
+	})
+	c := newTestClient(t, mux)
+
+	tests := []struct {
+		name string
+		opts []DeleteAnnotationOption
+		want int
+	}{
+		{"whole project", nil, 3},
+		{"span filter", []DeleteAnnotationOption{WithSpanIDFilter([]string{"s1", "s2"})}, 2},
+		{"time range", []DeleteAnnotationOption{WithTimeRange(time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := c.DeleteAnnotationsByName(t.Context(), "quality", append(tt.opts, WithDryRun(true))...)
+			if err != nil {
+				t.Fatalf("DeleteAnnotationsByName: %v", err)
+			}
+			if n != tt.want {
+				t.Errorf("count = %d, want %d", n, tt.want)
+			}
+		})
+	}
+
+	requests = 0
+	n, err := c.DeleteAnnotationsByName(t.Context(), "quality")
+	if !errors.Is(err, ErrNotSupported) || n != 0 {
+		t.Errorf("DeleteAnnotationsByName = %d, %v, want 0, ErrNotSupported", n, err)
+	}
+	if requests != 0 {
+		t.Errorf("made %d requests without WithDryRun, want 0", requests)
+	}
+
+	if _, err := c.DeleteAnnotationsByName(t.Context(), "", WithDryRun(true)); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("empty name: err = %v, want ErrInvalidInput", err)
+	}
+}
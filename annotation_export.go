@@ -0,0 +1,148 @@
+package phoenix
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// annotationExportChunkSize is the number of span or trace IDs requested per API call.
+const annotationExportChunkSize = 100
+
+// AnnotationExportOptions selects the annotations to export.
+// Annotations are exported for SpanIDs first, then TraceIDs.
+type AnnotationExportOptions struct {
+	SpanIDs  []string
+	TraceIDs []string
+}
+
+// AnnotationExportOption is a functional option for annotation exports.
+type AnnotationExportOption func(*annotationExportOptions)
+
+type annotationExportOptions struct {
+	limit int
+}
+
+// WithAnnotationExportLimit caps the number of exported annotations.
+// A limit of zero or less exports all matching annotations.
+func WithAnnotationExportLimit(n int) AnnotationExportOption {
+	return func(o *annotationExportOptions) {
+		o.limit = n
+	}
+}
+
+// annotationExportColumns are the CSV header columns, in order.
+var annotationExportColumns = []string{
+	"id", "span_id", "trace_id", "name", "score", "label", "explanation", "source", "created_at",
+}
+
+// annotationRecord is the JSONL representation of an exported annotation.
+type annotationRecord struct {
+	ID          string    `json:"id"`
+	SpanID      string    `json:"span_id,omitempty"`
+	TraceID     string    `json:"trace_id,omitempty"`
+	Name        string    `json:"name"`
+	Score       float64   `json:"score"`
+	Label       string    `json:"label,omitempty"`
+	Explanation string    `json:"explanation,omitempty"`
+	Source      string    `json:"source"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ExportAnnotationsCSV writes the selected annotations to w as CSV, with a
+// header row. Annotations are fetched and written in chunks so the full
+// result set is never held in memory.
+func (c *Client) ExportAnnotationsCSV(ctx context.Context, opts AnnotationExportOptions, w io.Writer, exportOpts ...AnnotationExportOption) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(annotationExportColumns); err != nil {
+		return err
+	}
+
+	err := c.exportAnnotations(ctx, opts, exportOpts, func(a *Annotation) error {
+		return cw.Write([]string{
+			a.ID,
+			a.SpanID,
+			a.TraceID,
+			a.Name,
+			strconv.FormatFloat(a.Score, 'f', -1, 64),
+			a.Label,
+			a.Explanation,
+			string(a.Source),
+			a.CreatedAt.Format(time.RFC3339Nano),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// ExportAnnotationsJSONL writes the selected annotations to w as JSON Lines,
+// one annotation object per line, using the same fields as ExportAnnotationsCSV.
+func (c *Client) ExportAnnotationsJSONL(ctx context.Context, opts AnnotationExportOptions, w io.Writer, exportOpts ...AnnotationExportOption) error {
+	enc := json.NewEncoder(w)
+	return c.exportAnnotations(ctx, opts, exportOpts, func(a *Annotation) error {
+		return enc.Encode(annotationRecord{
+			ID:          a.ID,
+			SpanID:      a.SpanID,
+			TraceID:     a.TraceID,
+			Name:        a.Name,
+			Score:       a.Score,
+			Label:       a.Label,
+			Explanation: a.Explanation,
+			Source:      string(a.Source),
+			CreatedAt:   a.CreatedAt,
+		})
+	})
+}
+
+// exportAnnotations fetches annotations in chunks and passes each to emit
+// until the selection or the limit is exhausted.
+func (c *Client) exportAnnotations(ctx context.Context, opts AnnotationExportOptions, exportOpts []AnnotationExportOption, emit func(*Annotation) error) error {
+	options := &annotationExportOptions{}
+	for _, opt := range exportOpts {
+		opt(options)
+	}
+
+	written := 0
+	emitAll := func(annotations []*Annotation) (bool, error) {
+		for _, a := range annotations {
+			if options.limit > 0 && written >= options.limit {
+				return true, nil
+			}
+			if err := emit(a); err != nil {
+				return false, err
+			}
+			written++
+		}
+		return false, nil
+	}
+
+	for ids := range slices.Chunk(opts.SpanIDs, annotationExportChunkSize) {
+		annotations, err := c.listAllSpanAnnotations(ctx, ids)
+		if err != nil {
+			return err
+		}
+		if done, err := emitAll(annotations); done || err != nil {
+			return err
+		}
+	}
+
+	for ids := range slices.Chunk(opts.TraceIDs, annotationExportChunkSize) {
+		annotations, err := c.ListTraceAnnotations(ctx, ids)
+		if err != nil {
+			return err
+		}
+		if done, err := emitAll(annotations); done || err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
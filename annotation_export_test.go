@@ -0,0 +1,42 @@
+package phoenix
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestExportAnnotationsTracePages(t *testing.T) {
+	// Phoenix pages trace annotations, 10 at a time by default.
+	var annotations []map[string]any
+	traceIDs := make([]string, 0, 30)
+	for i := range 30 {
+		id := fmt.Sprintf("t%d", i)
+		traceIDs = append(traceIDs, id)
+		annotations = append(annotations, traceAnnotationJSON(fmt.Sprintf("a%d", i), id, "quality", "", 1))
+	}
+	var lists int
+	c := newTestClient(t, traceAnnotationsMux(t, annotations, 10, &lists))
+
+	var buf bytes.Buffer
+	if err := c.ExportAnnotationsJSONL(t.Context(), AnnotationExportOptions{TraceIDs: traceIDs}, &buf); err != nil {
+		t.Fatalf("ExportAnnotationsJSONL: %v", err)
+	}
+	var ids []string
+	sc := bufio.NewScanner(&buf)
+	for sc.Scan() {
+		var rec annotationRecord
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			t.Fatalf("decoding %q: %v", sc.Text(), err)
+		}
+		ids = append(ids, rec.ID)
+	}
+	if len(ids) != 30 || ids[0] != "a0" || ids[29] != "a29" {
+		t.Errorf("exported %v, want a0 to a29", ids)
+	}
+	if lists != 3 {
+		t.Errorf("listed %d pages, want 3", lists)
+	}
+}
@@ -0,0 +1,167 @@
+package phoenix
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// defaultAnnotationImportBatchSize is the number of annotations sent per API call.
+const defaultAnnotationImportBatchSize = 100
+
+// AnnotationImportOption is a functional option for annotation imports.
+type AnnotationImportOption func(*annotationImportOptions)
+
+type annotationImportOptions struct {
+	batchSize int
+}
+
+// WithAnnotationImportBatchSize sets the number of annotations sent per API call.
+// Values of zero or less use the default of 100.
+func WithAnnotationImportBatchSize(n int) AnnotationImportOption {
+	return func(o *annotationImportOptions) {
+		if n > 0 {
+			o.batchSize = n
+		}
+	}
+}
+
+// ImportAnnotationsFromCSV reads span annotations from a CSV file and creates
+// them in batches. The first row must be a header; the columns span_id, name
+// and score are required, while label, explanation and source are optional
+// and may appear in any order.
+//
+// Rows with a missing span ID or name, a score outside [0.0, 1.0] or an
+// unknown source are skipped and reported in a *BatchImportError; the
+// remaining rows are still imported. The returned count is the number of
+// annotations successfully created.
+func (c *Client) ImportAnnotationsFromCSV(ctx context.Context, filePath string, opts ...AnnotationImportOption) (int, error) {
+	options := &annotationImportOptions{batchSize: defaultAnnotationImportBatchSize}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return 0, fmt.Errorf("%w: empty CSV file", ErrInvalidInput)
+		}
+		return 0, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"span_id", "name", "score"} {
+		if _, ok := columns[required]; !ok {
+			return 0, fmt.Errorf("%w: missing required column %q", ErrInvalidInput, required)
+		}
+	}
+
+	var (
+		imported  int
+		batch     []*Annotation
+		importErr BatchImportError
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := c.CreateSpanAnnotations(ctx, batch); err != nil {
+			return err
+		}
+		imported += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	for row := 2; ; row++ {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return imported, err
+		}
+
+		a, err := parseAnnotationRecord(record, columns)
+		if err != nil {
+			importErr.Errors = append(importErr.Errors, &RowError{Row: row, Err: err})
+			continue
+		}
+
+		batch = append(batch, a)
+		if len(batch) >= options.batchSize {
+			if err := flush(); err != nil {
+				return imported, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return imported, err
+	}
+
+	if len(importErr.Errors) > 0 {
+		return imported, &importErr
+	}
+	return imported, nil
+}
+
+// parseAnnotationRecord converts a CSV record to an annotation, validating its fields.
+func parseAnnotationRecord(record []string, columns map[string]int) (*Annotation, error) {
+	field := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	a := &Annotation{
+		SpanID:      field("span_id"),
+		Name:        field("name"),
+		Label:       field("label"),
+		Explanation: field("explanation"),
+	}
+	if a.SpanID == "" {
+		return nil, fmt.Errorf("%w: span_id is required", ErrInvalidInput)
+	}
+	if a.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidInput)
+	}
+
+	score, err := strconv.ParseFloat(field("score"), 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid score %q", ErrInvalidInput, field("score"))
+	}
+	if math.IsNaN(score) || score < 0 || score > 1 {
+		return nil, fmt.Errorf("%w: score %v out of range [0.0, 1.0]", ErrInvalidInput, score)
+	}
+	a.Score = score
+
+	switch source := AnnotatorKind(strings.ToUpper(field("source"))); source {
+	case "":
+		a.Source = AnnotatorKindHuman
+	case AnnotatorKindHuman, AnnotatorKindLLM, AnnotatorKindCode:
+		a.Source = source
+	default:
+		return nil, fmt.Errorf("%w: unknown source %q", ErrInvalidInput, field("source"))
+	}
+
+	return a, nil
+}
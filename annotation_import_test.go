@@ -0,0 +1,108 @@
+package phoenix
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTempFile writes content to a file in a test directory and returns
+// its path.
+func writeTempFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestImportAnnotationsFromCSV(t *testing.T) {
+	var batches []annotationRequest
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/span_annotations", func(w http.ResponseWriter, r *http.Request) {
+		var req annotationRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		batches = append(batches, req)
+		writeJSON(t, w, map[string]any{"data": []map[string]any{}})
+	})
+	c := newTestClient(t, mux)
+
+	path := writeTempFile(t, "annotations.csv", ""+
+		"Score,span_id,name,label,source\n"+
+		"0.9,s1,quality,good,llm\n"+
+		"0.5,s2,quality,,\n"+
+		"1.5,s3,quality,,\n"+
+		"0.2,,quality,,\n"+
+		"0.3,s4,quality,,robot\n"+
+		"abc,s5,quality,,\n"+
+		"0,s6,quality,bad,CODE\n")
+
+	n, err := c.ImportAnnotationsFromCSV(t.Context(), path, WithAnnotationImportBatchSize(2))
+	if n != 3 {
+		t.Errorf("imported %d annotations, want 3", n)
+	}
+	var importErr *BatchImportError
+	if !errors.As(err, &importErr) {
+		t.Fatalf("ImportAnnotationsFromCSV = %v, want *BatchImportError", err)
+	}
+	var rows []int
+	for _, re := range importErr.Errors {
+		rows = append(rows, re.Row)
+		if !errors.Is(re, ErrInvalidInput) {
+			t.Errorf("row %d error = %v, want ErrInvalidInput", re.Row, re.Err)
+		}
+	}
+	if len(rows) != 4 || rows[0] != 4 || rows[1] != 5 || rows[2] != 6 || rows[3] != 7 {
+		t.Errorf("invalid rows = %v, want [4 5 6 7]", rows)
+	}
+
+	if len(batches) != 2 || len(batches[0].Data) != 2 || len(batches[1].Data) != 1 {
+		t.Fatalf("batches = %+v, want sizes 2 and 1", batches)
+	}
+	first := batches[0].Data[0]
+	if first.SpanID != "s1" || first.Name != "quality" || first.AnnotatorKind != "LLM" ||
+		first.Result.Score == nil || *first.Result.Score != 0.9 || first.Result.Label != "good" {
+		t.Errorf("first annotation = %+v", first)
+	}
+	if kind := batches[0].Data[1].AnnotatorKind; kind != "HUMAN" {
+		t.Errorf("default annotator kind = %q, want HUMAN", kind)
+	}
+	if kind := batches[1].Data[0].AnnotatorKind; kind != "CODE" {
+		t.Errorf("annotator kind = %q, want CODE", kind)
+	}
+}
+
+func TestImportAnnotationsFromCSVInvalidFile(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	c := newTestClient(t, mux)
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"empty", ""},
+		{"missing column", "span_id,name\ns1,quality\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := c.ImportAnnotationsFromCSV(t.Context(), writeTempFile(t, "annotations.csv", tt.content))
+			if n != 0 || !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("ImportAnnotationsFromCSV = %d, %v, want ErrInvalidInput", n, err)
+			}
+		})
+	}
+
+	if _, err := c.ImportAnnotationsFromCSV(t.Context(), filepath.Join(t.TempDir(), "missing.csv")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("ImportAnnotationsFromCSV(missing) = %v, want %v", err, os.ErrNotExist)
+	}
+}
@@ -0,0 +1,83 @@
+package phoenix
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// annotationRequest is the body of the annotation create endpoints.
+type annotationRequest struct {
+	Data []struct {
+		SpanID        string `json:"span_id"`
+		TraceID       string `json:"trace_id"`
+		Name          string `json:"name"`
+		AnnotatorKind string `json:"annotator_kind"`
+		Result        struct {
+			Score       *float64 `json:"score"`
+			Label       string   `json:"label"`
+			Explanation string   `json:"explanation"`
+		} `json:"result"`
+		Metadata map[string]json.RawMessage `json:"metadata"`
+	} `json:"data"`
+}
+
+func TestCreateAnnotationScore(t *testing.T) {
+	var got annotationRequest
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		got = annotationRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		writeJSON(t, w, map[string]any{"data": []map[string]any{{"id": "a1"}}})
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/span_annotations", handler)
+	mux.HandleFunc("POST /v1/trace_annotations", handler)
+	c := newTestClient(t, mux)
+
+	create := map[string]func(opts ...AnnotationOption) error{
+		"span": func(opts ...AnnotationOption) error {
+			return c.CreateSpanAnnotation(t.Context(), "s1", "quality", 0.5, opts...)
+		},
+		"trace": func(opts ...AnnotationOption) error {
+			return c.CreateTraceAnnotation(t.Context(), "t1", "quality", 0.5, opts...)
+		},
+	}
+	for kind, fn := range create {
+		t.Run(kind, func(t *testing.T) {
+			if err := fn(WithAnnotationLabel("good")); err != nil {
+				t.Fatalf("create: %v", err)
+			}
+			if len(got.Data) != 1 {
+				t.Fatalf("sent %d annotations, want 1", len(got.Data))
+			}
+			d := got.Data[0]
+			if d.Result.Score == nil || *d.Result.Score != 0.5 {
+				t.Errorf("score = %v, want 0.5", d.Result.Score)
+			}
+			if d.Result.Label != "good" {
+				t.Errorf("label = %q, want good", d.Result.Label)
+			}
+			if _, ok := d.Metadata[AnnotationMetadataKeyConfidenceInterval]; ok {
+				t.Errorf("metadata has confidence interval without WithAnnotationScore")
+			}
+
+			if err := fn(WithAnnotationScore(0.8, 0.7, 0.9, 0.95)); err != nil {
+				t.Fatalf("create: %v", err)
+			}
+			d = got.Data[0]
+			if d.Result.Score == nil || *d.Result.Score != 0.8 {
+				t.Errorf("score = %v, want 0.8", d.Result.Score)
+			}
+			var ci ScoreConfidenceInterval
+			if err := json.Unmarshal(d.Metadata[AnnotationMetadataKeyConfidenceInterval], &ci); err != nil {
+				t.Fatalf("decoding confidence interval: %v", err)
+			}
+			want := ScoreConfidenceInterval{Lower: 0.7, Upper: 0.9, Confidence: 0.95}
+			if ci != want {
+				t.Errorf("confidence interval = %+v, want %+v", ci, want)
+			}
+		})
+	}
+}
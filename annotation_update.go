@@ -0,0 +1,176 @@
+package phoenix
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/agentplexus/go-phoenix/internal/api"
+)
+
+// annotationUpdateBatchSize is the number of annotations sent per update
+// request.
+const annotationUpdateBatchSize = 100
+
+// AnnotationUpdate changes the result of an existing span annotation. Nil
+// fields are left unchanged.
+type AnnotationUpdate struct {
+	ID          string
+	Score       *float64
+	Label       *string
+	Explanation *string
+}
+
+// UpdateAnnotations corrects the results of existing span annotations in the
+// client's project, for example to fix the scores written by a faulty
+// evaluator. Updates to the same annotation are applied in order.
+//
+// Phoenix cannot look annotations up by ID, so the annotations of every span
+// in the project are listed, 100 spans at a time, until all updated
+// annotations are found. Each annotation is then sent again, in batches of
+// 100, with its span, name and identifier unchanged, which Phoenix applies
+// as an update in place.
+//
+// Updates that fail do not stop the others: they are returned in a
+// *BatchUpdateError, keyed by annotation ID. Annotations that cannot be
+// found fail with ErrAnnotationNotFound.
+func (c *Client) UpdateAnnotations(ctx context.Context, updates []AnnotationUpdate) error {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	batchErr := &BatchUpdateError{}
+	pending := make(map[string][]AnnotationUpdate)
+	var order []string
+	for _, u := range updates {
+		switch {
+		case u.ID == "":
+			batchErr.Errors = append(batchErr.Errors, &UpdateError{Err: fmt.Errorf("%w: annotation ID is required", ErrInvalidInput)})
+			continue
+		case u.Score == nil && u.Label == nil && u.Explanation == nil:
+			batchErr.Errors = append(batchErr.Errors, &UpdateError{ID: u.ID, Err: fmt.Errorf("%w: update changes no fields", ErrInvalidInput)})
+			continue
+		}
+		if _, ok := pending[u.ID]; !ok {
+			order = append(order, u.ID)
+		}
+		pending[u.ID] = append(pending[u.ID], u)
+	}
+
+	found, err := c.findSpanAnnotations(ctx, pending)
+	if err != nil {
+		return err
+	}
+
+	data := make([]api.SpanAnnotationData, 0, len(found))
+	var dataIDs []string
+	for _, id := range order {
+		a, ok := found[id]
+		if !ok {
+			batchErr.Errors = append(batchErr.Errors, &UpdateError{ID: id, Err: ErrAnnotationNotFound})
+			continue
+		}
+		data = append(data, updatedAnnotationData(a, pending[id]))
+		dataIDs = append(dataIDs, id)
+	}
+
+	for start := 0; start < len(data); start += annotationUpdateBatchSize {
+		end := min(start+annotationUpdateBatchSize, len(data))
+		if err := c.annotateSpans(ctx, data[start:end]); err != nil {
+			for _, id := range dataIDs[start:end] {
+				batchErr.Errors = append(batchErr.Errors, &UpdateError{ID: id, Err: err})
+			}
+		}
+	}
+
+	if len(batchErr.Errors) > 0 {
+		return batchErr
+	}
+	return nil
+}
+
+// findSpanAnnotations returns the annotations of the client's project whose
+// ID is a key of ids, keyed by ID.
+func (c *Client) findSpanAnnotations(ctx context.Context, ids map[string][]AnnotationUpdate) (map[string]*api.SpanAnnotation, error) {
+	found := make(map[string]*api.SpanAnnotation, len(ids))
+	if len(ids) == 0 {
+		return found, nil
+	}
+
+	spanIDs, err := c.projectSpanIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for batch := range slices.Chunk(spanIDs, annotationUpdateBatchSize) {
+		options := defaultListOptions()
+		for {
+			data, next, err := c.spanAnnotationsPage(ctx, batch, options)
+			if err != nil {
+				return nil, err
+			}
+			for i := range data {
+				if _, ok := ids[data[i].ID]; ok {
+					found[data[i].ID] = &data[i]
+				}
+			}
+			if next == "" {
+				break
+			}
+			options.cursor = next
+		}
+		if len(found) == len(ids) {
+			break
+		}
+	}
+	return found, nil
+}
+
+// updatedAnnotationData returns the request data that rewrites a with
+// updates applied.
+func updatedAnnotationData(a *api.SpanAnnotation, updates []AnnotationUpdate) api.SpanAnnotationData {
+	result := a.Result.Value
+	for _, u := range updates {
+		if u.Score != nil {
+			result.Score = api.NewOptNilFloat64(*u.Score)
+		}
+		if u.Label != nil {
+			result.Label = api.NewOptNilString(*u.Label)
+		}
+		if u.Explanation != nil {
+			result.Explanation = api.NewOptNilString(*u.Explanation)
+		}
+	}
+
+	d := api.SpanAnnotationData{
+		SpanID:        a.SpanID,
+		Name:          a.Name,
+		AnnotatorKind: api.SpanAnnotationDataAnnotatorKind(a.AnnotatorKind),
+		Identifier:    a.Identifier,
+		Result:        api.NewOptAnnotationResult(result),
+	}
+	if a.Metadata.Set && !a.Metadata.Null {
+		d.Metadata.SetTo(api.SpanAnnotationDataMetadata(a.Metadata.Value))
+	}
+	return d
+}
+
+// annotateSpans sends span annotations, creating them or updating the
+// existing annotations with the same span, name and identifier.
+func (c *Client) annotateSpans(ctx context.Context, data []api.SpanAnnotationData) error {
+	ctx = withRequestIDRecorder(ctx)
+	res, err := c.apiClient.AnnotateSpans(ctx, &api.AnnotateSpansRequestBody{
+		Data: data,
+	}, api.AnnotateSpansParams{})
+	if err != nil {
+		return err
+	}
+
+	switch res.(type) {
+	case *api.AnnotateSpansResponseBody:
+		return nil
+	case *api.AnnotateSpansNotFound:
+		return ErrSpanNotFound
+	default:
+		return c.unexpectedResponseError(ctx)
+	}
+}
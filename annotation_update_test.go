@@ -0,0 +1,134 @@
+package phoenix
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+// annotationUpdateMux serves one span per annotation, lists annotations by
+// span ID and records annotation requests. Annotation request number failAt
+// (counting from 1) fails with a 404; 0 never fails.
+func annotationUpdateMux(t *testing.T, annotations []map[string]any, requests *[]annotationRequest, failAt int) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/projects/test/spans", func(w http.ResponseWriter, r *http.Request) {
+		spans := make([]map[string]any, 0, len(annotations))
+		for _, a := range annotations {
+			spans = append(spans, spanJSON("t1", a["span_id"].(string), nil))
+		}
+		writeJSON(t, w, pageJSON(spans, ""))
+	})
+	mux.HandleFunc("GET /v1/projects/test/span_annotations", func(w http.ResponseWriter, r *http.Request) {
+		spanIDs := r.URL.Query()["span_ids"]
+		if len(spanIDs) > annotationUpdateBatchSize {
+			t.Errorf("listed annotations of %d spans, want at most %d", len(spanIDs), annotationUpdateBatchSize)
+		}
+		var page []map[string]any
+		for _, a := range annotations {
+			if slices.Contains(spanIDs, a["span_id"].(string)) {
+				page = append(page, a)
+			}
+		}
+		writeJSON(t, w, pageJSON(page, ""))
+	})
+	mux.HandleFunc("POST /v1/span_annotations", func(w http.ResponseWriter, r *http.Request) {
+		var req annotationRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		*requests = append(*requests, req)
+		if len(*requests) == failAt {
+			notFound(w)
+			return
+		}
+		writeJSON(t, w, map[string]any{"data": []map[string]any{}})
+	})
+	return mux
+}
+
+func TestUpdateAnnotations(t *testing.T) {
+	a1 := spanAnnotationJSON("a1", "s1", "quality", 1, "2025-01-01T00:00:00Z")
+	a1["metadata"] = map[string]any{"evaluator": "v1"}
+	annotations := []map[string]any{
+		a1,
+		spanAnnotationJSON("a2", "s2", "quality", 1, "2025-01-01T00:00:00Z"),
+		spanAnnotationJSON("a3", "s3", "quality", 1, "2025-01-01T00:00:00Z"),
+	}
+	var requests []annotationRequest
+	c := newTestClient(t, annotationUpdateMux(t, annotations, &requests, 0))
+
+	score, label, explanation := 0.2, "fixed", "evaluator bug"
+	err := c.UpdateAnnotations(t.Context(), []AnnotationUpdate{
+		{ID: "a1", Score: &score},
+		{ID: "a2", Explanation: &explanation},
+		{ID: "a1", Label: &label},
+		{ID: "a9", Score: &score},
+		{ID: "", Score: &score},
+		{ID: "a3"},
+	})
+
+	var batchErr *BatchUpdateError
+	if !errors.As(err, &batchErr) || len(batchErr.Errors) != 3 {
+		t.Fatalf("UpdateAnnotations = %v, want a *BatchUpdateError with 3 errors", err)
+	}
+	failed := make(map[string]error)
+	for _, e := range batchErr.Errors {
+		failed[e.ID] = e.Err
+	}
+	if !errors.Is(failed[""], ErrInvalidInput) || !errors.Is(failed["a3"], ErrInvalidInput) {
+		t.Errorf("invalid update errors = %v, want ErrInvalidInput", failed)
+	}
+	if !errors.Is(failed["a9"], ErrAnnotationNotFound) {
+		t.Errorf("a9 error = %v, want %v", failed["a9"], ErrAnnotationNotFound)
+	}
+
+	if len(requests) != 1 || len(requests[0].Data) != 2 {
+		t.Fatalf("requests = %+v, want one request updating a1 and a2", requests)
+	}
+	first, second := requests[0].Data[0], requests[0].Data[1]
+	if first.SpanID != "s1" || first.Name != "quality" || first.AnnotatorKind != "CODE" ||
+		first.Result.Score == nil || *first.Result.Score != score || first.Result.Label != label {
+		t.Errorf("a1 update = %+v, want both updates applied", first)
+	}
+	if string(first.Metadata["evaluator"]) != `"v1"` {
+		t.Errorf("a1 metadata = %v, want it kept", first.Metadata)
+	}
+	if second.SpanID != "s2" || second.Result.Score == nil || *second.Result.Score != 1 || second.Result.Explanation != explanation {
+		t.Errorf("a2 update = %+v, want the score kept and the explanation set", second)
+	}
+
+	if err := c.UpdateAnnotations(t.Context(), nil); err != nil {
+		t.Errorf("UpdateAnnotations(nil) = %v", err)
+	}
+}
+
+func TestUpdateAnnotationsBatches(t *testing.T) {
+	const n = annotationUpdateBatchSize + 50
+	annotations := make([]map[string]any, 0, n)
+	updates := make([]AnnotationUpdate, 0, n)
+	score := 0.5
+	for i := range n {
+		id := fmt.Sprintf("a%d", i)
+		annotations = append(annotations, spanAnnotationJSON(id, fmt.Sprintf("s%d", i), "quality", 1, "2025-01-01T00:00:00Z"))
+		updates = append(updates, AnnotationUpdate{ID: id, Score: &score})
+	}
+	var requests []annotationRequest
+	c := newTestClient(t, annotationUpdateMux(t, annotations, &requests, 2))
+
+	err := c.UpdateAnnotations(t.Context(), updates)
+	if len(requests) != 2 || len(requests[0].Data) != annotationUpdateBatchSize || len(requests[1].Data) != 50 {
+		t.Fatalf("sent %d requests, want batches of %d and 50", len(requests), annotationUpdateBatchSize)
+	}
+
+	// Only the annotations of the failed batch are reported.
+	var batchErr *BatchUpdateError
+	if !errors.As(err, &batchErr) || len(batchErr.Errors) != 50 {
+		t.Fatalf("UpdateAnnotations = %v, want 50 failed updates", err)
+	}
+	if e := batchErr.Errors[0]; e.ID != fmt.Sprintf("a%d", annotationUpdateBatchSize) || !errors.Is(e, ErrSpanNotFound) {
+		t.Errorf("first failure = %v, want a%d with %v", e, annotationUpdateBatchSize, ErrSpanNotFound)
+	}
+}
@@ -0,0 +1,61 @@
+package phoenix
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// BillingPeriod is the time range usage is reported for.
+type BillingPeriod struct {
+	Start time.Time
+	End   time.Time
+}
+
+// CurrentBillingPeriod returns the calendar month containing now, in UTC.
+func CurrentBillingPeriod() BillingPeriod {
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return BillingPeriod{Start: start, End: start.AddDate(0, 1, 0)}
+}
+
+// BillingMetrics is the usage of a Phoenix Cloud workspace during a
+// billing period.
+type BillingMetrics struct {
+	SpanCount           int64
+	AnnotationCount     int64
+	DatasetExampleCount int64
+	StorageGB           float64
+
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+}
+
+// GetWorkspaceBillingMetrics returns the usage of a Phoenix Cloud workspace
+// during period.
+//
+// Phoenix does not currently expose an endpoint for usage or billing data,
+// so this returns ErrNotSupported after validating its arguments. It is
+// expected to map onto a request of the form
+//
+//	GET /v1/workspaces/{workspace_id}/usage?start={rfc3339}&end={rfc3339}
+//
+// whose response reports span_count, annotation_count,
+// dataset_example_count, storage_gb, period_start and period_end, matching
+// the fields of BillingMetrics. Until then, GetQuotaStatus reports the rate
+// limits that apply to the current client.
+func (c *Client) GetWorkspaceBillingMetrics(ctx context.Context, workspaceID string, period BillingPeriod) (*BillingMetrics, error) {
+	if workspaceID == "" {
+		return nil, fmt.Errorf("%w: workspace ID is required", ErrInvalidInput)
+	}
+	if period.Start.IsZero() || period.End.IsZero() {
+		return nil, fmt.Errorf("%w: billing period start and end are required", ErrInvalidInput)
+	}
+	if !period.End.After(period.Start) {
+		return nil, fmt.Errorf("%w: billing period ends before it starts", ErrInvalidInput)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("%w: billing metrics for workspace %q", ErrNotSupported, workspaceID)
+}
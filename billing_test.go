@@ -0,0 +1,60 @@
+package phoenix
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetWorkspaceBillingMetrics(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	c := newTestClient(t, mux)
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	month := BillingPeriod{Start: start, End: start.AddDate(0, 1, 0)}
+	canceled, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		workspaceID string
+		period      BillingPeriod
+		wantErr     error
+	}{
+		{"not supported", t.Context(), "ws1", month, ErrNotSupported},
+		{"missing workspace", t.Context(), "", month, ErrInvalidInput},
+		{"missing end", t.Context(), "ws1", BillingPeriod{Start: start}, ErrInvalidInput},
+		{"reversed", t.Context(), "ws1", BillingPeriod{Start: month.End, End: month.Start}, ErrInvalidInput},
+		{"empty", t.Context(), "ws1", BillingPeriod{Start: start, End: start}, ErrInvalidInput},
+		{"canceled", canceled, "ws1", month, context.Canceled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := c.GetWorkspaceBillingMetrics(tt.ctx, tt.workspaceID, tt.period)
+			if m != nil || !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetWorkspaceBillingMetrics = %+v, %v, want %v", m, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCurrentBillingPeriod(t *testing.T) {
+	p := CurrentBillingPeriod()
+	now := time.Now().UTC()
+	if p.Start.After(now) || !p.End.After(now) {
+		t.Errorf("CurrentBillingPeriod = %v to %v, want it to contain %v", p.Start, p.End, now)
+	}
+	if p.Start.Day() != 1 || p.Start.Hour() != 0 || p.Start.Location() != time.UTC {
+		t.Errorf("Start = %v, want midnight UTC on the first of the month", p.Start)
+	}
+	if !p.End.Equal(p.Start.AddDate(0, 1, 0)) {
+		t.Errorf("End = %v, want one month after %v", p.End, p.Start)
+	}
+}
@@ -9,9 +9,14 @@
 package phoenix
 
 import (
+	"context"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"sync"
 
 	"github.com/agentplexus/go-phoenix/internal/api"
+	phoenixotel "github.com/agentplexus/go-phoenix/otel"
 )
 
 // Version is the SDK version.
@@ -19,8 +24,23 @@ const Version = "0.1.0"
 
 // Client is the main Phoenix client for interacting with the API.
 type Client struct {
-	config    *Config
-	apiClient *api.Client
+	config     *Config
+	apiClient  *api.Client
+	retrier    *retrier
+	quota      *quotaTracker
+	calls      *callTracker
+	requestIDs *requestIDTracker
+	limiter    *concurrencyLimiter
+	http       *authHTTPClient
+
+	// datasetsByName caches GetDatasetByName lookups.
+	datasetsByName sync.Map // name -> *datasetCacheEntry
+
+	// projects caches the project list for SearchProjects.
+	projects projectListCache
+
+	// spanStatusCounts caches GetSpanStatusCounts results.
+	spanStatusCounts spanStatusCache
 }
 
 // NewClient creates a new Phoenix client with the given options.
@@ -41,11 +61,37 @@ func NewClient(opts ...Option) (*Client, error) {
 			Timeout: options.timeout,
 		}
 	}
+	if t := options.transport; t != nil {
+		transport, err := phoenixotel.NewHTTPTransport(t.MaxIdleConns, t.MaxIdleConnsPerHost, t.MaxConnsPerHost, t.HTTP2)
+		if err != nil {
+			return nil, err
+		}
+		withTransport := *httpClient
+		withTransport.Transport = transport
+		httpClient = &withTransport
+	}
+	limiter := newConcurrencyLimiter(httpClient.Transport, options.maxConcurrentRequests)
+	limited := *httpClient
+	limited.Transport = limiter
+	httpClient = &limited
+
+	var r *retrier
+	if options.retryPolicy != nil {
+		r = newRetrier(*options.retryPolicy)
+	}
+
+	quota := &quotaTracker{}
+	calls := &callTracker{}
+	requestIDs := &requestIDTracker{}
 
 	// Wrap with auth transport
 	authClient := &authHTTPClient{
-		client: httpClient,
-		apiKey: options.config.APIKey,
+		client:     httpClient,
+		apiKey:     options.config.APIKey,
+		retrier:    r,
+		quota:      quota,
+		calls:      calls,
+		requestIDs: requestIDs,
 	}
 
 	// Create the ogen client
@@ -57,16 +103,44 @@ func NewClient(opts ...Option) (*Client, error) {
 		return nil, err
 	}
 
-	return &Client{
-		config:    options.config,
-		apiClient: apiClient,
-	}, nil
+	c := &Client{
+		config:     options.config,
+		apiClient:  apiClient,
+		retrier:    r,
+		quota:      quota,
+		calls:      calls,
+		requestIDs: requestIDs,
+		limiter:    limiter,
+		http:       authClient,
+	}
+
+	if options.warmUpCtx != nil {
+		if err := c.warmUp(options.warmUpCtx); err != nil {
+			if options.warmUpRequired {
+				return nil, fmt.Errorf("phoenix: warm-up failed: %w", err)
+			}
+			slog.Warn("phoenix: warm-up failed", "url", options.config.URL, "error", err)
+		}
+	}
+
+	return c, nil
+}
+
+// warmUp sends a minimal request to establish a pooled connection, paying
+// the TCP and TLS handshake cost before the first real request.
+func (c *Client) warmUp(ctx context.Context) error {
+	_, _, err := c.ListProjects(ctx, WithLimit(1))
+	return err
 }
 
 // authHTTPClient wraps an http.Client to add authentication headers.
 type authHTTPClient struct {
-	client *http.Client
-	apiKey string
+	client     *http.Client
+	apiKey     string
+	retrier    *retrier
+	quota      *quotaTracker
+	calls      *callTracker
+	requestIDs *requestIDTracker
 }
 
 // Do implements ht.Client interface.
@@ -80,7 +154,20 @@ func (c *authHTTPClient) Do(req *http.Request) (*http.Response, error) {
 	req.Header.Set("X-Phoenix-SDK-Version", Version)
 	req.Header.Set("X-Phoenix-SDK-Lang", "go")
 
-	return c.client.Do(req)
+	var resp *http.Response
+	var err error
+	if c.retrier != nil {
+		resp, err = c.retrier.do(c.client, req)
+	} else {
+		resp, err = c.client.Do(req)
+	}
+	c.quota.observe(resp)
+	c.calls.observe(resp, err)
+	c.requestIDs.observe(resp)
+	if rec := requestIDRecorderFrom(req.Context()); rec != nil {
+		rec.observe(resp)
+	}
+	return resp, err
 }
 
 // Config returns the client configuration.
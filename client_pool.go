@@ -0,0 +1,106 @@
+package phoenix
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// poolHealthCheckTimeout bounds the HEAD request Get sends before handing
+// out a pooled client.
+const poolHealthCheckTimeout = 2 * time.Second
+
+// ClientPool reuses Clients, and with them their HTTP connections, across
+// short-lived callers such as serverless function invocations. Clients are
+// created lazily with the pool's options and are safe to share; Put only
+// makes a client available for reuse.
+type ClientPool struct {
+	opts []Option
+
+	mu   sync.Mutex
+	idle []*Client
+}
+
+// NewClientPool returns a pool of clients created with NewClient(opts...).
+func NewClientPool(opts ...Option) *ClientPool {
+	return &ClientPool{opts: opts}
+}
+
+// Get returns a pooled client, or a new one if the pool is empty. Before a
+// pooled client is handed out, a HEAD request to the server checks that it
+// can still reach Phoenix; a client that fails the check is evicted, its
+// idle connections are closed and a new client is returned instead. Get
+// returns an error if ctx is done or a new client cannot be created, for
+// example because the pool's options are invalid.
+func (p *ClientPool) Get(ctx context.Context) (*Client, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	if n := len(p.idle); n > 0 {
+		c := p.idle[n-1]
+		p.idle[n-1] = nil
+		p.idle = p.idle[:n-1]
+		p.mu.Unlock()
+		if c.healthy(ctx) {
+			return c, nil
+		}
+		c.CloseIdleConnections()
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return NewClient(p.opts...)
+	}
+	p.mu.Unlock()
+
+	return NewClient(p.opts...)
+}
+
+// Put returns c to the pool for reuse. c must not be used afterwards.
+func (p *ClientPool) Put(c *Client) {
+	if c == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.idle = append(p.idle, c)
+}
+
+// Close closes the idle connections of the pooled clients and empties the
+// pool. Clients currently checked out are unaffected.
+func (p *ClientPool) Close() {
+	p.mu.Lock()
+	idle := p.idle
+	p.idle = nil
+	p.mu.Unlock()
+
+	for _, c := range idle {
+		c.CloseIdleConnections()
+	}
+}
+
+// healthy reports whether a HEAD request to the server gets a response
+// other than a server error.
+func (c *Client) healthy(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, poolHealthCheckTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, c.config.BaseURL(), nil)
+	if err != nil {
+		return false
+	}
+	resp, err := c.http.client.Do(req)
+	if err != nil {
+		return false
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode < http.StatusInternalServerError
+}
+
+// CloseIdleConnections closes the idle connections of the client's HTTP
+// transport.
+func (c *Client) CloseIdleConnections() {
+	c.http.client.CloseIdleConnections()
+}
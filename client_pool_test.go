@@ -0,0 +1,140 @@
+package phoenix
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// newPoolTestServer returns a server answering the pool's health checks,
+// with status as the response code, and counts HEAD requests.
+func newPoolTestServer(t *testing.T, status *atomic.Int32, heads *atomic.Int32) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		heads.Add(1)
+		w.WriteHeader(int(status.Load()))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestClientPoolReuse(t *testing.T) {
+	var status, heads atomic.Int32
+	status.Store(http.StatusOK)
+	srv := newPoolTestServer(t, &status, &heads)
+	p := NewClientPool(WithURL(srv.URL))
+
+	c1, err := p.Get(t.Context())
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	p.Put(c1)
+	c2, err := p.Get(t.Context())
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if c2 != c1 {
+		t.Error("Get did not reuse the pooled client")
+	}
+	if heads.Load() != 1 {
+		t.Errorf("sent %d health checks, want 1 for the pooled client", heads.Load())
+	}
+	c3, err := p.Get(t.Context())
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if c3 == c1 {
+		t.Error("Get returned a client that is already checked out")
+	}
+
+	p.Put(c2)
+	p.Put(c3)
+	p.Close()
+	c4, err := p.Get(t.Context())
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if c4 == c2 || c4 == c3 {
+		t.Error("Get returned a client after Close")
+	}
+}
+
+func TestClientPoolErrors(t *testing.T) {
+	p := NewClientPool(WithURL(""))
+	if c, err := p.Get(t.Context()); err == nil || c != nil {
+		t.Errorf("Get with invalid options = %v, %v, want error", c, err)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	p = NewClientPool(WithURL("http://localhost:6006"))
+	if _, err := p.Get(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Get with cancelled context = %v, want context.Canceled", err)
+	}
+}
+
+func TestClientPoolHealthCheck(t *testing.T) {
+	var status, heads atomic.Int32
+	status.Store(http.StatusOK)
+	srv := newPoolTestServer(t, &status, &heads)
+	p := NewClientPool(WithURL(srv.URL))
+
+	c1, err := p.Get(t.Context())
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	// A server error fails the check.
+	status.Store(http.StatusServiceUnavailable)
+	p.Put(c1)
+	c2, err := p.Get(t.Context())
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if c2 == c1 {
+		t.Error("Get reused a client that failed the health check")
+	}
+
+	// So does a server that has gone away.
+	status.Store(http.StatusOK)
+	p.Put(c2)
+	srv.Close()
+	c3, err := p.Get(t.Context())
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if c3 == c2 {
+		t.Error("Get reused a client after the server went down")
+	}
+	p.Put(c3)
+	if c4, err := p.Get(t.Context()); err != nil || c4 == c3 {
+		t.Errorf("Get = %p, %v, want a client other than the evicted %p", c4, err, c3)
+	}
+}
+
+func TestClientPoolConcurrent(t *testing.T) {
+	var status, heads atomic.Int32
+	status.Store(http.StatusOK)
+	srv := newPoolTestServer(t, &status, &heads)
+	p := NewClientPool(WithURL(srv.URL))
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c, err := p.Get(t.Context())
+			if err != nil {
+				t.Errorf("Get: %v", err)
+				return
+			}
+			p.Put(c)
+		}()
+	}
+	wg.Wait()
+}
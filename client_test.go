@@ -0,0 +1,196 @@
+package phoenix
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// newTestClient returns a client for a test server that serves mux.
+func newTestClient(t *testing.T, mux *http.ServeMux, opts ...Option) *Client {
+	t.Helper()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	opts = append([]Option{WithURL(srv.URL), WithProjectName("test")}, opts...)
+	c, err := NewClient(opts...)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return c
+}
+
+// writeJSON writes v as a JSON response.
+func writeJSON(t *testing.T, w http.ResponseWriter, v any) {
+	t.Helper()
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		t.Errorf("encoding response: %v", err)
+	}
+}
+
+// notFound writes the plain text 404 response Phoenix sends for missing
+// resources.
+func notFound(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusNotFound)
+	_, _ = w.Write([]byte("not found"))
+}
+
+// examplesResponse is the body of GET /v1/datasets/{id}/examples.
+func examplesResponse(datasetID, versionID string, examples ...map[string]any) map[string]any {
+	if examples == nil {
+		examples = []map[string]any{}
+	}
+	return map[string]any{"data": map[string]any{
+		"dataset_id": datasetID,
+		"version_id": versionID,
+		"examples":   examples,
+	}}
+}
+
+// exampleJSON is a dataset example as returned by the API.
+func exampleJSON(id string, input, output map[string]any) map[string]any {
+	if input == nil {
+		input = map[string]any{}
+	}
+	if output == nil {
+		output = map[string]any{}
+	}
+	return map[string]any{
+		"id":         id,
+		"input":      input,
+		"output":     output,
+		"metadata":   map[string]any{},
+		"updated_at": "2025-01-01T00:00:00Z",
+	}
+}
+
+// experimentJSON is an experiment as returned by the API, with no missing
+// runs.
+func experimentJSON(id string, examples, succeeded, failed int) map[string]any {
+	return map[string]any{
+		"id":                   id,
+		"dataset_id":           "ds1",
+		"dataset_version_id":   "v1",
+		"repetitions":          1,
+		"metadata":             map[string]any{},
+		"project_name":         nil,
+		"created_at":           "2025-01-01T00:00:00Z",
+		"updated_at":           "2025-01-01T00:00:00Z",
+		"example_count":        examples,
+		"successful_run_count": succeeded,
+		"failed_run_count":     failed,
+		"missing_run_count":    0,
+	}
+}
+
+// spanJSON is a span as returned by the API.
+func spanJSON(traceID, spanID string, attributes map[string]any) map[string]any {
+	if attributes == nil {
+		attributes = map[string]any{}
+	}
+	return map[string]any{
+		"id":          spanID,
+		"name":        "span-" + spanID,
+		"context":     map[string]any{"trace_id": traceID, "span_id": spanID},
+		"span_kind":   "LLM",
+		"parent_id":   nil,
+		"start_time":  "2025-01-01T00:00:00Z",
+		"end_time":    "2025-01-01T00:00:01Z",
+		"status_code": "OK",
+		"attributes":  attributes,
+		"events":      []any{},
+	}
+}
+
+// spanAnnotationJSON is a span annotation as returned by the API.
+func spanAnnotationJSON(id, spanID, name string, score float64, createdAt string) map[string]any {
+	return map[string]any{
+		"id":             id,
+		"span_id":        spanID,
+		"name":           name,
+		"annotator_kind": "CODE",
+		"source":         "API",
+		"user_id":        nil,
+		"result":         map[string]any{"score": score},
+		"metadata":       map[string]any{},
+		"identifier":     "",
+		"created_at":     createdAt,
+		"updated_at":     createdAt,
+	}
+}
+
+// traceAnnotationJSON is a trace annotation as returned by the API.
+func traceAnnotationJSON(id, traceID, name, label string, score float64) map[string]any {
+	return map[string]any{
+		"id":             id,
+		"trace_id":       traceID,
+		"name":           name,
+		"annotator_kind": "CODE",
+		"source":         "API",
+		"user_id":        nil,
+		"result":         map[string]any{"score": score, "label": label},
+		"metadata":       map[string]any{},
+		"identifier":     label,
+		"created_at":     "2025-01-01T00:00:00Z",
+		"updated_at":     "2025-01-01T00:00:00Z",
+	}
+}
+
+// traceAnnotationsMux serves the trace annotations of the requested trace
+// IDs in pages of pageSize and counts list requests.
+func traceAnnotationsMux(t *testing.T, annotations []map[string]any, pageSize int, lists *int) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/projects/{project}/trace_annotations", func(w http.ResponseWriter, r *http.Request) {
+		*lists++
+		traceIDs := r.URL.Query()["trace_ids"]
+		var matching []map[string]any
+		for _, a := range annotations {
+			if slices.Contains(traceIDs, a["trace_id"].(string)) {
+				matching = append(matching, a)
+			}
+		}
+		offset := 0
+		if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+			offset, _ = strconv.Atoi(cursor)
+		}
+		end := min(offset+pageSize, len(matching))
+		next := ""
+		if end < len(matching) {
+			next = strconv.Itoa(end)
+		}
+		writeJSON(t, w, pageJSON(matching[offset:end], next))
+	})
+	return mux
+}
+
+// pageJSON is a page of a cursor-paginated list response.
+func pageJSON[T any](data []T, next string) map[string]any {
+	if data == nil {
+		data = []T{}
+	}
+	resp := map[string]any{"data": data, "next_cursor": nil}
+	if next != "" {
+		resp["next_cursor"] = next
+	}
+	return resp
+}
+
+// promptVersionJSON is a string-template prompt version as returned by the
+// API.
+func promptVersionJSON(id, template string) map[string]any {
+	return map[string]any{
+		"id":                    id,
+		"description":           nil,
+		"model_provider":        "OPENAI",
+		"model_name":            "gpt-4o",
+		"template":              map[string]any{"type": "string", "template": template},
+		"template_type":         "STR",
+		"template_format":       "MUSTACHE",
+		"invocation_parameters": map[string]any{"type": "openai", "openai": map[string]any{}},
+	}
+}
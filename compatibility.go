@@ -0,0 +1,244 @@
+package phoenix
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	phoenixotel "github.com/agentplexus/go-phoenix/otel"
+)
+
+// MinimumServerVersion is the oldest Phoenix server version this SDK
+// supports.
+const MinimumServerVersion = "8.0.0"
+
+// serverVersionPath is the Phoenix endpoint that reports the server version
+// as plain text. It is not part of the REST API specification.
+const serverVersionPath = "/arize_phoenix_version"
+
+// featureRequirement is the server version that introduced an API feature
+// used by the SDK.
+type featureRequirement struct {
+	feature    string
+	minVersion string
+}
+
+// featureRequirements lists features that need a newer server than
+// MinimumServerVersion.
+var featureRequirements = []featureRequirement{
+	{feature: "project management endpoints", minVersion: "9.0.0"},
+	{feature: "span and trace annotation listing", minVersion: "10.0.0"},
+	{feature: "annotation configs", minVersion: "10.0.0"},
+}
+
+// jsonAttributeMinVersion is the oldest Phoenix server version that renders
+// JSON-encoded input, output and metadata values. Older servers get raw
+// strings under phoenixotel.EncodingAuto.
+const jsonAttributeMinVersion = "10.0.0"
+
+// APICompatibilityReport describes whether the Phoenix server is compatible
+// with this SDK.
+type APICompatibilityReport struct {
+	ServerVersion string
+	SDKVersion    string
+	// Compatible reports whether the server is at least MinimumServerVersion.
+	Compatible bool
+	// Warnings lists features that may not be available on the server.
+	Warnings []string
+}
+
+// CheckAPICompatibility fetches the Phoenix server version and compares it
+// with MinimumServerVersion and the versions that introduced the features
+// the SDK uses.
+func (c *Client) CheckAPICompatibility(ctx context.Context) (*APICompatibilityReport, error) {
+	serverVersion, err := c.serverVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &APICompatibilityReport{
+		ServerVersion: serverVersion,
+		SDKVersion:    Version,
+	}
+
+	server, ok := parseVersion(serverVersion)
+	if !ok {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("unrecognized server version %q", serverVersion))
+		return report, nil
+	}
+
+	minimum, _ := parseVersion(MinimumServerVersion)
+	report.Compatible = compareVersions(server, minimum) >= 0
+	for _, req := range featureRequirements {
+		required, _ := parseVersion(req.minVersion)
+		if compareVersions(server, required) < 0 {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("%s require Phoenix %s or later", req.feature, req.minVersion))
+		}
+	}
+	return report, nil
+}
+
+// AttributeEncoding returns the span attribute encoding suited to the
+// server: phoenixotel.EncodingString for servers older than 10.0.0 and
+// phoenixotel.EncodingJSON otherwise, including when the version is not
+// recognized. Use it to resolve phoenixotel.EncodingAuto.
+func (r *APICompatibilityReport) AttributeEncoding() phoenixotel.AttributeEncoding {
+	server, ok := parseVersion(r.ServerVersion)
+	if !ok {
+		return phoenixotel.EncodingJSON
+	}
+	required, _ := parseVersion(jsonAttributeMinVersion)
+	if compareVersions(server, required) < 0 {
+		return phoenixotel.EncodingString
+	}
+	return phoenixotel.EncodingJSON
+}
+
+// compatibilityJSON is the compatibility matrix of SDK and Phoenix server
+// versions.
+//
+//go:embed compatibility.json
+var compatibilityJSON []byte
+
+// compatibilityRecord is an entry of compatibility.json. An empty
+// MaxPhoenixVersion means no newer server is known to be incompatible.
+type compatibilityRecord struct {
+	SDKVersion        string `json:"sdk_version"`
+	MinPhoenixVersion string `json:"min_phoenix_version"`
+	MaxPhoenixVersion string `json:"max_phoenix_version"`
+	Notes             string `json:"notes"`
+}
+
+// CompatibilityResult is the compatibility of an SDK version with a Phoenix
+// server version, according to the SDK's compatibility matrix.
+type CompatibilityResult struct {
+	SDKVersion     string
+	PhoenixVersion string
+	// MinPhoenixVersion and MaxPhoenixVersion bound the supported server
+	// versions, inclusive. MaxPhoenixVersion is empty when there is no
+	// known upper bound.
+	MinPhoenixVersion string
+	MaxPhoenixVersion string
+	Compatible        bool
+	Notes             string
+}
+
+// Compatibility looks up whether sdkVersion supports the Phoenix server
+// version phoenixVersion in the compatibility matrix embedded in the SDK.
+// It returns ErrInvalidInput if either version cannot be parsed or the
+// matrix has no entry for sdkVersion.
+func Compatibility(sdkVersion, phoenixVersion string) (CompatibilityResult, error) {
+	sdk, ok := parseVersion(sdkVersion)
+	if !ok {
+		return CompatibilityResult{}, fmt.Errorf("%w: unrecognized SDK version %q", ErrInvalidInput, sdkVersion)
+	}
+	server, ok := parseVersion(phoenixVersion)
+	if !ok {
+		return CompatibilityResult{}, fmt.Errorf("%w: unrecognized Phoenix version %q", ErrInvalidInput, phoenixVersion)
+	}
+
+	var records []compatibilityRecord
+	if err := json.Unmarshal(compatibilityJSON, &records); err != nil {
+		return CompatibilityResult{}, fmt.Errorf("phoenix: decoding compatibility matrix: %w", err)
+	}
+	for _, rec := range records {
+		if v, _ := parseVersion(rec.SDKVersion); compareVersions(v, sdk) != 0 {
+			continue
+		}
+		result := CompatibilityResult{
+			SDKVersion:        sdkVersion,
+			PhoenixVersion:    phoenixVersion,
+			MinPhoenixVersion: rec.MinPhoenixVersion,
+			MaxPhoenixVersion: rec.MaxPhoenixVersion,
+			Notes:             rec.Notes,
+		}
+		minimum, _ := parseVersion(rec.MinPhoenixVersion)
+		result.Compatible = compareVersions(server, minimum) >= 0
+		if maximum, ok := parseVersion(rec.MaxPhoenixVersion); ok {
+			result.Compatible = result.Compatible && compareVersions(server, maximum) <= 0
+		}
+		return result, nil
+	}
+	return CompatibilityResult{}, fmt.Errorf("%w: no compatibility data for SDK version %q", ErrInvalidInput, sdkVersion)
+}
+
+// CompatibilityCheck fetches the Phoenix server version with
+// CheckAPICompatibility and looks up its compatibility with this SDK version
+// in the compatibility matrix.
+func (c *Client) CompatibilityCheck(ctx context.Context) (*CompatibilityResult, error) {
+	report, err := c.CheckAPICompatibility(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result, err := Compatibility(report.SDKVersion, report.ServerVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// serverVersion fetches the server version string.
+func (c *Client) serverVersion(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.config.BaseURL()+serverVersionPath, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", &APIError{StatusCode: resp.StatusCode, Message: "fetching server version", RequestID: requestIDFromHeader(resp.Header)}
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 256))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+// parseVersion parses the major, minor and patch numbers of a version such
+// as "10.2.1" or "v10.2.1rc1". Missing components are zero and any
+// pre-release suffix is ignored.
+func parseVersion(s string) ([3]int, bool) {
+	var v [3]int
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	parts := strings.SplitN(s, ".", 3)
+	for i, part := range parts {
+		end := strings.IndexFunc(part, func(r rune) bool { return r < '0' || r > '9' })
+		if end == 0 {
+			return v, false
+		}
+		if end > 0 {
+			part = part[:end]
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return v, false
+		}
+		v[i] = n
+	}
+	return v, true
+}
+
+// compareVersions returns -1, 0 or 1 as a is older than, equal to or newer than b.
+func compareVersions(a, b [3]int) int {
+	for i := range a {
+		switch {
+		case a[i] < b[i]:
+			return -1
+		case a[i] > b[i]:
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,113 @@
+package phoenix
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	phoenixotel "github.com/agentplexus/go-phoenix/otel"
+)
+
+// newVersionTestClient returns a client for a server that reports version.
+func newVersionTestClient(t *testing.T, version string) *Client {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /arize_phoenix_version", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte(version + "\n"))
+	})
+	return newTestClient(t, mux)
+}
+
+func TestCheckAPICompatibility(t *testing.T) {
+	tests := []struct {
+		version    string
+		compatible bool
+		warnings   int
+	}{
+		{"7.5.0", false, 3},
+		{"8.0.0", true, 3},
+		{"9.1.0", true, 2},
+		{"10.2.1rc1", true, 0},
+		{"v11", true, 0},
+		{"dev", false, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			c := newVersionTestClient(t, tt.version)
+			report, err := c.CheckAPICompatibility(t.Context())
+			if err != nil {
+				t.Fatalf("CheckAPICompatibility: %v", err)
+			}
+			if report.ServerVersion != tt.version || report.SDKVersion != Version {
+				t.Errorf("versions = %q, %q, want %q, %q", report.ServerVersion, report.SDKVersion, tt.version, Version)
+			}
+			if report.Compatible != tt.compatible {
+				t.Errorf("Compatible = %v, want %v", report.Compatible, tt.compatible)
+			}
+			if len(report.Warnings) != tt.warnings {
+				t.Errorf("Warnings = %q, want %d", report.Warnings, tt.warnings)
+			}
+		})
+	}
+}
+
+func TestCheckAPICompatibilityError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /arize_phoenix_version", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1")
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	c := newTestClient(t, mux)
+
+	_, err := c.CheckAPICompatibility(t.Context())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway || apiErr.RequestID != "req-1" {
+		t.Errorf("CheckAPICompatibility = %v, want a 502 *APIError with the request ID", err)
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		in   string
+		want [3]int
+		ok   bool
+	}{
+		{"10.2.1", [3]int{10, 2, 1}, true},
+		{" v9.1 ", [3]int{9, 1, 0}, true},
+		{"11.0.0rc2", [3]int{11, 0, 0}, true},
+		{"8.0.0.dev1", [3]int{8, 0, 0}, true},
+		{"", [3]int{}, false},
+		{"latest", [3]int{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseVersion(tt.in)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("parseVersion(%q) = %v, %v, want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	if compareVersions([3]int{9, 10, 0}, [3]int{10, 0, 0}) != -1 ||
+		compareVersions([3]int{10, 1, 0}, [3]int{10, 0, 9}) != 1 ||
+		compareVersions([3]int{8, 0, 0}, [3]int{8, 0, 0}) != 0 {
+		t.Error("compareVersions does not order versions numerically")
+	}
+}
+
+func TestReportAttributeEncoding(t *testing.T) {
+	tests := []struct {
+		version string
+		want    phoenixotel.AttributeEncoding
+	}{
+		{"9.9.9", phoenixotel.EncodingString},
+		{"10.0.0", phoenixotel.EncodingJSON},
+		{"12.1.0", phoenixotel.EncodingJSON},
+		{"dev", phoenixotel.EncodingJSON},
+	}
+	for _, tt := range tests {
+		r := &APICompatibilityReport{ServerVersion: tt.version}
+		if got := r.AttributeEncoding(); got != tt.want {
+			t.Errorf("AttributeEncoding for %q = %q, want %q", tt.version, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package phoenix
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultMaxConcurrentRequests is the default limit on in-flight API
+// requests. See WithMaxConcurrentRequests.
+const DefaultMaxConcurrentRequests = 10
+
+// concurrencyLimiter is an http.RoundTripper that bounds the number of
+// requests in flight. A request holds its slot until its response body is
+// closed, or until it fails.
+type concurrencyLimiter struct {
+	next     http.RoundTripper
+	sem      chan struct{} // nil when unlimited
+	inFlight atomic.Int64
+}
+
+func newConcurrencyLimiter(next http.RoundTripper, limit int) *concurrencyLimiter {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	l := &concurrencyLimiter{next: next}
+	if limit > 0 {
+		l.sem = make(chan struct{}, limit)
+	}
+	return l
+}
+
+// RoundTrip waits for a free slot, or for the request context to end, and
+// then sends the request.
+func (l *concurrencyLimiter) RoundTrip(req *http.Request) (*http.Response, error) {
+	if l.sem != nil {
+		select {
+		case l.sem <- struct{}{}:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+	l.inFlight.Add(1)
+
+	resp, err := l.next.RoundTrip(req)
+	if err != nil {
+		l.release()
+		return nil, err
+	}
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: l.release}
+	return resp, nil
+}
+
+func (l *concurrencyLimiter) release() {
+	l.inFlight.Add(-1)
+	if l.sem != nil {
+		<-l.sem
+	}
+}
+
+// releasingBody releases a concurrency slot when the body is first closed.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// ConcurrentRequestsInFlight returns the number of API requests currently
+// in flight, including those whose response body has not been closed yet.
+func (c *Client) ConcurrentRequestsInFlight() int {
+	return int(c.limiter.inFlight.Load())
+}
+
+// CloseIdleConnections closes the idle connections of the wrapped transport.
+func (l *concurrencyLimiter) CloseIdleConnections() {
+	if ci, ok := l.next.(interface{ CloseIdleConnections() }); ok {
+		ci.CloseIdleConnections()
+	}
+}
@@ -2,26 +2,39 @@ package phoenix
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/agentplexus/go-phoenix/internal/api"
+	"github.com/go-faster/jx"
 )
 
+// MetadataKeyArchived is the metadata key used to mark a resource as archived.
+const MetadataKeyArchived = "archived"
+
 // Dataset represents a Phoenix dataset.
 type Dataset struct {
 	ID           string
 	Name         string
 	Description  string
 	ExampleCount int
+	Metadata     map[string]any
+	Archived     bool
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 }
 
 // DatasetExample represents an example in a dataset.
+// ID and UpdatedAt are set on examples read back from Phoenix.
 type DatasetExample struct {
-	Input    any            `json:"input,omitempty"`
-	Output   any            `json:"output,omitempty"`
-	Metadata map[string]any `json:"metadata,omitempty"`
+	ID        string         `json:"id,omitempty"`
+	Input     any            `json:"input,omitempty"`
+	Output    any            `json:"output,omitempty"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+	UpdatedAt time.Time      `json:"updated_at,omitzero"`
 }
 
 // DatasetOption is a functional option for dataset operations.
@@ -29,6 +42,7 @@ type DatasetOption func(*datasetOptions)
 
 type datasetOptions struct {
 	description string
+	progress    func(uploaded, total int)
 }
 
 // WithDatasetDescription sets the dataset description.
@@ -40,6 +54,7 @@ func WithDatasetDescription(desc string) DatasetOption {
 
 // ListDatasets lists all datasets.
 func (c *Client) ListDatasets(ctx context.Context, opts ...ListOption) ([]*Dataset, string, error) { //nolint:dupl // Type-safe pattern differs only in types
+	ctx = withRequestIDRecorder(ctx)
 	options := defaultListOptions()
 	for _, opt := range opts {
 		opt(options)
@@ -60,12 +75,16 @@ func (c *Client) ListDatasets(ctx context.Context, opts ...ListOption) ([]*Datas
 
 	resp, ok := res.(*api.ListDatasetsResponseBody)
 	if !ok {
-		return nil, "", &APIError{Message: "unexpected response type"}
+		return nil, "", c.unexpectedResponseError(ctx)
 	}
 
 	datasets := make([]*Dataset, 0, len(resp.Data))
 	for i := range resp.Data {
-		datasets = append(datasets, convertDataset(&resp.Data[i]))
+		ds := convertDataset(&resp.Data[i])
+		if ds.Archived && !options.includeArchived {
+			continue
+		}
+		datasets = append(datasets, ds)
 	}
 
 	var nextCursor string
@@ -78,6 +97,7 @@ func (c *Client) ListDatasets(ctx context.Context, opts ...ListOption) ([]*Datas
 
 // CreateDataset creates a new dataset with the given examples.
 func (c *Client) CreateDataset(ctx context.Context, name string, examples []DatasetExample, opts ...DatasetOption) (*Dataset, error) {
+	ctx = withRequestIDRecorder(ctx)
 	options := &datasetOptions{}
 	for _, opt := range opts {
 		opt(options)
@@ -109,7 +129,7 @@ func (c *Client) CreateDataset(ctx context.Context, name string, examples []Data
 
 	resp, ok := res.(*api.UploadDatasetResponseBody)
 	if !ok {
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, c.unexpectedResponseError(ctx)
 	}
 
 	return &Dataset{
@@ -162,6 +182,7 @@ func convertExamplesToAPIFormat(examples []DatasetExample) (
 
 // GetDataset retrieves a dataset by ID.
 func (c *Client) GetDataset(ctx context.Context, id string) (*Dataset, error) {
+	ctx = withRequestIDRecorder(ctx)
 	res, err := c.apiClient.GetDataset(ctx, api.GetDatasetParams{
 		ID: id,
 	})
@@ -169,15 +190,57 @@ func (c *Client) GetDataset(ctx context.Context, id string) (*Dataset, error) {
 		return nil, err
 	}
 
-	resp, ok := res.(*api.GetDatasetResponseBody)
-	if !ok {
-		return nil, &APIError{Message: "unexpected response type"}
+	switch resp := res.(type) {
+	case *api.GetDatasetResponseBody:
+		return convertDataset(fullDataset(&resp.Data)), nil
+	case *api.GetDatasetNotFound:
+		return nil, ErrDatasetNotFound
+	default:
+		return nil, c.unexpectedResponseError(ctx)
 	}
+}
 
-	return &Dataset{
-		ID:   resp.Data.ID,
-		Name: resp.Data.Name,
-	}, nil
+// GetDatasetExampleCount returns the number of examples in a dataset without
+// fetching the examples themselves.
+func (c *Client) GetDatasetExampleCount(ctx context.Context, datasetID string) (int, error) {
+	ds, err := c.GetDataset(ctx, datasetID)
+	if err != nil {
+		return 0, err
+	}
+	return ds.ExampleCount, nil
+}
+
+// GetDatasetExampleCount returns the example count cached when the dataset
+// was fetched. Use Client.GetDatasetExampleCount for an up-to-date count.
+func (d *Dataset) GetDatasetExampleCount() int {
+	return d.ExampleCount
+}
+
+// ArchiveDataset marks a dataset as archived instead of deleting it.
+//
+// Phoenix does not currently expose an endpoint for updating dataset
+// metadata, so this returns ErrNotSupported. Datasets whose metadata already
+// carries the archived flag are still hidden from ListDatasets unless
+// WithIncludeArchived(true) is passed.
+func (c *Client) ArchiveDataset(ctx context.Context, datasetID string) error {
+	return c.setDatasetArchived(ctx, datasetID, true)
+}
+
+// RestoreDataset clears the archived flag on a dataset.
+// See ArchiveDataset for current API limitations.
+func (c *Client) RestoreDataset(ctx context.Context, datasetID string) error {
+	return c.setDatasetArchived(ctx, datasetID, false)
+}
+
+func (c *Client) setDatasetArchived(ctx context.Context, datasetID string, archived bool) error {
+	ds, err := c.GetDataset(ctx, datasetID)
+	if err != nil {
+		return err
+	}
+	if ds.Archived == archived {
+		return nil
+	}
+	return fmt.Errorf("%w: updating metadata for dataset %q", ErrNotSupported, datasetID)
 }
 
 // DeleteDataset deletes a dataset by ID.
@@ -185,9 +248,250 @@ func (c *Client) DeleteDataset(ctx context.Context, id string) error {
 	_, err := c.apiClient.DeleteDatasetById(ctx, api.DeleteDatasetByIdParams{
 		ID: id,
 	})
+	c.datasetsByName.Range(func(name, entry any) bool {
+		if entry.(*datasetCacheEntry).dataset.ID == id {
+			c.datasetsByName.Delete(name)
+		}
+		return true
+	})
 	return err
 }
 
+// datasetNameCacheTTL is how long GetDatasetByName caches a lookup.
+const datasetNameCacheTTL = 5 * time.Minute
+
+// datasetCacheEntry is a dataset cached by GetDatasetByName.
+type datasetCacheEntry struct {
+	dataset *Dataset
+	expires time.Time
+}
+
+// GetDatasetByName retrieves a dataset by name, including archived datasets.
+//
+// Phoenix has no lookup by name, so this pages through ListDatasets until a
+// dataset with the name is found. Successful lookups are cached for five
+// minutes; the cached dataset's ExampleCount may be stale within that time.
+// Returns ErrDatasetNotFound if no dataset has the name.
+func (c *Client) GetDatasetByName(ctx context.Context, name string) (*Dataset, error) {
+	if entry, ok := c.datasetsByName.Load(name); ok {
+		cached := entry.(*datasetCacheEntry)
+		if time.Now().Before(cached.expires) {
+			ds := *cached.dataset
+			return &ds, nil
+		}
+		c.datasetsByName.Delete(name)
+	}
+
+	cursor := ""
+	for {
+		datasets, next, err := c.ListDatasets(ctx, WithCursor(cursor), WithIncludeArchived(true))
+		if err != nil {
+			return nil, err
+		}
+		for _, ds := range datasets {
+			if ds.Name == name {
+				cached := *ds
+				c.datasetsByName.Store(name, &datasetCacheEntry{
+					dataset: &cached,
+					expires: time.Now().Add(datasetNameCacheTTL),
+				})
+				return ds, nil
+			}
+		}
+		if next == "" {
+			return nil, fmt.Errorf("%w: %q", ErrDatasetNotFound, name)
+		}
+		cursor = next
+	}
+}
+
+// ListDatasetExamples lists the examples in the latest version of a dataset.
+//
+// The Phoenix examples endpoint returns the whole dataset in one response, so
+// WithCursor and WithLimit are applied client-side. The returned cursor is an
+// opaque offset to pass to WithCursor for the next page.
+func (c *Client) ListDatasetExamples(ctx context.Context, datasetID string, opts ...ListOption) ([]*DatasetExample, string, error) {
+	options := defaultListOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	examples, err := c.getDatasetExamples(ctx, datasetID)
+	if err != nil {
+		return nil, "", err
+	}
+	return pageExamples(examples, options)
+}
+
+// SearchDatasetExamples returns examples whose input, output or metadata
+// contain query (case-insensitive).
+//
+// Phoenix has no search endpoint for dataset examples, so matching is done
+// client-side over the JSON encoding of each example. Paging options behave
+// as in ListDatasetExamples and apply to the matching examples.
+func (c *Client) SearchDatasetExamples(ctx context.Context, datasetID, query string, opts ...ListOption) ([]*DatasetExample, string, error) {
+	options := defaultListOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	examples, err := c.getDatasetExamples(ctx, datasetID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	query = strings.ToLower(query)
+	matches := examples[:0]
+	for _, ex := range examples {
+		if exampleContains(ex, query) {
+			matches = append(matches, ex)
+		}
+	}
+	return pageExamples(matches, options)
+}
+
+// FilterDatasetExamples returns all examples for which fn returns true.
+// Filtering is done client-side over a single fetch of the dataset. WithCursor
+// starts filtering at the given offset; WithLimit is ignored since all
+// matching examples are returned.
+func (c *Client) FilterDatasetExamples(ctx context.Context, datasetID string, fn func(*DatasetExample) bool, opts ...ListOption) ([]*DatasetExample, error) {
+	options := defaultListOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+	options.limit = 0
+
+	examples, err := c.getDatasetExamples(ctx, datasetID)
+	if err != nil {
+		return nil, err
+	}
+	examples, _, err = pageExamples(examples, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*DatasetExample
+	for _, ex := range examples {
+		if fn(ex) {
+			matches = append(matches, ex)
+		}
+	}
+	return matches, nil
+}
+
+// getDatasetExamples fetches all examples in the latest version of a dataset.
+func (c *Client) getDatasetExamples(ctx context.Context, datasetID string) ([]*DatasetExample, error) {
+	return c.getDatasetExamplesAtVersion(ctx, datasetID, "")
+}
+
+// getDatasetExamplesAtVersion fetches the examples of a dataset version.
+// An empty versionID selects the latest version.
+func (c *Client) getDatasetExamplesAtVersion(ctx context.Context, datasetID, versionID string) ([]*DatasetExample, error) {
+	examples, _, err := c.getDatasetVersionExamples(ctx, datasetID, versionID)
+	return examples, err
+}
+
+// getDatasetVersionExamples is getDatasetExamplesAtVersion, also returning
+// the ID of the version the examples belong to.
+func (c *Client) getDatasetVersionExamples(ctx context.Context, datasetID, versionID string) ([]*DatasetExample, string, error) {
+	ctx = withRequestIDRecorder(ctx)
+	params := api.GetDatasetExamplesParams{
+		ID: datasetID,
+	}
+	if versionID != "" {
+		params.VersionID = api.NewOptNilString(versionID)
+	}
+
+	res, err := c.apiClient.GetDatasetExamples(ctx, params)
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch resp := res.(type) {
+	case *api.ListDatasetExamplesResponseBody:
+		examples := make([]*DatasetExample, 0, len(resp.Data.Examples))
+		for i := range resp.Data.Examples {
+			examples = append(examples, convertDatasetExample(&resp.Data.Examples[i]))
+		}
+		return examples, resp.Data.VersionID, nil
+	case *api.GetDatasetExamplesNotFound:
+		return nil, "", ErrDatasetNotFound
+	default:
+		return nil, "", c.unexpectedResponseError(ctx)
+	}
+}
+
+// pageExamples applies offset-based cursor and limit options to examples.
+func pageExamples(examples []*DatasetExample, options *listOptions) ([]*DatasetExample, string, error) {
+	offset := 0
+	if options.cursor != "" {
+		n, err := strconv.Atoi(options.cursor)
+		if err != nil || n < 0 {
+			return nil, "", ErrInvalidInput
+		}
+		offset = n
+	}
+	if offset >= len(examples) {
+		return []*DatasetExample{}, "", nil
+	}
+
+	end := len(examples)
+	if options.limit > 0 && offset+options.limit < end {
+		end = offset + options.limit
+	}
+
+	var nextCursor string
+	if end < len(examples) {
+		nextCursor = strconv.Itoa(end)
+	}
+	return examples[offset:end], nextCursor, nil
+}
+
+// exampleContains reports whether the JSON encoding of an example contains
+// the lower-cased query.
+func exampleContains(ex *DatasetExample, query string) bool {
+	for _, v := range []any{ex.Input, ex.Output, ex.Metadata} {
+		data, err := json.Marshal(v)
+		if err != nil {
+			continue
+		}
+		if strings.Contains(strings.ToLower(string(data)), query) {
+			return true
+		}
+	}
+	return false
+}
+
+func convertDatasetExample(e *api.DatasetExample) *DatasetExample {
+	ex := &DatasetExample{
+		ID:        e.ID,
+		Metadata:  convertRawMap(e.Metadata),
+		UpdatedAt: e.UpdatedAt,
+	}
+	// Leave Input/Output as untyped nil rather than a nil map when empty.
+	if input := convertRawMap(e.Input); input != nil {
+		ex.Input = input
+	}
+	if output := convertRawMap(e.Output); output != nil {
+		ex.Output = output
+	}
+	return ex
+}
+
+// fullDataset converts the single-dataset response into the list item shape
+// so both share convertDataset.
+func fullDataset(d *api.DatasetWithExampleCount) *api.Dataset {
+	return &api.Dataset{
+		CreatedAt:    d.CreatedAt,
+		Description:  d.Description,
+		ExampleCount: d.ExampleCount,
+		ID:           d.ID,
+		Metadata:     api.DatasetMetadata(d.Metadata),
+		Name:         d.Name,
+		UpdatedAt:    d.UpdatedAt,
+	}
+}
+
 func convertDataset(d *api.Dataset) *Dataset {
 	if d == nil {
 		return nil
@@ -196,11 +500,48 @@ func convertDataset(d *api.Dataset) *Dataset {
 		ID:           d.ID,
 		Name:         d.Name,
 		ExampleCount: d.ExampleCount,
+		Metadata:     convertRawMap(d.Metadata),
 		CreatedAt:    d.CreatedAt,
 		UpdatedAt:    d.UpdatedAt,
 	}
 	if !d.Description.Null {
 		dataset.Description = d.Description.Value
 	}
+	dataset.Archived = isArchived(dataset.Metadata)
 	return dataset
 }
+
+// convertRawMap decodes a map of raw JSON values into Go values.
+// Values that fail to decode are skipped.
+func convertRawMap[M ~map[string]jx.Raw](m M) map[string]any {
+	if len(m) == 0 {
+		return nil
+	}
+	result := make(map[string]any, len(m))
+	for k, raw := range m {
+		var v any
+		if err := json.Unmarshal(raw, &v); err == nil {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// encodeRawMap encodes Go values into a map of raw JSON values.
+func encodeRawMap[M ~map[string]jx.Raw](m map[string]any) (M, error) {
+	result := make(M, len(m))
+	for k, v := range m {
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("phoenix: encoding %q: %w", k, err)
+		}
+		result[k] = jx.Raw(raw)
+	}
+	return result, nil
+}
+
+// isArchived reports whether the metadata carries a truthy archived flag.
+func isArchived(metadata map[string]any) bool {
+	archived, _ := metadata[MetadataKeyArchived].(bool)
+	return archived
+}
@@ -0,0 +1,165 @@
+package phoenix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/agentplexus/go-phoenix/internal/api"
+)
+
+// DatasetExampleRevision is the state of a dataset example in a dataset version
+// where it was added or changed.
+type DatasetExampleRevision struct {
+	// RevisionID is the ID of the dataset version that introduced this revision.
+	RevisionID string
+	CreatedAt  time.Time
+	Input      any
+	Output     any
+	Metadata   map[string]any
+	// ChangedFields lists which of "input", "output" and "metadata" differ
+	// from the previous revision. It lists all three for the first revision.
+	ChangedFields []string
+}
+
+// ListDatasetExampleRevisions returns the revision history of a dataset
+// example, oldest first.
+//
+// Phoenix has no per-example history endpoint. Every upload or append creates
+// a new dataset version, so revisions are derived by fetching the examples of
+// each dataset version and keeping the versions in which the example was
+// added or changed. This issues one request per dataset version.
+func (c *Client) ListDatasetExampleRevisions(ctx context.Context, datasetID, exampleID string) ([]*DatasetExampleRevision, error) {
+	versions, err := c.listDatasetVersions(ctx, datasetID)
+	if err != nil {
+		return nil, err
+	}
+
+	var revisions []*DatasetExampleRevision
+	var prev *DatasetExample
+	for _, v := range versions {
+		examples, err := c.getDatasetExamplesAtVersion(ctx, datasetID, v.VersionID)
+		if err != nil {
+			return nil, err
+		}
+
+		ex := findDatasetExample(examples, exampleID)
+		if ex == nil {
+			prev = nil
+			continue
+		}
+
+		changed := changedExampleFields(prev, ex)
+		prev = ex
+		if len(changed) == 0 {
+			continue
+		}
+
+		revisions = append(revisions, &DatasetExampleRevision{
+			RevisionID:    v.VersionID,
+			CreatedAt:     v.CreatedAt,
+			Input:         ex.Input,
+			Output:        ex.Output,
+			Metadata:      ex.Metadata,
+			ChangedFields: changed,
+		})
+	}
+
+	if len(revisions) == 0 {
+		return nil, ErrDatasetExampleNotFound
+	}
+	return revisions, nil
+}
+
+// GetDatasetExampleAtRevision returns a dataset example as it was in the given
+// revision, which is a dataset version ID.
+func (c *Client) GetDatasetExampleAtRevision(ctx context.Context, datasetID, exampleID, revisionID string) (*DatasetExample, error) {
+	examples, err := c.getDatasetExamplesAtVersion(ctx, datasetID, revisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	ex := findDatasetExample(examples, exampleID)
+	if ex == nil {
+		return nil, ErrDatasetExampleNotFound
+	}
+	return ex, nil
+}
+
+// listDatasetVersions returns all versions of a dataset, oldest first.
+func (c *Client) listDatasetVersions(ctx context.Context, datasetID string) ([]api.DatasetVersion, error) {
+	ctx = withRequestIDRecorder(ctx)
+	var versions []api.DatasetVersion
+	params := api.ListDatasetVersionsByDatasetIdParams{
+		ID:    datasetID,
+		Limit: api.NewOptInt(defaultListOptions().limit),
+	}
+
+	for {
+		res, err := c.apiClient.ListDatasetVersionsByDatasetId(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, ok := res.(*api.ListDatasetVersionsResponseBody)
+		if !ok {
+			return nil, c.unexpectedResponseError(ctx)
+		}
+		versions = append(versions, resp.Data...)
+
+		if resp.NextCursor.Null || resp.NextCursor.Value == "" {
+			break
+		}
+		params.Cursor = api.NewOptNilString(resp.NextCursor.Value)
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].CreatedAt.Before(versions[j].CreatedAt)
+	})
+	return versions, nil
+}
+
+// findDatasetExample returns the example with the given ID, or nil.
+func findDatasetExample(examples []*DatasetExample, exampleID string) *DatasetExample {
+	for _, ex := range examples {
+		if ex.ID == exampleID {
+			return ex
+		}
+	}
+	return nil
+}
+
+// changedExampleFields reports which example fields differ between prev and
+// cur. A nil prev means every field is new.
+func changedExampleFields(prev, cur *DatasetExample) []string {
+	if prev == nil {
+		return []string{"input", "output", "metadata"}
+	}
+
+	var changed []string
+	if !jsonEqual(prev.Input, cur.Input) {
+		changed = append(changed, "input")
+	}
+	if !jsonEqual(prev.Output, cur.Output) {
+		changed = append(changed, "output")
+	}
+	if !jsonEqual(prev.Metadata, cur.Metadata) {
+		changed = append(changed, "metadata")
+	}
+	return changed
+}
+
+// jsonEqual reports whether a and b have the same JSON encoding.
+func jsonEqual(a, b any) bool {
+	aj, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	bj, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(aj, bj)
+}
@@ -0,0 +1,118 @@
+package phoenix
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// newRevisionTestClient serves a dataset ds1 with five versions, returned
+// out of order over two pages. Example ex1 is added in v1, unchanged in v2,
+// changed in v3, removed in v4 and added back unchanged in v5.
+func newRevisionTestClient(t *testing.T) *Client {
+	t.Helper()
+	versionJSON := func(id, createdAt string) map[string]any {
+		return map[string]any{"version_id": id, "description": nil, "metadata": map[string]any{}, "created_at": createdAt}
+	}
+	original := exampleJSON("ex1", map[string]any{"q": "a"}, map[string]any{"a": 1})
+	changed := exampleJSON("ex1", map[string]any{"q": "a"}, map[string]any{"a": 2})
+	changed["metadata"] = map[string]any{"tag": "x"}
+	other := exampleJSON("ex2", map[string]any{"q": "b"}, nil)
+	examples := map[string][]map[string]any{
+		"v1": {original},
+		"v2": {original, other},
+		"v3": {other, changed},
+		"v4": {other},
+		"v5": {changed},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/datasets/{id}/versions", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "" {
+			writeJSON(t, w, pageJSON([]map[string]any{
+				versionJSON("v3", "2025-01-03T00:00:00Z"),
+				versionJSON("v1", "2025-01-01T00:00:00Z"),
+				versionJSON("v5", "2025-01-05T00:00:00Z"),
+			}, "next"))
+			return
+		}
+		writeJSON(t, w, pageJSON([]map[string]any{
+			versionJSON("v4", "2025-01-04T00:00:00Z"),
+			versionJSON("v2", "2025-01-02T00:00:00Z"),
+		}, ""))
+	})
+	mux.HandleFunc("GET /v1/datasets/{id}/examples", func(w http.ResponseWriter, r *http.Request) {
+		versionID := r.URL.Query().Get("version_id")
+		data, ok := examples[versionID]
+		if r.PathValue("id") != "ds1" || !ok {
+			notFound(w)
+			return
+		}
+		writeJSON(t, w, examplesResponse("ds1", versionID, data...))
+	})
+	return newTestClient(t, mux)
+}
+
+func TestListDatasetExampleRevisions(t *testing.T) {
+	c := newRevisionTestClient(t)
+
+	revisions, err := c.ListDatasetExampleRevisions(t.Context(), "ds1", "ex1")
+	if err != nil {
+		t.Fatalf("ListDatasetExampleRevisions: %v", err)
+	}
+
+	all := []string{"input", "output", "metadata"}
+	want := []struct {
+		id      string
+		changed []string
+	}{
+		{"v1", all},
+		{"v3", []string{"output", "metadata"}},
+		{"v5", all},
+	}
+	if len(revisions) != len(want) {
+		t.Fatalf("got %d revisions, want %d: %+v", len(revisions), len(want), revisions)
+	}
+	for i, w := range want {
+		r := revisions[i]
+		if r.RevisionID != w.id || !reflect.DeepEqual(r.ChangedFields, w.changed) {
+			t.Errorf("revision %d = %s %v, want %s %v", i, r.RevisionID, r.ChangedFields, w.id, w.changed)
+		}
+	}
+	if !revisions[0].CreatedAt.Before(revisions[1].CreatedAt) {
+		t.Errorf("revisions not oldest first: %v, %v", revisions[0].CreatedAt, revisions[1].CreatedAt)
+	}
+	if out, _ := revisions[1].Output.(map[string]any); out["a"] != float64(2) {
+		t.Errorf("revision v3 output = %v, want a=2", revisions[1].Output)
+	}
+	if revisions[1].Metadata["tag"] != "x" {
+		t.Errorf("revision v3 metadata = %v, want tag=x", revisions[1].Metadata)
+	}
+
+	if _, err := c.ListDatasetExampleRevisions(t.Context(), "ds1", "missing"); !errors.Is(err, ErrDatasetExampleNotFound) {
+		t.Errorf("ListDatasetExampleRevisions(missing example) = %v, want %v", err, ErrDatasetExampleNotFound)
+	}
+	if _, err := c.ListDatasetExampleRevisions(t.Context(), "other", "ex1"); !errors.Is(err, ErrDatasetNotFound) {
+		t.Errorf("ListDatasetExampleRevisions(missing dataset) = %v, want %v", err, ErrDatasetNotFound)
+	}
+}
+
+func TestGetDatasetExampleAtRevision(t *testing.T) {
+	c := newRevisionTestClient(t)
+
+	ex, err := c.GetDatasetExampleAtRevision(t.Context(), "ds1", "ex1", "v1")
+	if err != nil {
+		t.Fatalf("GetDatasetExampleAtRevision: %v", err)
+	}
+	if out, _ := ex.Output.(map[string]any); ex.ID != "ex1" || out["a"] != float64(1) {
+		t.Errorf("GetDatasetExampleAtRevision = %+v, want ex1 with a=1", ex)
+	}
+
+	if _, err := c.GetDatasetExampleAtRevision(t.Context(), "ds1", "ex1", "v4"); !errors.Is(err, ErrDatasetExampleNotFound) {
+		t.Errorf("GetDatasetExampleAtRevision(removed) = %v, want %v", err, ErrDatasetExampleNotFound)
+	}
+	if _, err := c.GetDatasetExampleAtRevision(t.Context(), "ds1", "ex1", "v9"); !errors.Is(err, ErrDatasetNotFound) {
+		t.Errorf("GetDatasetExampleAtRevision(missing version) = %v, want %v", err, ErrDatasetNotFound)
+	}
+}
@@ -0,0 +1,120 @@
+package phoenix
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// datasetSnapshotFormatVersion is the version of the JSON format written by
+// DatasetSnapshot.Save.
+const datasetSnapshotFormatVersion = 1
+
+// DatasetSnapshot is a local copy of a dataset version and all its examples,
+// for running experiments against data that does not change underneath
+// them. Snapshots are not stored in Phoenix.
+type DatasetSnapshot struct {
+	// SnapshotID identifies the snapshot. It is unique per SnapshotDataset
+	// call.
+	SnapshotID string
+
+	// DatasetID is the ID of the dataset the snapshot was taken from.
+	DatasetID string
+
+	// DatasetVersionID is the ID of the dataset version the examples belong
+	// to. Pass it to WithExperimentDatasetVersion to run an experiment over
+	// exactly these examples.
+	DatasetVersionID string
+
+	// CreatedAt is when the snapshot was taken.
+	CreatedAt time.Time
+
+	// Examples holds the examples of the dataset version.
+	Examples []DatasetExample
+}
+
+// SnapshotDataset fetches every example in the latest version of a dataset
+// and returns them as a snapshot.
+func (c *Client) SnapshotDataset(ctx context.Context, datasetID string) (*DatasetSnapshot, error) {
+	if datasetID == "" {
+		return nil, fmt.Errorf("%w: dataset ID is required", ErrInvalidInput)
+	}
+
+	examples, versionID, err := c.getDatasetVersionExamples(ctx, datasetID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &DatasetSnapshot{
+		SnapshotID:       rand.Text(),
+		DatasetID:        datasetID,
+		DatasetVersionID: versionID,
+		CreatedAt:        time.Now().UTC(),
+		Examples:         make([]DatasetExample, 0, len(examples)),
+	}
+	for _, ex := range examples {
+		snapshot.Examples = append(snapshot.Examples, *ex)
+	}
+	return snapshot, nil
+}
+
+// ToExamples returns a copy of the snapshot's examples, in the order
+// Phoenix returned them. Changing the copy does not change the snapshot.
+func (s *DatasetSnapshot) ToExamples() []DatasetExample {
+	examples := make([]DatasetExample, len(s.Examples))
+	copy(examples, s.Examples)
+	return examples
+}
+
+type datasetSnapshotFile struct {
+	FormatVersion    int              `json:"format_version"`
+	SnapshotID       string           `json:"snapshot_id"`
+	DatasetID        string           `json:"dataset_id"`
+	DatasetVersionID string           `json:"dataset_version_id,omitempty"`
+	CreatedAt        time.Time        `json:"created_at"`
+	Examples         []DatasetExample `json:"examples"`
+}
+
+// Save writes the snapshot to path as indented JSON.
+func (s *DatasetSnapshot) Save(path string) error {
+	data, err := json.MarshalIndent(datasetSnapshotFile{
+		FormatVersion:    datasetSnapshotFormatVersion,
+		SnapshotID:       s.SnapshotID,
+		DatasetID:        s.DatasetID,
+		DatasetVersionID: s.DatasetVersionID,
+		CreatedAt:        s.CreatedAt,
+		Examples:         s.Examples,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding dataset snapshot: %w", err)
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// LoadDatasetSnapshot reads a snapshot written by DatasetSnapshot.Save.
+// Example inputs, outputs and metadata are decoded as generic JSON values.
+func LoadDatasetSnapshot(path string) (*DatasetSnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file datasetSnapshotFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("decoding dataset snapshot: %w", err)
+	}
+	if file.FormatVersion != datasetSnapshotFormatVersion {
+		return nil, fmt.Errorf("%w: unsupported dataset snapshot format version %d", ErrInvalidInput, file.FormatVersion)
+	}
+
+	return &DatasetSnapshot{
+		SnapshotID:       file.SnapshotID,
+		DatasetID:        file.DatasetID,
+		DatasetVersionID: file.DatasetVersionID,
+		CreatedAt:        file.CreatedAt,
+		Examples:         file.Examples,
+	}, nil
+}
@@ -0,0 +1,100 @@
+package phoenix
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDatasetSnapshotRoundTrip(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/datasets/{id}/examples", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") != "ds1" {
+			notFound(w)
+			return
+		}
+		writeJSON(t, w, examplesResponse("ds1", "v7",
+			exampleJSON("ex1", map[string]any{"q": "a", "n": 1}, map[string]any{"a": []any{"x"}}),
+			exampleJSON("ex2", map[string]any{"q": "b"}, nil),
+		))
+	})
+	c := newTestClient(t, mux)
+
+	snapshot, err := c.SnapshotDataset(t.Context(), "ds1")
+	if err != nil {
+		t.Fatalf("SnapshotDataset: %v", err)
+	}
+	if snapshot.DatasetID != "ds1" || snapshot.DatasetVersionID != "v7" || snapshot.SnapshotID == "" || len(snapshot.Examples) != 2 {
+		t.Fatalf("SnapshotDataset = %+v", snapshot)
+	}
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	if err := snapshot.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	loaded, err := LoadDatasetSnapshot(path)
+	if err != nil {
+		t.Fatalf("LoadDatasetSnapshot: %v", err)
+	}
+
+	if loaded.SnapshotID != snapshot.SnapshotID || loaded.DatasetID != "ds1" || loaded.DatasetVersionID != "v7" {
+		t.Errorf("loaded snapshot = %+v, want IDs of %+v", loaded, snapshot)
+	}
+	if !loaded.CreatedAt.Equal(snapshot.CreatedAt) {
+		t.Errorf("CreatedAt = %v, want %v", loaded.CreatedAt, snapshot.CreatedAt)
+	}
+	// Numbers are decoded as float64, as in the original API response.
+	if !reflect.DeepEqual(loaded.Examples, snapshot.Examples) {
+		t.Errorf("Examples = %+v, want %+v", loaded.Examples, snapshot.Examples)
+	}
+
+	if _, err := c.SnapshotDataset(t.Context(), "missing"); !errors.Is(err, ErrDatasetNotFound) {
+		t.Errorf("SnapshotDataset(missing) = %v, want %v", err, ErrDatasetNotFound)
+	}
+}
+
+func TestLoadDatasetSnapshotErrors(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr error
+	}{
+		{"future version", write("v2.json", `{"format_version": 2, "dataset_id": "ds1", "examples": []}`), ErrInvalidInput},
+		{"missing version", write("v0.json", `{"dataset_id": "ds1", "examples": []}`), ErrInvalidInput},
+		{"missing file", filepath.Join(dir, "missing.json"), os.ErrNotExist},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := LoadDatasetSnapshot(tt.path)
+			if !errors.Is(err, tt.wantErr) || s != nil {
+				t.Errorf("LoadDatasetSnapshot = %v, %v, want %v", s, err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := LoadDatasetSnapshot(write("bad.json", `{"format_version": `)); err == nil {
+		t.Error("LoadDatasetSnapshot(invalid JSON) = nil error")
+	}
+}
+
+func TestDatasetSnapshotToExamples(t *testing.T) {
+	s := &DatasetSnapshot{Examples: []DatasetExample{{ID: "ex1", UpdatedAt: time.Unix(0, 0)}}}
+	examples := s.ToExamples()
+	examples[0].ID = "changed"
+	if s.Examples[0].ID != "ex1" {
+		t.Error("changing ToExamples result changed the snapshot")
+	}
+}
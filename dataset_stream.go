@@ -0,0 +1,201 @@
+package phoenix
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// datasetStreamChunkSize is the number of rows uploaded per request by
+// UploadDatasetStream.
+const datasetStreamChunkSize = 500
+
+// DatasetFormat is the encoding of a dataset stream.
+type DatasetFormat string
+
+const (
+	// DatasetFormatJSONL is one JSON object per line with input, output and
+	// metadata fields, as in DatasetExample.
+	DatasetFormatJSONL DatasetFormat = "jsonl"
+
+	// DatasetFormatCSV is CSV with a header row. The input and output
+	// columns hold the example input and output, decoded as JSON when they
+	// are valid JSON and kept as strings otherwise. A metadata column holds
+	// a JSON object; any other column is added to the example metadata.
+	DatasetFormatCSV DatasetFormat = "csv"
+)
+
+// WithProgressCallback sets a function called after each chunk of a streamed
+// upload with the number of rows uploaded so far. The total is -1 because
+// the length of a stream is not known in advance.
+func WithProgressCallback(fn func(uploaded, total int)) DatasetOption {
+	return func(o *datasetOptions) {
+		o.progress = fn
+	}
+}
+
+// UploadDatasetStream creates a dataset from a stream of examples without
+// holding the whole stream in memory. Rows are read and uploaded in chunks
+// of 500: the first chunk creates the dataset and later chunks are appended
+// to it.
+//
+// If reading or uploading fails after the dataset was created, the dataset
+// is returned together with a *BatchUploadError reporting how many rows were
+// uploaded.
+func (c *Client) UploadDatasetStream(ctx context.Context, name string, r io.Reader, format DatasetFormat, opts ...DatasetOption) (*Dataset, error) {
+	options := &datasetOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	next, err := newExampleReader(r, format)
+	if err != nil {
+		return nil, err
+	}
+
+	var dataset *Dataset
+	uploaded := 0
+	for {
+		chunk, readErr := readExampleChunk(next, datasetStreamChunkSize)
+		if len(chunk) > 0 {
+			if dataset == nil {
+				dataset, err = c.CreateDataset(ctx, name, chunk, opts...)
+				if err != nil {
+					return nil, err
+				}
+			} else if err := c.AddDatasetExamples(ctx, name, chunk); err != nil {
+				return dataset, &BatchUploadError{Uploaded: uploaded, Err: err}
+			}
+			uploaded += len(chunk)
+			dataset.ExampleCount = uploaded
+			if options.progress != nil {
+				options.progress(uploaded, -1)
+			}
+		}
+
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			if dataset == nil {
+				return nil, readErr
+			}
+			return dataset, &BatchUploadError{Uploaded: uploaded, Err: readErr}
+		}
+	}
+
+	if dataset == nil {
+		return nil, fmt.Errorf("%w: dataset stream has no rows", ErrInvalidInput)
+	}
+	return dataset, nil
+}
+
+// readExampleChunk reads up to size examples. It returns io.EOF, possibly
+// with a final partial chunk, once the stream is exhausted.
+func readExampleChunk(next func() (DatasetExample, error), size int) ([]DatasetExample, error) {
+	chunk := make([]DatasetExample, 0, size)
+	for len(chunk) < size {
+		ex, err := next()
+		if err != nil {
+			return chunk, err
+		}
+		chunk = append(chunk, ex)
+	}
+	return chunk, nil
+}
+
+// newExampleReader returns a function that decodes the next example from r.
+func newExampleReader(r io.Reader, format DatasetFormat) (func() (DatasetExample, error), error) {
+	switch format {
+	case DatasetFormatJSONL:
+		return jsonlExampleReader(r), nil
+	case DatasetFormatCSV:
+		return csvExampleReader(r)
+	default:
+		return nil, fmt.Errorf("%w: unsupported dataset format %q", ErrInvalidInput, format)
+	}
+}
+
+func jsonlExampleReader(r io.Reader) func() (DatasetExample, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	line := 0
+	return func() (DatasetExample, error) {
+		for scanner.Scan() {
+			line++
+			text := strings.TrimSpace(scanner.Text())
+			if text == "" {
+				continue
+			}
+			var ex DatasetExample
+			if err := json.Unmarshal([]byte(text), &ex); err != nil {
+				return DatasetExample{}, &RowError{Row: line, Err: err}
+			}
+			return ex, nil
+		}
+		if err := scanner.Err(); err != nil {
+			return DatasetExample{}, err
+		}
+		return DatasetExample{}, io.EOF
+	}
+}
+
+func csvExampleReader(r io.Reader) (func() (DatasetExample, error), error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: CSV dataset stream has no header", ErrInvalidInput)
+		}
+		return nil, err
+	}
+	cr.FieldsPerRecord = len(header)
+
+	row := 1
+	return func() (DatasetExample, error) {
+		record, err := cr.Read()
+		if err != nil {
+			return DatasetExample{}, err
+		}
+		row++
+
+		var ex DatasetExample
+		for i, column := range header {
+			value := record[i]
+			switch column {
+			case "input":
+				ex.Input = csvValue(value)
+			case "output":
+				ex.Output = csvValue(value)
+			case "metadata":
+				if value == "" {
+					continue
+				}
+				if err := json.Unmarshal([]byte(value), &ex.Metadata); err != nil {
+					return DatasetExample{}, &RowError{Row: row, Err: fmt.Errorf("metadata: %w", err)}
+				}
+			default:
+				if ex.Metadata == nil {
+					ex.Metadata = make(map[string]any)
+				}
+				ex.Metadata[column] = value
+			}
+		}
+		return ex, nil
+	}, nil
+}
+
+// csvValue decodes a CSV cell as JSON if it is valid JSON, and returns it as
+// a string otherwise.
+func csvValue(s string) any {
+	var v any
+	if err := json.Unmarshal([]byte(s), &v); err == nil {
+		return v
+	}
+	return s
+}
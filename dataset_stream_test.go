@@ -0,0 +1,208 @@
+package phoenix
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// uploadRequest is the JSON body of a dataset upload request.
+type uploadRequest struct {
+	Action   string           `json:"action"`
+	Name     string           `json:"name"`
+	Inputs   []map[string]any `json:"inputs"`
+	Outputs  []map[string]any `json:"outputs"`
+	Metadata []map[string]any `json:"metadata"`
+}
+
+// uploadMux serves dataset uploads, recording each request. Upload number
+// failAt (counting from 1) fails with a server error; 0 never fails.
+func uploadMux(t *testing.T, uploads *[]uploadRequest, failAt int) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/datasets/upload", func(w http.ResponseWriter, r *http.Request) {
+		var req uploadRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding upload: %v", err)
+		}
+		*uploads = append(*uploads, req)
+		if len(*uploads) == failAt {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		writeJSON(t, w, map[string]any{"data": map[string]any{"dataset_id": "ds1", "version_id": "v1"}})
+	})
+	return mux
+}
+
+func TestUploadDatasetStreamJSONL(t *testing.T) {
+	var uploads []uploadRequest
+	c := newTestClient(t, uploadMux(t, &uploads, 0))
+
+	var sb strings.Builder
+	for i := range 2*datasetStreamChunkSize + 1 {
+		fmt.Fprintf(&sb, `{"input": {"q": %d}, "output": {"a": %d}, "metadata": {"row": %d}}`+"\n", i, i, i)
+		if i == 10 {
+			sb.WriteString("\n")
+		}
+	}
+	var progress [][2]int
+	dataset, err := c.UploadDatasetStream(t.Context(), "big", strings.NewReader(sb.String()), DatasetFormatJSONL,
+		WithProgressCallback(func(uploaded, total int) { progress = append(progress, [2]int{uploaded, total}) }))
+	if err != nil {
+		t.Fatalf("UploadDatasetStream: %v", err)
+	}
+	if dataset.ID != "ds1" || dataset.Name != "big" || dataset.ExampleCount != 2*datasetStreamChunkSize+1 {
+		t.Errorf("dataset = %+v", dataset)
+	}
+
+	if len(uploads) != 3 {
+		t.Fatalf("sent %d uploads, want 3", len(uploads))
+	}
+	for i, want := range []struct {
+		action string
+		rows   int
+	}{{"create", datasetStreamChunkSize}, {"append", datasetStreamChunkSize}, {"append", 1}} {
+		u := uploads[i]
+		if u.Action != want.action || u.Name != "big" || len(u.Inputs) != want.rows || len(u.Outputs) != want.rows {
+			t.Errorf("upload %d = %s %q with %d rows, want %s with %d", i, u.Action, u.Name, len(u.Inputs), want.action, want.rows)
+		}
+	}
+	want := [][2]int{{datasetStreamChunkSize, -1}, {2 * datasetStreamChunkSize, -1}, {2*datasetStreamChunkSize + 1, -1}}
+	if fmt.Sprint(progress) != fmt.Sprint(want) {
+		t.Errorf("progress = %v, want %v", progress, want)
+	}
+}
+
+func TestExampleReaderCSV(t *testing.T) {
+	stream := "input,output,metadata,source\n" +
+		`"{""q"": ""a""}",plain answer,"{""k"": 1}",web` + "\n" +
+		`"{""q"": ""b""}","{""a"": 2}",,docs` + "\n" +
+		`{},{},not json,web` + "\n"
+	next, err := newExampleReader(strings.NewReader(stream), DatasetFormatCSV)
+	if err != nil {
+		t.Fatalf("newExampleReader: %v", err)
+	}
+
+	first, err := next()
+	if err != nil {
+		t.Fatalf("reading row 2: %v", err)
+	}
+	if fmt.Sprint(first.Input) != "map[q:a]" || first.Output != "plain answer" || fmt.Sprint(first.Metadata) != "map[k:1 source:web]" {
+		t.Errorf("row 2 = %+v", first)
+	}
+	second, err := next()
+	if err != nil {
+		t.Fatalf("reading row 3: %v", err)
+	}
+	if fmt.Sprint(second.Output) != "map[a:2]" || fmt.Sprint(second.Metadata) != "map[source:docs]" {
+		t.Errorf("row 3 = %+v", second)
+	}
+
+	var rowErr *RowError
+	if _, err := next(); !errors.As(err, &rowErr) || rowErr.Row != 4 {
+		t.Errorf("reading row 4 = %v, want *RowError for row 4", err)
+	}
+}
+
+func TestExampleReaderJSONL(t *testing.T) {
+	stream := `{"input": {"q": "a"}, "output": {"a": 1}, "metadata": {"k": "v"}}` + "\n\n" + "[1]\n"
+	next, err := newExampleReader(strings.NewReader(stream), DatasetFormatJSONL)
+	if err != nil {
+		t.Fatalf("newExampleReader: %v", err)
+	}
+
+	ex, err := next()
+	if err != nil {
+		t.Fatalf("reading line 1: %v", err)
+	}
+	if fmt.Sprint(ex.Input) != "map[q:a]" || fmt.Sprint(ex.Output) != "map[a:1]" || ex.Metadata["k"] != "v" {
+		t.Errorf("line 1 = %+v", ex)
+	}
+
+	// Blank lines are skipped but still counted.
+	var rowErr *RowError
+	if _, err := next(); !errors.As(err, &rowErr) || rowErr.Row != 3 {
+		t.Errorf("reading line 3 = %v, want *RowError for line 3", err)
+	}
+	if _, err := next(); !errors.Is(err, io.EOF) {
+		t.Errorf("reading past the end = %v, want %v", err, io.EOF)
+	}
+}
+
+func TestUploadDatasetStreamPartialFailure(t *testing.T) {
+	var sb strings.Builder
+	for range datasetStreamChunkSize + 1 {
+		sb.WriteString(`{"input": {"q": 1}}` + "\n")
+	}
+
+	t.Run("upload", func(t *testing.T) {
+		var uploads []uploadRequest
+		c := newTestClient(t, uploadMux(t, &uploads, 2))
+
+		dataset, err := c.UploadDatasetStream(t.Context(), "partial", strings.NewReader(sb.String()), DatasetFormatJSONL)
+		var uploadErr *BatchUploadError
+		if !errors.As(err, &uploadErr) || uploadErr.Uploaded != datasetStreamChunkSize {
+			t.Fatalf("UploadDatasetStream = %v, want *BatchUploadError after %d rows", err, datasetStreamChunkSize)
+		}
+		if dataset == nil || dataset.ID != "ds1" || dataset.ExampleCount != datasetStreamChunkSize {
+			t.Errorf("dataset = %+v, want the created dataset", dataset)
+		}
+	})
+
+	t.Run("read", func(t *testing.T) {
+		var uploads []uploadRequest
+		c := newTestClient(t, uploadMux(t, &uploads, 0))
+
+		stream := `{"input": {"q": 1}}` + "\n" + `{"input": {"q": 2}}` + "\n" + "{not json\n"
+		dataset, err := c.UploadDatasetStream(t.Context(), "partial", strings.NewReader(stream), DatasetFormatJSONL)
+		var uploadErr *BatchUploadError
+		var rowErr *RowError
+		if !errors.As(err, &uploadErr) || uploadErr.Uploaded != 2 || !errors.As(err, &rowErr) || rowErr.Row != 3 {
+			t.Fatalf("UploadDatasetStream = %v, want *BatchUploadError for row 3 after 2 rows", err)
+		}
+		if dataset == nil || len(uploads) != 1 {
+			t.Errorf("dataset = %+v after %d uploads, want the dataset created with the rows read", dataset, len(uploads))
+		}
+	})
+
+	t.Run("first row", func(t *testing.T) {
+		var uploads []uploadRequest
+		c := newTestClient(t, uploadMux(t, &uploads, 0))
+
+		dataset, err := c.UploadDatasetStream(t.Context(), "partial", strings.NewReader("{not json\n"), DatasetFormatJSONL)
+		var rowErr *RowError
+		if dataset != nil || !errors.As(err, &rowErr) || len(uploads) != 0 {
+			t.Errorf("UploadDatasetStream = %+v, %v after %d uploads, want a *RowError and no dataset", dataset, err, len(uploads))
+		}
+	})
+}
+
+func TestUploadDatasetStreamInvalid(t *testing.T) {
+	var uploads []uploadRequest
+	c := newTestClient(t, uploadMux(t, &uploads, 0))
+
+	tests := []struct {
+		name   string
+		stream string
+		format DatasetFormat
+	}{
+		{"empty JSONL", "\n\n", DatasetFormatJSONL},
+		{"empty CSV", "", DatasetFormatCSV},
+		{"header only", "input,output\n", DatasetFormatCSV},
+		{"unknown format", `{"input": {}}`, DatasetFormat("parquet")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := c.UploadDatasetStream(t.Context(), "ds", strings.NewReader(tt.stream), tt.format); !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("UploadDatasetStream = %v, want %v", err, ErrInvalidInput)
+			}
+		})
+	}
+	if len(uploads) != 0 {
+		t.Errorf("sent %d uploads for invalid streams", len(uploads))
+	}
+}
@@ -0,0 +1,69 @@
+package phoenix
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestFilterDatasetExamples(t *testing.T) {
+	requests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/datasets/{id}/examples", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.PathValue("id") != "ds1" {
+			notFound(w)
+			return
+		}
+		writeJSON(t, w, examplesResponse("ds1", "v1",
+			exampleJSON("ex1", map[string]any{"n": 1}, nil),
+			exampleJSON("ex2", map[string]any{"n": 2}, nil),
+			exampleJSON("ex3", map[string]any{"n": 3}, nil),
+			exampleJSON("ex4", map[string]any{"n": 4}, nil),
+		))
+	})
+	c := newTestClient(t, mux)
+
+	odd := func(ex *DatasetExample) bool {
+		n, _ := ex.Input.(map[string]any)["n"].(float64)
+		return int(n)%2 == 1
+	}
+	tests := []struct {
+		name string
+		opts []ListOption
+		want []string
+	}{
+		{"all", nil, []string{"ex1", "ex3"}},
+		{"small limit", []ListOption{WithLimit(1)}, []string{"ex1", "ex3"}},
+		{"cursor", []ListOption{WithCursor("1")}, []string{"ex3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = 0
+			got, err := c.FilterDatasetExamples(t.Context(), "ds1", odd, tt.opts...)
+			if err != nil {
+				t.Fatalf("FilterDatasetExamples: %v", err)
+			}
+			if requests != 1 {
+				t.Errorf("made %d requests, want 1", requests)
+			}
+			var ids []string
+			for _, ex := range got {
+				ids = append(ids, ex.ID)
+			}
+			if len(ids) != len(tt.want) {
+				t.Fatalf("got %v, want %v", ids, tt.want)
+			}
+			for i := range ids {
+				if ids[i] != tt.want[i] {
+					t.Errorf("got %v, want %v", ids, tt.want)
+					break
+				}
+			}
+		})
+	}
+
+	if _, err := c.FilterDatasetExamples(t.Context(), "ds2", odd); !errors.Is(err, ErrDatasetNotFound) {
+		t.Errorf("missing dataset: err = %v, want ErrDatasetNotFound", err)
+	}
+}
@@ -0,0 +1,37 @@
+package phoenix
+
+import (
+	"context"
+	"fmt"
+)
+
+// DatasetExampleUpdate describes changes to a dataset example. Nil fields
+// are left unchanged.
+type DatasetExampleUpdate struct {
+	Input    *any
+	Output   *any
+	Metadata *map[string]any
+}
+
+// UpdateDatasetExample updates an example in place and returns the updated
+// example.
+//
+// Phoenix does not currently expose an endpoint for updating dataset
+// examples, so this returns ErrNotSupported once the example is found.
+// Returns ErrDatasetNotFound or ErrDatasetExampleNotFound if the dataset or
+// example does not exist. To change examples, upload a new dataset with the
+// corrected examples.
+func (c *Client) UpdateDatasetExample(ctx context.Context, datasetID, exampleID string, update DatasetExampleUpdate) (*DatasetExample, error) {
+	if datasetID == "" || exampleID == "" {
+		return nil, fmt.Errorf("%w: dataset ID and example ID are required", ErrInvalidInput)
+	}
+
+	examples, err := c.getDatasetExamples(ctx, datasetID)
+	if err != nil {
+		return nil, err
+	}
+	if findDatasetExample(examples, exampleID) == nil {
+		return nil, ErrDatasetExampleNotFound
+	}
+	return nil, fmt.Errorf("%w: updating example %q of dataset %q", ErrNotSupported, exampleID, datasetID)
+}
@@ -0,0 +1,48 @@
+package phoenix
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestUpdateDatasetExample(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/datasets/{id}/examples", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") != "ds1" {
+			notFound(w)
+			return
+		}
+		writeJSON(t, w, examplesResponse("ds1", "v1", exampleJSON("ex1", map[string]any{"q": "a"}, nil)))
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	c := newTestClient(t, mux)
+
+	output := any("b")
+	update := DatasetExampleUpdate{Output: &output}
+	tests := []struct {
+		name      string
+		datasetID string
+		exampleID string
+		want      error
+	}{
+		{"existing example", "ds1", "ex1", ErrNotSupported},
+		{"missing example", "ds1", "ex2", ErrDatasetExampleNotFound},
+		{"missing dataset", "ds2", "ex1", ErrDatasetNotFound},
+		{"missing ID", "ds1", "", ErrInvalidInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ex, err := c.UpdateDatasetExample(t.Context(), tt.datasetID, tt.exampleID, update)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("UpdateDatasetExample = %v, want %v", err, tt.want)
+			}
+			if ex != nil {
+				t.Errorf("UpdateDatasetExample returned example %+v, want nil", ex)
+			}
+		})
+	}
+}
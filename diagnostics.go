@@ -0,0 +1,77 @@
+package phoenix
+
+import (
+	"net/http"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// DiagnosticInfo is a snapshot of the client's configuration and recent
+// activity that is safe to share in bug reports.
+type DiagnosticInfo struct {
+	SDKVersion string
+	GoVersion  string
+	OS         string
+
+	// Config is a copy of the client configuration with the API key masked
+	// to its last four characters.
+	Config Config
+
+	// EndpointReachable reports whether the most recent API call received
+	// an HTTP response, regardless of its status. It is false before the
+	// first call.
+	EndpointReachable bool
+
+	// LatestAPICall is when the most recent API call was made.
+	LatestAPICall time.Time
+
+	// TotalCallsMade is the number of API calls made by the client,
+	// not counting retries.
+	TotalCallsMade int64
+}
+
+// callTracker records API call activity for DiagnosticDump.
+type callTracker struct {
+	total     atomic.Int64
+	latest    atomic.Int64 // UnixNano of the most recent call
+	reachable atomic.Bool
+}
+
+func (t *callTracker) observe(resp *http.Response, err error) {
+	t.total.Add(1)
+	t.latest.Store(time.Now().UnixNano())
+	t.reachable.Store(resp != nil && err == nil)
+}
+
+// DiagnosticDump returns a snapshot of the client's configuration and
+// activity. It makes no API calls.
+func (c *Client) DiagnosticDump() *DiagnosticInfo {
+	cfg := *c.config
+	cfg.APIKey = maskSecret(cfg.APIKey)
+
+	info := &DiagnosticInfo{
+		SDKVersion:        Version,
+		GoVersion:         runtime.Version(),
+		OS:                runtime.GOOS + "/" + runtime.GOARCH,
+		Config:            cfg,
+		EndpointReachable: c.calls.reachable.Load(),
+		TotalCallsMade:    c.calls.total.Load(),
+	}
+	if latest := c.calls.latest.Load(); latest != 0 {
+		info.LatestAPICall = time.Unix(0, latest)
+	}
+	return info
+}
+
+// maskSecret replaces all but the last four characters of s with asterisks.
+func maskSecret(s string) string {
+	if s == "" {
+		return ""
+	}
+	if len(s) <= 4 {
+		return strings.Repeat("*", len(s))
+	}
+	return strings.Repeat("*", len(s)-4) + s[len(s)-4:]
+}
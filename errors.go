@@ -1,6 +1,14 @@
 package phoenix
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/ogen-go/ogen/validate"
+)
 
 // Sentinel errors for the Phoenix SDK.
 var (
@@ -22,28 +30,240 @@ var (
 	// ErrDatasetNotFound is returned when a dataset cannot be found.
 	ErrDatasetNotFound = errors.New("phoenix: dataset not found")
 
+	// ErrDatasetExampleNotFound is returned when a dataset example cannot be found.
+	ErrDatasetExampleNotFound = errors.New("phoenix: dataset example not found")
+
 	// ErrExperimentNotFound is returned when an experiment cannot be found.
 	ErrExperimentNotFound = errors.New("phoenix: experiment not found")
 
+	// ErrExperimentTimeout is returned by WaitForExperiment when the context
+	// deadline passes before the experiment completes.
+	ErrExperimentTimeout = errors.New("phoenix: timed out waiting for experiment")
+
+	// ErrAnnotationNotFound is returned when an annotation cannot be found.
+	ErrAnnotationNotFound = errors.New("phoenix: annotation not found")
+
 	// ErrPromptNotFound is returned when a prompt cannot be found.
 	ErrPromptNotFound = errors.New("phoenix: prompt not found")
 
+	// ErrPromptTagNotFound is returned when a prompt tag cannot be found.
+	ErrPromptTagNotFound = errors.New("phoenix: prompt tag not found")
+
+	// ErrTagAlreadyExists is returned when creating a prompt version with a
+	// tag that already points at another version of the prompt.
+	ErrTagAlreadyExists = errors.New("phoenix: tag already exists")
+
+	// ErrMissingPromptVariable matches a PromptRenderError with missing
+	// variables.
+	ErrMissingPromptVariable = errors.New("phoenix: missing prompt variable")
+
+	// ErrInvalidPromptVariable matches a PromptRenderError with invalid
+	// variables.
+	ErrInvalidPromptVariable = errors.New("phoenix: invalid prompt variable")
+
+	// ErrPromptTemplateSyntax matches a PromptRenderError for a template
+	// that could not be parsed.
+	ErrPromptTemplateSyntax = errors.New("phoenix: prompt template syntax error")
+
 	// ErrInvalidInput is returned when input validation fails.
 	ErrInvalidInput = errors.New("phoenix: invalid input")
+
+	// ErrNotSupported is returned when an operation is not supported by the Phoenix API.
+	ErrNotSupported = errors.New("phoenix: operation not supported")
 )
 
 // APIError represents an error returned by the Phoenix API.
+// RequestID is the X-Request-ID (or X-Trace-ID) header of the response
+// that caused the error, if the server sent one. Include it when reporting
+// problems to the Phoenix team.
 type APIError struct {
 	StatusCode int
 	Message    string
 	Details    string
+	RequestID  string
 }
 
 func (e *APIError) Error() string {
+	msg := "phoenix: API error: " + e.Message
 	if e.Details != "" {
-		return "phoenix: API error (" + e.Message + "): " + e.Details
+		msg = "phoenix: API error (" + e.Message + "): " + e.Details
+	}
+	if e.RequestID != "" {
+		msg += " (request ID " + e.RequestID + ")"
+	}
+	return msg
+}
+
+// RowError describes a single row rejected during a batch import.
+// Row is the 1-based line number in the source file, including the header.
+type RowError struct {
+	Row int
+	Err error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// BatchImportError is returned when some rows of a batch import fail
+// validation. Valid rows are still imported.
+type BatchImportError struct {
+	Errors []*RowError
+}
+
+func (e *BatchImportError) Error() string {
+	if len(e.Errors) == 1 {
+		return "phoenix: batch import: " + e.Errors[0].Error()
+	}
+	return fmt.Sprintf("phoenix: batch import: %d invalid rows (first: %v)", len(e.Errors), e.Errors[0])
+}
+
+// Unwrap returns the individual row errors.
+func (e *BatchImportError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, re := range e.Errors {
+		errs[i] = re
+	}
+	return errs
+}
+
+// UpdateError describes a single item that failed to update in a batch
+// update.
+type UpdateError struct {
+	ID  string
+	Err error
+}
+
+func (e *UpdateError) Error() string {
+	return fmt.Sprintf("%s: %v", e.ID, e.Err)
+}
+
+func (e *UpdateError) Unwrap() error {
+	return e.Err
+}
+
+// BatchUpdateError is returned when some items of a batch update fail.
+// The other items are still updated.
+type BatchUpdateError struct {
+	Errors []*UpdateError
+}
+
+func (e *BatchUpdateError) Error() string {
+	if len(e.Errors) == 1 {
+		return "phoenix: batch update: " + e.Errors[0].Error()
+	}
+	return fmt.Sprintf("phoenix: batch update: %d failed items (first: %v)", len(e.Errors), e.Errors[0])
+}
+
+// Unwrap returns the individual item errors.
+func (e *BatchUpdateError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, ue := range e.Errors {
+		errs[i] = ue
+	}
+	return errs
+}
+
+// PromptTagError is returned by CreatePrompt and CreateChatPrompt when the
+// prompt version was created but could not be tagged with WithPromptTag.
+type PromptTagError struct {
+	// Version is the created, untagged version.
+	Version *PromptVersion
+	Tag     string
+	Err     error
+}
+
+func (e *PromptTagError) Error() string {
+	return fmt.Sprintf("phoenix: tagging prompt version %q with %q: %v", e.Version.ID, e.Tag, e.Err)
+}
+
+func (e *PromptTagError) Unwrap() error {
+	return e.Err
+}
+
+// MultiError collects the errors of independent operations that were all
+// attempted, such as the concurrent lists made by ListAll.
+type MultiError struct {
+	Errors []error
+}
+
+func (e *MultiError) Error() string {
+	if len(e.Errors) == 1 {
+		return e.Errors[0].Error()
+	}
+	return fmt.Sprintf("phoenix: %d errors (first: %v)", len(e.Errors), e.Errors[0])
+}
+
+// Unwrap returns the individual errors.
+func (e *MultiError) Unwrap() []error {
+	return e.Errors
+}
+
+// BatchUploadError is returned when a streamed upload fails part-way.
+// Uploaded rows remain in the dataset.
+type BatchUploadError struct {
+	// Uploaded is the number of rows uploaded before the failure.
+	Uploaded int
+	Err      error
+}
+
+func (e *BatchUploadError) Error() string {
+	return fmt.Sprintf("phoenix: batch upload failed after %d rows: %v", e.Uploaded, e.Err)
+}
+
+func (e *BatchUploadError) Unwrap() error {
+	return e.Err
+}
+
+// PromptRenderError describes why a prompt template could not be rendered
+// with a set of variables. Use errors.Is with ErrMissingPromptVariable,
+// ErrInvalidPromptVariable or ErrPromptTemplateSyntax to check for a kind
+// of failure.
+type PromptRenderError struct {
+	// MissingVars lists the template variables without a value.
+	MissingVars []string
+
+	// InvalidVars maps variable names to why their value is invalid.
+	InvalidVars map[string]string
+
+	// TemplateSyntaxError describes why the template could not be parsed.
+	TemplateSyntaxError string
+}
+
+func (e *PromptRenderError) Error() string {
+	var problems []string
+	if e.TemplateSyntaxError != "" {
+		problems = append(problems, "template syntax: "+e.TemplateSyntaxError)
+	}
+	if len(e.MissingVars) > 0 {
+		problems = append(problems, "missing variables: "+strings.Join(e.MissingVars, ", "))
+	}
+	for _, name := range slices.Sorted(maps.Keys(e.InvalidVars)) {
+		problems = append(problems, fmt.Sprintf("invalid variable %q: %s", name, e.InvalidVars[name]))
+	}
+	if len(problems) == 0 {
+		return "phoenix: rendering prompt failed"
+	}
+	return "phoenix: rendering prompt: " + strings.Join(problems, "; ")
+}
+
+// Is reports whether target is the sentinel error for one of the failures
+// e describes.
+func (e *PromptRenderError) Is(target error) bool {
+	switch target {
+	case ErrMissingPromptVariable:
+		return len(e.MissingVars) > 0
+	case ErrInvalidPromptVariable:
+		return len(e.InvalidVars) > 0
+	case ErrPromptTemplateSyntax:
+		return e.TemplateSyntaxError != ""
+	default:
+		return false
 	}
-	return "phoenix: API error: " + e.Message
 }
 
 // IsNotFound returns true if the error indicates a resource was not found.
@@ -58,8 +278,11 @@ func IsNotFound(err error) bool {
 		errors.Is(err, ErrTraceNotFound) ||
 		errors.Is(err, ErrSpanNotFound) ||
 		errors.Is(err, ErrDatasetNotFound) ||
+		errors.Is(err, ErrDatasetExampleNotFound) ||
 		errors.Is(err, ErrExperimentNotFound) ||
-		errors.Is(err, ErrPromptNotFound)
+		errors.Is(err, ErrAnnotationNotFound) ||
+		errors.Is(err, ErrPromptNotFound) ||
+		errors.Is(err, ErrPromptTagNotFound)
 }
 
 // IsUnauthorized returns true if the error indicates an authentication failure.
@@ -94,3 +317,20 @@ func IsRateLimited(err error) bool {
 	}
 	return false
 }
+
+// IsConflict returns true if the error indicates the resource already exists.
+func IsConflict(err error) bool {
+	if err == nil {
+		return false
+	}
+	if apiErr, ok := err.(*APIError); ok {
+		return apiErr.StatusCode == 409
+	}
+	// Conflicts are not declared in the OpenAPI spec for most endpoints,
+	// so they surface as unexpected status codes from the generated client.
+	var statusErr *validate.UnexpectedStatusCodeError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == 409
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package phoenix
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestPromptRenderError(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     *PromptRenderError
+		wantIs  []error
+		wantNot []error
+		wantMsg string
+	}{
+		{
+			name:    "missing",
+			err:     &PromptRenderError{MissingVars: []string{"name", "topic"}},
+			wantIs:  []error{ErrMissingPromptVariable},
+			wantNot: []error{ErrInvalidPromptVariable, ErrPromptTemplateSyntax},
+			wantMsg: "phoenix: rendering prompt: missing variables: name, topic",
+		},
+		{
+			name: "all",
+			err: &PromptRenderError{
+				MissingVars:         []string{"name"},
+				InvalidVars:         map[string]string{"b": "not a string", "a": "too long"},
+				TemplateSyntaxError: "unclosed {{",
+			},
+			wantIs: []error{ErrMissingPromptVariable, ErrInvalidPromptVariable, ErrPromptTemplateSyntax},
+			wantMsg: `phoenix: rendering prompt: template syntax: unclosed {{; missing variables: name; ` +
+				`invalid variable "a": too long; invalid variable "b": not a string`,
+		},
+		{
+			name:    "empty",
+			err:     &PromptRenderError{},
+			wantNot: []error{ErrMissingPromptVariable, ErrInvalidPromptVariable, ErrPromptTemplateSyntax, ErrInvalidInput},
+			wantMsg: "phoenix: rendering prompt failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Wrapping keeps the checks working.
+			err := fmt.Errorf("rendering greeting: %w", tt.err)
+			for _, target := range tt.wantIs {
+				if !errors.Is(err, target) {
+					t.Errorf("errors.Is(%v) = false", target)
+				}
+			}
+			for _, target := range tt.wantNot {
+				if errors.Is(err, target) {
+					t.Errorf("errors.Is(%v) = true", target)
+				}
+			}
+			if got := tt.err.Error(); got != tt.wantMsg {
+				t.Errorf("Error = %q, want %q", got, tt.wantMsg)
+			}
+
+			var renderErr *PromptRenderError
+			if !errors.As(err, &renderErr) || renderErr != tt.err {
+				t.Errorf("errors.As = %v, want the *PromptRenderError", renderErr)
+			}
+		})
+	}
+}
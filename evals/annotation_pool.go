@@ -0,0 +1,243 @@
+package evals
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	phoenix "github.com/agentplexus/go-phoenix"
+)
+
+// Default batching parameters for AnnotationWorkerPool.
+const (
+	DefaultAnnotationBatchSize     = 100
+	DefaultAnnotationFlushInterval = time.Second
+)
+
+// AnnotationJob is a span annotation queued for background recording.
+type AnnotationJob struct {
+	SpanID      string
+	Name        string
+	Score       float64
+	Label       string
+	Explanation string
+	Source      phoenix.AnnotatorKind
+}
+
+// AnnotationWorkerPool records span annotations in the background so that
+// evaluation does not block on Phoenix. Each worker batches jobs until it
+// holds BatchSize jobs or FlushInterval has passed, then sends the batch
+// with a single CreateSpanAnnotations call.
+//
+// Errors from background sends are retained and returned by the next Flush
+// or Close.
+type AnnotationWorkerPool struct {
+	client        *phoenix.Client
+	batchSize     int
+	flushInterval time.Duration
+
+	jobs    chan AnnotationJob
+	flushes []chan chan error
+	wg      sync.WaitGroup
+
+	mu     sync.RWMutex
+	closed bool
+
+	errMu sync.Mutex
+	errs  []error
+}
+
+// AnnotationPoolOption configures an AnnotationWorkerPool.
+type AnnotationPoolOption func(*AnnotationWorkerPool)
+
+// WithBatchSize sets the maximum number of annotations sent per request.
+func WithBatchSize(n int) AnnotationPoolOption {
+	return func(p *AnnotationWorkerPool) {
+		if n > 0 {
+			p.batchSize = n
+		}
+	}
+}
+
+// WithFlushInterval sets how long a worker holds a partial batch before sending it.
+func WithFlushInterval(d time.Duration) AnnotationPoolOption {
+	return func(p *AnnotationWorkerPool) {
+		if d > 0 {
+			p.flushInterval = d
+		}
+	}
+}
+
+// NewAnnotationWorkerPool starts a pool of workers recording annotations
+// with client. Call Close to flush pending annotations and stop the workers.
+func NewAnnotationWorkerPool(client *phoenix.Client, workers int, opts ...AnnotationPoolOption) *AnnotationWorkerPool {
+	if workers < 1 {
+		workers = 1
+	}
+	p := &AnnotationWorkerPool{
+		client:        client,
+		batchSize:     DefaultAnnotationBatchSize,
+		flushInterval: DefaultAnnotationFlushInterval,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	p.jobs = make(chan AnnotationJob, p.batchSize*workers)
+	p.flushes = make([]chan chan error, workers)
+	for i := range p.flushes {
+		p.flushes[i] = make(chan chan error)
+		p.wg.Add(1)
+		go p.work(p.flushes[i])
+	}
+	return p
+}
+
+// BatchSize returns the maximum number of annotations sent per request.
+func (p *AnnotationWorkerPool) BatchSize() int {
+	return p.batchSize
+}
+
+// FlushInterval returns how long a worker holds a partial batch.
+func (p *AnnotationWorkerPool) FlushInterval() time.Duration {
+	return p.flushInterval
+}
+
+// Submit queues a job. It blocks while the queue is full and drops the job
+// if the pool has been closed.
+func (p *AnnotationWorkerPool) Submit(job AnnotationJob) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return
+	}
+	p.jobs <- job
+}
+
+// Flush sends every job submitted before the call and returns any errors
+// recorded since the last Flush.
+func (p *AnnotationWorkerPool) Flush(ctx context.Context) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return p.takeErrors()
+	}
+
+	replies := make([]chan error, len(p.flushes))
+	for i, flush := range p.flushes {
+		replies[i] = make(chan error, 1)
+		select {
+		case flush <- replies[i]:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	for _, reply := range replies {
+		select {
+		case err := <-reply:
+			p.recordError(err)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return p.takeErrors()
+}
+
+// Close sends all pending jobs, stops the workers and returns any errors
+// not yet reported by Flush. Submit has no effect after Close.
+func (p *AnnotationWorkerPool) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	close(p.jobs)
+	p.mu.Unlock()
+
+	p.wg.Wait()
+	return p.takeErrors()
+}
+
+func (p *AnnotationWorkerPool) work(flush chan chan error) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*phoenix.Annotation, 0, p.batchSize)
+	send := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := p.client.CreateSpanAnnotations(context.Background(), batch)
+		batch = make([]*phoenix.Annotation, 0, p.batchSize)
+		return err
+	}
+
+	for {
+		select {
+		case job, ok := <-p.jobs:
+			if !ok {
+				p.recordError(send())
+				return
+			}
+			batch = append(batch, job.annotation())
+			if len(batch) >= p.batchSize {
+				p.recordError(send())
+			}
+		case <-ticker.C:
+			p.recordError(send())
+		case reply := <-flush:
+			// Drain queued jobs so that everything submitted before Flush
+			// is sent by some worker before Flush returns.
+			var errs []error
+		drain:
+			for {
+				select {
+				case job, ok := <-p.jobs:
+					if !ok {
+						break drain
+					}
+					batch = append(batch, job.annotation())
+					if len(batch) >= p.batchSize {
+						errs = append(errs, send())
+					}
+				default:
+					break drain
+				}
+			}
+			errs = append(errs, send())
+			reply <- errors.Join(errs...)
+		}
+	}
+}
+
+func (p *AnnotationWorkerPool) recordError(err error) {
+	if err == nil {
+		return
+	}
+	p.errMu.Lock()
+	p.errs = append(p.errs, err)
+	p.errMu.Unlock()
+}
+
+func (p *AnnotationWorkerPool) takeErrors() error {
+	p.errMu.Lock()
+	defer p.errMu.Unlock()
+	err := errors.Join(p.errs...)
+	p.errs = nil
+	return err
+}
+
+func (j AnnotationJob) annotation() *phoenix.Annotation {
+	return &phoenix.Annotation{
+		SpanID:      j.SpanID,
+		Name:        j.Name,
+		Score:       j.Score,
+		Label:       j.Label,
+		Explanation: j.Explanation,
+		Source:      j.Source,
+	}
+}
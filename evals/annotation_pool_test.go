@@ -0,0 +1,112 @@
+package evals
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	phoenix "github.com/agentplexus/go-phoenix"
+)
+
+// annotationServer records the span annotations posted to it.
+type annotationServer struct {
+	mu       sync.Mutex
+	requests int
+	names    []string
+}
+
+func newAnnotationServer(t *testing.T) (*annotationServer, *phoenix.Client) {
+	t.Helper()
+
+	s := &annotationServer{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Data []struct {
+				Name string `json:"name"`
+			} `json:"data"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		s.mu.Lock()
+		s.requests++
+		for _, d := range body.Data {
+			s.names = append(s.names, d.Name)
+		}
+		s.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := phoenix.NewClient(phoenix.WithURL(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return s, client
+}
+
+func (s *annotationServer) counts() (requests, annotations int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests, len(s.names)
+}
+
+func TestAnnotationWorkerPool_Flush(t *testing.T) {
+	srv, client := newAnnotationServer(t)
+	pool := NewAnnotationWorkerPool(client, 2, WithFlushInterval(time.Hour))
+	defer pool.Close()
+
+	for i := 0; i < 10; i++ {
+		pool.Submit(AnnotationJob{SpanID: "span", Name: "score", Score: 1})
+	}
+	if err := pool.Flush(t.Context()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	if _, annotations := srv.counts(); annotations != 10 {
+		t.Errorf("expected 10 annotations after Flush, got %d", annotations)
+	}
+}
+
+func TestAnnotationWorkerPool_BatchSize(t *testing.T) {
+	srv, client := newAnnotationServer(t)
+	pool := NewAnnotationWorkerPool(client, 1, WithBatchSize(3), WithFlushInterval(time.Hour))
+
+	for i := 0; i < 7; i++ {
+		pool.Submit(AnnotationJob{SpanID: "span", Name: "score", Score: 1})
+	}
+	if err := pool.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	requests, annotations := srv.counts()
+	if annotations != 7 {
+		t.Errorf("expected 7 annotations, got %d", annotations)
+	}
+	if requests != 3 {
+		t.Errorf("expected 3 requests for batches of 3, got %d", requests)
+	}
+}
+
+func TestAnnotationWorkerPool_SubmitAfterClose(t *testing.T) {
+	srv, client := newAnnotationServer(t)
+	pool := NewAnnotationWorkerPool(client, 1)
+	if err := pool.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	pool.Submit(AnnotationJob{SpanID: "span", Name: "score"})
+	if err := pool.Flush(t.Context()); err != nil {
+		t.Fatalf("Flush after Close: %v", err)
+	}
+	if _, annotations := srv.counts(); annotations != 0 {
+		t.Errorf("expected no annotations after Close, got %d", annotations)
+	}
+}
@@ -0,0 +1,146 @@
+package evals
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	phoenix "github.com/agentplexus/go-phoenix"
+	"github.com/agentplexus/omniobserve/llmops"
+)
+
+// DatasetEvalResult holds the outcome of evaluating a task over dataset examples.
+type DatasetEvalResult struct {
+	DatasetID string
+	Examples  []*ExampleEvalResult
+	Duration  time.Duration
+}
+
+// ExampleEvalResult is the evaluation of a task against a single example.
+type ExampleEvalResult struct {
+	ExampleID string
+	Input     any
+	Expected  any
+	Output    any
+	Scores    []llmops.MetricScore
+	// Error is the task error message, empty if the task succeeded.
+	// Metrics are not run for failed examples.
+	Error string
+}
+
+// EvaluateDataset runs task against each example and scores its output with
+// metrics, using the example output as the expected value. Results are not
+// recorded to Phoenix; use SaveExperimentResults to persist them.
+func (e *Evaluator) EvaluateDataset(ctx context.Context, datasetID string, examples []*phoenix.DatasetExample, task phoenix.ExperimentTask, metrics ...llmops.Metric) (*DatasetEvalResult, error) {
+	if task == nil {
+		return nil, fmt.Errorf("%w: task is required", phoenix.ErrInvalidInput)
+	}
+
+	start := time.Now()
+	result := &DatasetEvalResult{
+		DatasetID: datasetID,
+		Examples:  make([]*ExampleEvalResult, 0, len(examples)),
+	}
+
+	for _, example := range examples {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		r := &ExampleEvalResult{
+			ExampleID: example.ID,
+			Input:     example.Input,
+			Expected:  example.Output,
+		}
+		output, err := task(ctx, example)
+		if err != nil {
+			r.Error = err.Error()
+			result.Examples = append(result.Examples, r)
+			continue
+		}
+		r.Output = output
+
+		input := llmops.EvalInput{
+			Input:    example.Input,
+			Output:   output,
+			Expected: example.Output,
+			Metadata: example.Metadata,
+		}
+		for _, metric := range metrics {
+			score, err := metric.Evaluate(input)
+			if err != nil {
+				// Record error in score but continue with other metrics
+				score = llmops.MetricScore{
+					Name:  metric.Name(),
+					Error: err.Error(),
+				}
+			}
+			r.Scores = append(r.Scores, score)
+		}
+		result.Examples = append(result.Examples, r)
+	}
+
+	result.Duration = time.Since(start)
+	return result, nil
+}
+
+// SaveExperimentResults persists evaluation results as dataset examples so
+// that later evaluations can compare against them as a baseline. Each
+// example keeps the original input, uses the task output as its output, and
+// records the metric scores in its metadata under "scores" (metric name to
+// score), along with the source example ID and any task error.
+//
+// If a dataset named datasetName exists the examples are appended to it,
+// creating a new dataset version; otherwise a new dataset is created.
+func SaveExperimentResults(ctx context.Context, client *phoenix.Client, result *DatasetEvalResult, datasetName string) error {
+	if result == nil || len(result.Examples) == 0 {
+		return fmt.Errorf("%w: no results to save", phoenix.ErrInvalidInput)
+	}
+
+	examples := make([]phoenix.DatasetExample, 0, len(result.Examples))
+	for _, r := range result.Examples {
+		scores := make(map[string]float64, len(r.Scores))
+		for _, s := range r.Scores {
+			if s.Error == "" {
+				scores[s.Name] = s.Score
+			}
+		}
+		metadata := map[string]any{
+			"scores":            scores,
+			"source_example_id": r.ExampleID,
+		}
+		if result.DatasetID != "" {
+			metadata["source_dataset_id"] = result.DatasetID
+		}
+		if r.Error != "" {
+			metadata["error"] = r.Error
+		}
+
+		examples = append(examples, phoenix.DatasetExample{
+			Input:    r.Input,
+			Output:   r.Output,
+			Metadata: metadata,
+		})
+	}
+
+	exists, err := datasetExists(ctx, client, datasetName)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return client.AddDatasetExamples(ctx, datasetName, examples)
+	}
+	_, err = client.CreateDataset(ctx, datasetName, examples)
+	return err
+}
+
+// datasetExists reports whether a dataset with the given name exists,
+// including archived datasets.
+func datasetExists(ctx context.Context, client *phoenix.Client, name string) (bool, error) {
+	_, err := client.GetDatasetByName(ctx, name)
+	if errors.Is(err, phoenix.ErrDatasetNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
@@ -0,0 +1,169 @@
+package evals
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	phoenix "github.com/agentplexus/go-phoenix"
+	"github.com/agentplexus/omniobserve/llmops"
+)
+
+func TestEvaluator_EvaluateDataset(t *testing.T) {
+	e := NewEvaluatorWithOptions(nil, WithRecordResults(false))
+
+	examples := []*phoenix.DatasetExample{
+		{ID: "ex-1", Input: "a", Output: "A"},
+		{ID: "ex-2", Input: "b", Output: "B"},
+	}
+	task := func(_ context.Context, ex *phoenix.DatasetExample) (any, error) {
+		if ex.ID == "ex-2" {
+			return nil, errors.New("task failed")
+		}
+		return "A", nil
+	}
+
+	result, err := e.EvaluateDataset(t.Context(), "ds-1", examples, task, &mockMetric{name: "m", score: 0.5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Examples) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(result.Examples))
+	}
+
+	ok := result.Examples[0]
+	if ok.Output != "A" || ok.Expected != "A" {
+		t.Errorf("unexpected output/expected: %v/%v", ok.Output, ok.Expected)
+	}
+	if len(ok.Scores) != 1 || ok.Scores[0].Score != 0.5 {
+		t.Errorf("unexpected scores: %+v", ok.Scores)
+	}
+
+	failed := result.Examples[1]
+	if failed.Error != "task failed" {
+		t.Errorf("expected task error, got %q", failed.Error)
+	}
+	if len(failed.Scores) != 0 {
+		t.Errorf("expected no scores for failed example, got %d", len(failed.Scores))
+	}
+}
+
+func TestSaveExperimentResults_Empty(t *testing.T) {
+	err := SaveExperimentResults(t.Context(), nil, &DatasetEvalResult{}, "baseline")
+	if !errors.Is(err, phoenix.ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+}
+
+// datasetServer serves the dataset list and records dataset uploads.
+type datasetServer struct {
+	uploads    []datasetUpload
+	failUpload bool
+}
+
+// datasetUpload is the JSON body of a dataset upload request.
+type datasetUpload struct {
+	Action string `json:"action"`
+	Name   string `json:"name"`
+	Inputs []any  `json:"inputs"`
+}
+
+func newDatasetServer(t *testing.T, existing ...string) (*datasetServer, *phoenix.Client) {
+	t.Helper()
+
+	s := &datasetServer{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/datasets", func(w http.ResponseWriter, r *http.Request) {
+		data := make([]map[string]any, 0, len(existing))
+		for i, name := range existing {
+			data = append(data, map[string]any{
+				"id":            fmt.Sprintf("ds-%d", i),
+				"name":          name,
+				"description":   nil,
+				"metadata":      map[string]any{},
+				"example_count": 1,
+				"created_at":    "2025-01-01T00:00:00Z",
+				"updated_at":    "2025-01-01T00:00:00Z",
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data, "next_cursor": nil})
+	})
+	mux.HandleFunc("POST /v1/datasets/upload", func(w http.ResponseWriter, r *http.Request) {
+		var u datasetUpload
+		if err := json.NewDecoder(r.Body).Decode(&u); err != nil {
+			t.Errorf("decoding upload: %v", err)
+		}
+		s.uploads = append(s.uploads, u)
+		if s.failUpload {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"dataset_id":"ds-new","version_id":"v1"}}`))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	client, err := phoenix.NewClient(phoenix.WithURL(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return s, client
+}
+
+func evalResult() *DatasetEvalResult {
+	return &DatasetEvalResult{
+		DatasetID: "ds-1",
+		Examples: []*ExampleEvalResult{
+			{ExampleID: "ex-1", Input: "a", Output: "A", Scores: []llmops.MetricScore{{Name: "m", Score: 0.5}}},
+			{ExampleID: "ex-2", Input: "b", Error: "task failed"},
+		},
+	}
+}
+
+func TestSaveExperimentResults_Create(t *testing.T) {
+	s, client := newDatasetServer(t, "other")
+
+	if err := SaveExperimentResults(t.Context(), client, evalResult(), "baseline"); err != nil {
+		t.Fatalf("SaveExperimentResults: %v", err)
+	}
+	if len(s.uploads) != 1 {
+		t.Fatalf("expected 1 upload, got %d", len(s.uploads))
+	}
+	if u := s.uploads[0]; u.Action != "create" || u.Name != "baseline" || len(u.Inputs) != 2 {
+		t.Errorf("unexpected upload: %+v", u)
+	}
+}
+
+func TestSaveExperimentResults_Append(t *testing.T) {
+	s, client := newDatasetServer(t, "other", "baseline")
+
+	if err := SaveExperimentResults(t.Context(), client, evalResult(), "baseline"); err != nil {
+		t.Fatalf("SaveExperimentResults: %v", err)
+	}
+	if len(s.uploads) != 1 {
+		t.Fatalf("expected 1 upload, got %d", len(s.uploads))
+	}
+	if u := s.uploads[0]; u.Action != "append" || u.Name != "baseline" || len(u.Inputs) != 2 {
+		t.Errorf("unexpected upload: %+v", u)
+	}
+}
+
+func TestSaveExperimentResults_UploadError(t *testing.T) {
+	for _, existing := range [][]string{nil, {"baseline"}} {
+		s, client := newDatasetServer(t, existing...)
+		s.failUpload = true
+
+		if err := SaveExperimentResults(t.Context(), client, evalResult(), "baseline"); err == nil {
+			t.Errorf("existing datasets %v: expected an upload error", existing)
+		}
+		if len(s.uploads) != 1 {
+			t.Errorf("existing datasets %v: expected 1 upload, got %d", existing, len(s.uploads))
+		}
+	}
+}
@@ -38,7 +38,8 @@ import (
 // Evaluator implements llmops.Evaluator for Phoenix.
 type Evaluator struct {
 	client        *phoenix.Client
-	recordResults bool // Whether to record results to Phoenix
+	recordResults bool                  // Whether to record results to Phoenix
+	pool          *AnnotationWorkerPool // Records results in the background when set
 }
 
 // NewEvaluator creates a new Phoenix evaluator.
@@ -59,6 +60,14 @@ func WithRecordResults(record bool) EvaluatorOption {
 	}
 }
 
+// WithAnnotationPool records results through pool instead of a blocking
+// request per evaluation. The caller owns the pool and must Close it.
+func WithAnnotationPool(pool *AnnotationWorkerPool) EvaluatorOption {
+	return func(e *Evaluator) {
+		e.pool = pool
+	}
+}
+
 // NewEvaluatorWithOptions creates an evaluator with options.
 func NewEvaluatorWithOptions(client *phoenix.Client, opts ...EvaluatorOption) *Evaluator {
 	e := NewEvaluator(client)
@@ -92,7 +101,9 @@ func (e *Evaluator) Evaluate(ctx context.Context, input llmops.EvalInput, metric
 	}
 
 	// Record results to Phoenix if enabled and we have a span ID
-	if e.recordResults && input.SpanID != "" {
+	if e.recordResults && input.SpanID != "" && e.pool != nil {
+		e.submitScores(input.SpanID, scores)
+	} else if e.recordResults && input.SpanID != "" {
 		if err := e.recordScoresToPhoenix(ctx, input.SpanID, scores); err != nil {
 			// Log error but don't fail the evaluation
 			result.Metadata = map[string]any{
@@ -159,6 +170,30 @@ func (e *Evaluator) recordScoresToPhoenix(ctx context.Context, spanID string, sc
 	return err
 }
 
+// submitScores queues metric scores on the annotation pool.
+func (e *Evaluator) submitScores(spanID string, scores []llmops.MetricScore) {
+	for _, score := range scores {
+		if score.Error != "" {
+			// Skip errored scores
+			continue
+		}
+
+		job := AnnotationJob{
+			SpanID:      spanID,
+			Name:        score.Name,
+			Score:       score.Score,
+			Explanation: score.Reason,
+			Source:      phoenix.AnnotatorKind(inferAnnotatorKind(score)),
+		}
+		if m, ok := score.Metadata.(map[string]any); ok {
+			if label, ok := m["label"].(string); ok {
+				job.Label = label
+			}
+		}
+		e.pool.Submit(job)
+	}
+}
+
 // addSpanAnnotation adds a single annotation to a span.
 func (e *Evaluator) addSpanAnnotation(ctx context.Context, spanID, name string, score float64, reason, source string) error {
 	result := buildAnnotationResult(score, reason)
@@ -0,0 +1,56 @@
+package evals
+
+import "github.com/agentplexus/omniobserve/llmops"
+
+// NormalizeScore maps score linearly from [min, max] to [0, 1]. Scores
+// outside the range are clamped. If max is not greater than min, it
+// returns 0.
+func NormalizeScore(score, min, max float64) float64 {
+	if max <= min {
+		return 0
+	}
+	n := (score - min) / (max - min)
+	switch {
+	case n < 0:
+		return 0
+	case n > 1:
+		return 1
+	default:
+		return n
+	}
+}
+
+// NormalizedMetric returns a metric that evaluates m and maps its score
+// from [min, max] to [0, 1] with NormalizeScore, so metrics on different
+// scales, such as 0-10 or 0-100, can be compared and aggregated. Scores
+// of failed evaluations are left unchanged.
+func NormalizedMetric(m llmops.Metric, min, max float64) llmops.Metric {
+	return &scoreMetric{Metric: m, transform: func(score float64) float64 {
+		return NormalizeScore(score, min, max)
+	}}
+}
+
+// InvertScore returns a metric that evaluates m and reports 1 - score, for
+// metrics where lower scores are better, such as toxicity. m should
+// produce scores in [0, 1]; see NormalizedMetric. Scores of failed
+// evaluations are left unchanged.
+func InvertScore(m llmops.Metric) llmops.Metric {
+	return &scoreMetric{Metric: m, transform: func(score float64) float64 {
+		return 1 - score
+	}}
+}
+
+// scoreMetric wraps a metric and transforms its scores.
+type scoreMetric struct {
+	llmops.Metric
+	transform func(float64) float64
+}
+
+func (m *scoreMetric) Evaluate(input llmops.EvalInput) (llmops.MetricScore, error) {
+	score, err := m.Metric.Evaluate(input)
+	if err != nil || score.Error != "" {
+		return score, err
+	}
+	score.Score = m.transform(score.Score)
+	return score, nil
+}
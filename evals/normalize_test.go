@@ -0,0 +1,62 @@
+package evals
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/agentplexus/omniobserve/llmops"
+)
+
+func TestNormalizeScore(t *testing.T) {
+	tests := []struct {
+		score, min, max float64
+		want            float64
+	}{
+		{0.5, 0, 1, 0.5},
+		{7, 0, 10, 0.7},
+		{25, 0, 100, 0.25},
+		{3, 1, 5, 0.5},
+		{-1, 0, 10, 0},
+		{11, 0, 10, 1},
+		{5, 5, 5, 0},
+	}
+	for _, tt := range tests {
+		if got := NormalizeScore(tt.score, tt.min, tt.max); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("NormalizeScore(%v, %v, %v) = %v, want %v", tt.score, tt.min, tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizedMetric(t *testing.T) {
+	m := NormalizedMetric(&mockMetric{name: "rating", score: 8}, 0, 10)
+	if got := m.Name(); got != "rating" {
+		t.Errorf("Name() = %q, want %q", got, "rating")
+	}
+	score, err := m.Evaluate(llmops.EvalInput{})
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	if math.Abs(score.Score-0.8) > 1e-9 {
+		t.Errorf("Score = %v, want 0.8", score.Score)
+	}
+}
+
+func TestInvertScore(t *testing.T) {
+	m := InvertScore(NormalizedMetric(&mockMetric{name: "toxicity", score: 20}, 0, 100))
+	score, err := m.Evaluate(llmops.EvalInput{})
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	if math.Abs(score.Score-0.8) > 1e-9 {
+		t.Errorf("Score = %v, want 0.8", score.Score)
+	}
+}
+
+func TestInvertScoreError(t *testing.T) {
+	errMetric := errors.New("metric failed")
+	_, err := InvertScore(&mockMetric{name: "broken", err: errMetric}).Evaluate(llmops.EvalInput{})
+	if !errors.Is(err, errMetric) {
+		t.Errorf("err = %v, want %v", err, errMetric)
+	}
+}
@@ -2,6 +2,8 @@ package phoenix
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/agentplexus/go-phoenix/internal/api"
@@ -20,10 +22,12 @@ type Experiment struct {
 	Repetitions        int
 	CreatedAt          time.Time
 	UpdatedAt          time.Time
+	Metadata           map[string]any
 }
 
 // ListExperiments lists experiments for a dataset.
 func (c *Client) ListExperiments(ctx context.Context, datasetID string, opts ...ListOption) ([]*Experiment, string, error) { //nolint:dupl // Type-safe pattern differs only in types
+	ctx = withRequestIDRecorder(ctx)
 	options := defaultListOptions()
 	for _, opt := range opts {
 		opt(options)
@@ -46,7 +50,7 @@ func (c *Client) ListExperiments(ctx context.Context, datasetID string, opts ...
 
 	resp, ok := res.(*api.ListExperimentsResponseBody)
 	if !ok {
-		return nil, "", &APIError{Message: "unexpected response type"}
+		return nil, "", c.unexpectedResponseError(ctx)
 	}
 
 	experiments := make([]*Experiment, 0, len(resp.Data))
@@ -62,6 +66,190 @@ func (c *Client) ListExperiments(ctx context.Context, datasetID string, opts ...
 	return experiments, nextCursor, nil
 }
 
+// ExperimentOption is a functional option for experiment operations.
+type ExperimentOption func(*experimentOptions)
+
+type experimentOptions struct {
+	name             string
+	description      string
+	metadata         map[string]any
+	repetitions      int
+	datasetVersionID string
+	onCompleted      func(*Experiment)
+
+	recordHash      bool
+	hashTaskVersion string
+	hashMetrics     []string
+}
+
+// WithExperimentName sets the experiment name.
+// If omitted, Phoenix generates a random name.
+func WithExperimentName(name string) ExperimentOption {
+	return func(o *experimentOptions) {
+		o.name = name
+	}
+}
+
+// WithExperimentDescription sets the experiment description.
+func WithExperimentDescription(desc string) ExperimentOption {
+	return func(o *experimentOptions) {
+		o.description = desc
+	}
+}
+
+// WithExperimentMetadata sets the experiment metadata.
+func WithExperimentMetadata(metadata map[string]any) ExperimentOption {
+	return func(o *experimentOptions) {
+		o.metadata = metadata
+	}
+}
+
+// WithExperimentRepetitions sets how many times each example is run.
+func WithExperimentRepetitions(n int) ExperimentOption {
+	return func(o *experimentOptions) {
+		o.repetitions = n
+	}
+}
+
+// WithExperimentDatasetVersion runs the experiment over a specific dataset
+// version instead of the latest one.
+func WithExperimentDatasetVersion(versionID string) ExperimentOption {
+	return func(o *experimentOptions) {
+		o.datasetVersionID = versionID
+	}
+}
+
+// WithExperimentCompletedCallback sets a function that WaitForExperiment
+// calls with the experiment once it completes.
+func WithExperimentCompletedCallback(fn func(*Experiment)) ExperimentOption {
+	return func(o *experimentOptions) {
+		o.onCompleted = fn
+	}
+}
+
+// CreateExperiment creates an experiment over a dataset.
+func (c *Client) CreateExperiment(ctx context.Context, datasetID string, opts ...ExperimentOption) (*Experiment, error) {
+	options := &experimentOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return c.createExperiment(ctx, datasetID, options)
+}
+
+func (c *Client) createExperiment(ctx context.Context, datasetID string, options *experimentOptions) (*Experiment, error) {
+	ctx = withRequestIDRecorder(ctx)
+	req := &api.CreateExperimentRequestBody{}
+	if options.name != "" {
+		req.Name.SetTo(options.name)
+	}
+	if options.description != "" {
+		req.Description.SetTo(options.description)
+	}
+	if options.repetitions > 0 {
+		req.Repetitions.SetTo(options.repetitions)
+	}
+	if options.datasetVersionID != "" {
+		req.VersionID.SetTo(options.datasetVersionID)
+	}
+	metadata := options.metadata
+	if options.recordHash {
+		var err error
+		if metadata, err = c.reproducibilityMetadata(ctx, datasetID, options); err != nil {
+			return nil, err
+		}
+	}
+	if len(metadata) > 0 {
+		metadata, err := encodeRawMap[api.CreateExperimentRequestBodyMetadata](metadata)
+		if err != nil {
+			return nil, err
+		}
+		req.Metadata.SetTo(metadata)
+	}
+
+	res, err := c.apiClient.CreateExperiment(ctx, req, api.CreateExperimentParams{
+		DatasetID: datasetID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp := res.(type) {
+	case *api.CreateExperimentResponseBody:
+		return convertExperiment(&resp.Data), nil
+	case *api.CreateExperimentNotFound:
+		return nil, ErrDatasetNotFound
+	default:
+		return nil, c.unexpectedResponseError(ctx)
+	}
+}
+
+// GetExperiment retrieves an experiment by ID.
+func (c *Client) GetExperiment(ctx context.Context, experimentID string) (*Experiment, error) {
+	ctx = withRequestIDRecorder(ctx)
+	res, err := c.apiClient.GetExperiment(ctx, api.GetExperimentParams{
+		ExperimentID: experimentID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp := res.(type) {
+	case *api.GetExperimentResponseBody:
+		return convertExperiment(&resp.Data), nil
+	case *api.GetExperimentNotFound:
+		return nil, ErrExperimentNotFound
+	default:
+		return nil, c.unexpectedResponseError(ctx)
+	}
+}
+
+// WaitForExperiment polls the experiment every pollInterval until all of its
+// runs have finished, successfully or not, and returns the completed
+// experiment. It returns ErrExperimentTimeout if the context deadline passes
+// first, or the context error if ctx is cancelled. pollInterval must be
+// positive.
+func (c *Client) WaitForExperiment(ctx context.Context, experimentID string, pollInterval time.Duration, opts ...ExperimentOption) (*Experiment, error) {
+	options := &experimentOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if pollInterval <= 0 {
+		return nil, fmt.Errorf("%w: poll interval must be positive", ErrInvalidInput)
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		exp, err := c.GetExperiment(ctx, experimentID)
+		if err != nil && ctx.Err() == nil {
+			return nil, err
+		}
+		if err == nil && exp.completed() {
+			if options.onCompleted != nil {
+				options.onCompleted(exp)
+			}
+			return exp, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, fmt.Errorf("%w: %s", ErrExperimentTimeout, experimentID)
+			}
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// completed reports whether every run of the experiment is accounted for as
+// successful, failed or missing.
+func (e *Experiment) completed() bool {
+	repetitions := max(e.Repetitions, 1)
+	return e.SuccessfulRunCount+e.FailedRunCount+e.MissingRunCount >= e.ExampleCount*repetitions
+}
+
 // DeleteExperiment deletes an experiment.
 func (c *Client) DeleteExperiment(ctx context.Context, experimentID string) error {
 	_, err := c.apiClient.DeleteExperiment(ctx, api.DeleteExperimentParams{
@@ -85,6 +273,7 @@ func convertExperiment(e *api.Experiment) *Experiment {
 		Repetitions:        e.Repetitions,
 		CreatedAt:          e.CreatedAt,
 		UpdatedAt:          e.UpdatedAt,
+		Metadata:           convertRawMap(e.Metadata),
 	}
 	if !e.ProjectName.Null {
 		exp.ProjectName = e.ProjectName.Value
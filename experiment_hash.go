@@ -0,0 +1,89 @@
+package phoenix
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ExperimentHashMetadataKey is the experiment metadata key holding the
+// reproducibility hash recorded with WithReproducibilityHash.
+const ExperimentHashMetadataKey = "reproducibility_hash"
+
+// WithReproducibilityHash records a reproducibility hash in the metadata of
+// the created experiment. The hash covers the dataset version the
+// experiment runs over, taskVersion and the names of the metrics used to
+// score it; see ComputeReproducibilityHash. If no dataset version is set
+// with WithExperimentDatasetVersion, the latest version is looked up first.
+func WithReproducibilityHash(taskVersion string, metricNames ...string) ExperimentOption {
+	return func(o *experimentOptions) {
+		o.hashTaskVersion = taskVersion
+		o.hashMetrics = metricNames
+		o.recordHash = true
+	}
+}
+
+// ComputeReproducibilityHash returns a hex-encoded SHA-256 hash of a dataset
+// version ID, the sorted metric names and taskVersion.
+//
+// Go cannot recover the source of a function at run time, so the task is
+// identified by a version string chosen by the caller, such as
+// "summarize@v3" or a commit hash. Change it whenever the task changes in a
+// way that affects its output.
+func ComputeReproducibilityHash(datasetVersionID, taskVersion string, metricNames []string) string {
+	h := sha256.New()
+	write := func(s string) {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+
+	write(datasetVersionID)
+	names := slices.Clone(metricNames)
+	slices.Sort(names)
+	write(strings.Join(names, "\x1f"))
+	write(taskVersion)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ReproducibilityHash returns the reproducibility hash recorded when the
+// experiment was created, or "" if none was recorded.
+func (e *Experiment) ReproducibilityHash() string {
+	hash, _ := e.Metadata[ExperimentHashMetadataKey].(string)
+	return hash
+}
+
+// CompareExperimentHashes reports whether two experiments have the same
+// reproducibility hash, meaning they ran the same task and metrics over the
+// same dataset version. Experiments without a hash are never equivalent.
+func CompareExperimentHashes(a, b *Experiment) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	hash := a.ReproducibilityHash()
+	return hash != "" && hash == b.ReproducibilityHash()
+}
+
+// reproducibilityMetadata returns options.metadata with the reproducibility
+// hash added, resolving the latest dataset version if none is set.
+func (c *Client) reproducibilityMetadata(ctx context.Context, datasetID string, options *experimentOptions) (map[string]any, error) {
+	versionID := options.datasetVersionID
+	if versionID == "" {
+		versions, err := c.listDatasetVersions(ctx, datasetID)
+		if err != nil {
+			return nil, err
+		}
+		if len(versions) > 0 {
+			versionID = versions[len(versions)-1].VersionID
+		}
+	}
+
+	metadata := maps.Clone(options.metadata)
+	if metadata == nil {
+		metadata = make(map[string]any, 1)
+	}
+	metadata[ExperimentHashMetadataKey] = ComputeReproducibilityHash(versionID, options.hashTaskVersion, options.hashMetrics)
+	return metadata, nil
+}
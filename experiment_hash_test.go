@@ -0,0 +1,109 @@
+package phoenix
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestComputeReproducibilityHash(t *testing.T) {
+	base := ComputeReproducibilityHash("v1", "task@1", []string{"a", "b"})
+	if got := ComputeReproducibilityHash("v1", "task@1", []string{"b", "a"}); got != base {
+		t.Errorf("hash depends on metric order: %s != %s", got, base)
+	}
+
+	tests := []struct {
+		name        string
+		versionID   string
+		taskVersion string
+		metrics     []string
+	}{
+		{"dataset version", "v2", "task@1", []string{"a", "b"}},
+		{"task version", "v1", "task@2", []string{"a", "b"}},
+		{"metrics", "v1", "task@1", []string{"a"}},
+		{"metric boundaries", "v1", "task@1", []string{"ab"}},
+		{"field boundaries", "v1task@1", "", []string{"a", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ComputeReproducibilityHash(tt.versionID, tt.taskVersion, tt.metrics); got == base {
+				t.Errorf("hash unchanged: %s", got)
+			}
+		})
+	}
+}
+
+func TestCompareExperimentHashes(t *testing.T) {
+	withHash := func(hash string) *Experiment {
+		return &Experiment{Metadata: map[string]any{ExperimentHashMetadataKey: hash}}
+	}
+	tests := []struct {
+		name string
+		a, b *Experiment
+		want bool
+	}{
+		{"same hash", withHash("h1"), withHash("h1"), true},
+		{"different hash", withHash("h1"), withHash("h2"), false},
+		{"no hash", &Experiment{}, &Experiment{}, false},
+		{"empty hash", withHash(""), withHash(""), false},
+		{"nil", withHash("h1"), nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompareExperimentHashes(tt.a, tt.b); got != tt.want {
+				t.Errorf("CompareExperimentHashes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithReproducibilityHash(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/datasets/{id}/versions", func(w http.ResponseWriter, r *http.Request) {
+		versions := []map[string]any{
+			{"version_id": "v1", "description": nil, "metadata": map[string]any{}, "created_at": "2025-01-01T00:00:00Z"},
+			{"version_id": "v2", "description": nil, "metadata": map[string]any{}, "created_at": "2025-01-02T00:00:00Z"},
+		}
+		writeJSON(t, w, pageJSON(versions, ""))
+	})
+	mux.HandleFunc("POST /v1/datasets/{id}/experiments", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Metadata map[string]any `json:"metadata"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		experiment := experimentJSON("exp1", 0, 0, 0)
+		experiment["metadata"] = req.Metadata
+		writeJSON(t, w, map[string]any{"data": experiment})
+	})
+	c := newTestClient(t, mux)
+
+	tests := []struct {
+		name      string
+		opts      []ExperimentOption
+		versionID string
+	}{
+		{"latest version", nil, "v2"},
+		{"explicit version", []ExperimentOption{WithExperimentDatasetVersion("v1")}, "v1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append(tt.opts,
+				WithExperimentMetadata(map[string]any{"owner": "me"}),
+				WithReproducibilityHash("task@1", "accuracy"),
+			)
+			experiment, err := c.CreateExperiment(t.Context(), "ds1", opts...)
+			if err != nil {
+				t.Fatalf("CreateExperiment: %v", err)
+			}
+			want := ComputeReproducibilityHash(tt.versionID, "task@1", []string{"accuracy"})
+			if got := experiment.ReproducibilityHash(); got != want {
+				t.Errorf("ReproducibilityHash = %q, want %q", got, want)
+			}
+			if experiment.Metadata["owner"] != "me" {
+				t.Errorf("metadata = %v, want owner kept", experiment.Metadata)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package phoenix
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+)
+
+// experimentResultFormatVersion is the version of the JSON format written by
+// ExperimentResult.Save.
+const experimentResultFormatVersion = 1
+
+// ExperimentStats are aggregate statistics over the runs of an experiment.
+type ExperimentStats struct {
+	RunCount    int
+	FailedCount int
+	// MeanDurationMs is the mean wall-clock duration of a run.
+	MeanDurationMs float64
+	// MeanScores is the mean score of each metric over the runs that have it.
+	MeanScores map[string]float64
+}
+
+// ScoreChange is the change in a metric's mean score between two results.
+type ScoreChange struct {
+	Before float64
+	After  float64
+	Delta  float64
+}
+
+// ExperimentDiff describes how one experiment result differs from another.
+type ExperimentDiff struct {
+	// ScoreChanges holds the change in mean score of every metric present in
+	// both results.
+	ScoreChanges map[string]ScoreChange
+	// AddedExamples are dataset example IDs only run in the other result.
+	AddedExamples []string
+	// RemovedExamples are dataset example IDs only run in this result.
+	RemovedExamples []string
+	// FailedCountDelta is the change in the number of failed runs.
+	FailedCountDelta int
+}
+
+// Stats computes aggregate statistics over the result's runs.
+func (r *ExperimentResult) Stats() ExperimentStats {
+	stats := ExperimentStats{
+		RunCount:   len(r.Runs),
+		MeanScores: make(map[string]float64),
+	}
+
+	var totalMs float64
+	scoreSums := make(map[string]float64)
+	scoreCounts := make(map[string]int)
+	for _, run := range r.Runs {
+		if run.Failed() {
+			stats.FailedCount++
+		}
+		totalMs += float64(run.EndTime.Sub(run.StartTime)) / float64(time.Millisecond)
+		for name, score := range run.Scores {
+			scoreSums[name] += score
+			scoreCounts[name]++
+		}
+	}
+	if len(r.Runs) > 0 {
+		stats.MeanDurationMs = totalMs / float64(len(r.Runs))
+	}
+	for name, sum := range scoreSums {
+		stats.MeanScores[name] = sum / float64(scoreCounts[name])
+	}
+	return stats
+}
+
+// Diff compares the result with other, treating r as the baseline.
+func (r *ExperimentResult) Diff(other *ExperimentResult) *ExperimentDiff {
+	before, after := r.Stats(), other.Stats()
+	diff := &ExperimentDiff{
+		ScoreChanges:     make(map[string]ScoreChange),
+		FailedCountDelta: after.FailedCount - before.FailedCount,
+	}
+	for name, b := range before.MeanScores {
+		if a, ok := after.MeanScores[name]; ok {
+			diff.ScoreChanges[name] = ScoreChange{Before: b, After: a, Delta: a - b}
+		}
+	}
+
+	mine, theirs := r.exampleIDs(), other.exampleIDs()
+	for id := range theirs {
+		if _, ok := mine[id]; !ok {
+			diff.AddedExamples = append(diff.AddedExamples, id)
+		}
+	}
+	for id := range mine {
+		if _, ok := theirs[id]; !ok {
+			diff.RemovedExamples = append(diff.RemovedExamples, id)
+		}
+	}
+	slices.Sort(diff.AddedExamples)
+	slices.Sort(diff.RemovedExamples)
+	return diff
+}
+
+func (r *ExperimentResult) exampleIDs() map[string]struct{} {
+	ids := make(map[string]struct{}, len(r.Runs))
+	for _, run := range r.Runs {
+		ids[run.DatasetExampleID] = struct{}{}
+	}
+	return ids
+}
+
+// experimentResultFile is the JSON representation of a saved ExperimentResult.
+type experimentResultFile struct {
+	FormatVersion int                   `json:"format_version"`
+	Experiment    *experimentRecord     `json:"experiment,omitempty"`
+	Runs          []experimentRunRecord `json:"runs"`
+	Stats         experimentStatsRecord `json:"stats"`
+}
+
+type experimentRecord struct {
+	ID                 string    `json:"id"`
+	DatasetID          string    `json:"dataset_id"`
+	DatasetVersionID   string    `json:"dataset_version_id"`
+	ProjectName        string    `json:"project_name,omitempty"`
+	ExampleCount       int       `json:"example_count"`
+	SuccessfulRunCount int       `json:"successful_run_count"`
+	FailedRunCount     int       `json:"failed_run_count"`
+	MissingRunCount    int       `json:"missing_run_count"`
+	Repetitions        int       `json:"repetitions"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+type experimentRunRecord struct {
+	ID               string             `json:"id"`
+	ExperimentID     string             `json:"experiment_id"`
+	DatasetExampleID string             `json:"dataset_example_id"`
+	RepetitionNumber int                `json:"repetition_number"`
+	Output           any                `json:"output"`
+	Error            string             `json:"error,omitempty"`
+	TraceID          string             `json:"trace_id,omitempty"`
+	SpanID           string             `json:"span_id,omitempty"`
+	StartTime        time.Time          `json:"start_time"`
+	EndTime          time.Time          `json:"end_time"`
+	Scores           map[string]float64 `json:"scores,omitempty"`
+}
+
+type experimentStatsRecord struct {
+	RunCount       int                `json:"run_count"`
+	FailedCount    int                `json:"failed_count"`
+	MeanDurationMs float64            `json:"mean_duration_ms"`
+	MeanScores     map[string]float64 `json:"mean_scores"`
+}
+
+// Save writes the result, including every run and its aggregate statistics,
+// to path as indented JSON. Run outputs must be JSON-encodable.
+func (r *ExperimentResult) Save(path string) error {
+	stats := r.Stats()
+	file := experimentResultFile{
+		FormatVersion: experimentResultFormatVersion,
+		Runs:          make([]experimentRunRecord, 0, len(r.Runs)),
+		Stats: experimentStatsRecord{
+			RunCount:       stats.RunCount,
+			FailedCount:    stats.FailedCount,
+			MeanDurationMs: stats.MeanDurationMs,
+			MeanScores:     stats.MeanScores,
+		},
+	}
+	if e := r.Experiment; e != nil {
+		file.Experiment = &experimentRecord{
+			ID:                 e.ID,
+			DatasetID:          e.DatasetID,
+			DatasetVersionID:   e.DatasetVersionID,
+			ProjectName:        e.ProjectName,
+			ExampleCount:       e.ExampleCount,
+			SuccessfulRunCount: e.SuccessfulRunCount,
+			FailedRunCount:     e.FailedRunCount,
+			MissingRunCount:    e.MissingRunCount,
+			Repetitions:        e.Repetitions,
+			CreatedAt:          e.CreatedAt,
+			UpdatedAt:          e.UpdatedAt,
+		}
+	}
+	for _, run := range r.Runs {
+		file.Runs = append(file.Runs, experimentRunRecord{
+			ID:               run.ID,
+			ExperimentID:     run.ExperimentID,
+			DatasetExampleID: run.DatasetExampleID,
+			RepetitionNumber: run.RepetitionNumber,
+			Output:           run.Output,
+			Error:            run.Error,
+			TraceID:          run.TraceID,
+			SpanID:           run.SpanID,
+			StartTime:        run.StartTime,
+			EndTime:          run.EndTime,
+			Scores:           run.Scores,
+		})
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding experiment result: %w", err)
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// LoadExperimentResult reads a result written by ExperimentResult.Save.
+// Run outputs are decoded as generic JSON values. The saved aggregate
+// statistics are informational; Stats recomputes them from the runs.
+func LoadExperimentResult(path string) (*ExperimentResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file experimentResultFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("decoding experiment result: %w", err)
+	}
+	if file.FormatVersion != experimentResultFormatVersion {
+		return nil, fmt.Errorf("%w: unsupported experiment result format version %d", ErrInvalidInput, file.FormatVersion)
+	}
+
+	result := &ExperimentResult{Runs: make([]*ExperimentRun, 0, len(file.Runs))}
+	if e := file.Experiment; e != nil {
+		result.Experiment = &Experiment{
+			ID:                 e.ID,
+			DatasetID:          e.DatasetID,
+			DatasetVersionID:   e.DatasetVersionID,
+			ProjectName:        e.ProjectName,
+			ExampleCount:       e.ExampleCount,
+			SuccessfulRunCount: e.SuccessfulRunCount,
+			FailedRunCount:     e.FailedRunCount,
+			MissingRunCount:    e.MissingRunCount,
+			Repetitions:        e.Repetitions,
+			CreatedAt:          e.CreatedAt,
+			UpdatedAt:          e.UpdatedAt,
+		}
+	}
+	for _, run := range file.Runs {
+		result.Runs = append(result.Runs, &ExperimentRun{
+			ID:               run.ID,
+			ExperimentID:     run.ExperimentID,
+			DatasetExampleID: run.DatasetExampleID,
+			RepetitionNumber: run.RepetitionNumber,
+			Output:           run.Output,
+			Error:            run.Error,
+			TraceID:          run.TraceID,
+			SpanID:           run.SpanID,
+			StartTime:        run.StartTime,
+			EndTime:          run.EndTime,
+			Scores:           run.Scores,
+		})
+	}
+	return result, nil
+}
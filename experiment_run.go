@@ -0,0 +1,221 @@
+package phoenix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/agentplexus/go-phoenix/internal/api"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// experimentErrorAnnotationName is the annotation recorded on the spans of failed runs.
+const experimentErrorAnnotationName = "experiment_error"
+
+// errorSummaryKeyLength is the number of characters of an error message used
+// to group errors in ExperimentResult.ErrorSummary.
+const errorSummaryKeyLength = 100
+
+// ExperimentTask is the function evaluated against each dataset example.
+// The context carries a span for the run, so spans started from it are
+// grouped under the run's trace.
+type ExperimentTask func(ctx context.Context, example *DatasetExample) (any, error)
+
+// ExperimentRun is the outcome of running a task against one dataset example.
+type ExperimentRun struct {
+	ID               string
+	ExperimentID     string
+	DatasetExampleID string
+	RepetitionNumber int
+	Output           any
+	// Error is the task error message, empty if the run succeeded.
+	Error     string
+	TraceID   string
+	SpanID    string
+	StartTime time.Time
+	EndTime   time.Time
+	// Scores holds metric scores by name. RunExperiment leaves it empty;
+	// callers that evaluate run outputs fill it in.
+	Scores map[string]float64
+}
+
+// Failed reports whether the run's task returned an error.
+func (r *ExperimentRun) Failed() bool {
+	return r.Error != ""
+}
+
+// ExperimentResult holds an experiment and its runs.
+type ExperimentResult struct {
+	Experiment *Experiment
+	Runs       []*ExperimentRun
+}
+
+// ErrorSummary counts failed runs grouped by error. Errors are grouped by
+// the first 100 characters of their message so that errors differing only
+// in a trailing detail are counted together.
+func (r *ExperimentResult) ErrorSummary() map[string]int {
+	summary := make(map[string]int)
+	for _, run := range r.Runs {
+		if !run.Failed() {
+			continue
+		}
+		key := run.Error
+		if runes := []rune(key); len(runes) > errorSummaryKeyLength {
+			key = string(runes[:errorSummaryKeyLength])
+		}
+		summary[key]++
+	}
+	return summary
+}
+
+// FailedExamples returns the runs whose task returned an error.
+func (r *ExperimentResult) FailedExamples() []*ExperimentRun {
+	var failed []*ExperimentRun
+	for _, run := range r.Runs {
+		if run.Failed() {
+			failed = append(failed, run)
+		}
+	}
+	return failed
+}
+
+// RunExperiment creates an experiment over a dataset, runs task against each
+// example (once per repetition) and records every run in Phoenix.
+//
+// A task error does not stop the experiment: it is captured in the run's
+// Error field and sent to Phoenix with the run. Each run is executed inside
+// a span from the global OTEL tracer provider; when that provider exports to
+// Phoenix, failed runs are also annotated on their span with a CODE
+// annotation scored 0.0. If recording those annotations fails, the result is
+// returned together with the error.
+func (c *Client) RunExperiment(ctx context.Context, datasetID string, task ExperimentTask, opts ...ExperimentOption) (*ExperimentResult, error) {
+	options := &experimentOptions{repetitions: 1}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if task == nil {
+		return nil, fmt.Errorf("%w: task is required", ErrInvalidInput)
+	}
+	if options.repetitions < 1 {
+		options.repetitions = 1
+	}
+
+	experiment, err := c.createExperiment(ctx, datasetID, options)
+	if err != nil {
+		return nil, err
+	}
+
+	examples, err := c.getDatasetExamplesAtVersion(ctx, datasetID, options.datasetVersionID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ExperimentResult{Experiment: experiment}
+	for rep := 1; rep <= options.repetitions; rep++ {
+		for _, example := range examples {
+			run, err := c.runExperimentTask(ctx, experiment.ID, example, rep, task)
+			if err != nil {
+				return result, err
+			}
+			result.Runs = append(result.Runs, run)
+		}
+	}
+
+	if err := c.annotateFailedRuns(ctx, result.FailedExamples()); err != nil {
+		return result, fmt.Errorf("phoenix: recording failed run annotations: %w", err)
+	}
+
+	return result, nil
+}
+
+// runExperimentTask runs task against a single example and records the run.
+// The returned error is only non-nil if the run could not be recorded.
+func (c *Client) runExperimentTask(ctx context.Context, experimentID string, example *DatasetExample, rep int, task ExperimentTask) (*ExperimentRun, error) {
+	ctx = withRequestIDRecorder(ctx)
+	parent := trace.SpanContextFromContext(ctx)
+	ctx, span := otel.Tracer("github.com/agentplexus/go-phoenix").Start(ctx, "experiment_run")
+
+	run := &ExperimentRun{
+		ExperimentID:     experimentID,
+		DatasetExampleID: example.ID,
+		RepetitionNumber: rep,
+		StartTime:        time.Now(),
+	}
+	// The no-op tracer returns the parent span context, which must not be
+	// mistaken for a span of this run.
+	if sc := span.SpanContext(); sc.IsValid() && sc.SpanID() != parent.SpanID() {
+		run.TraceID = sc.TraceID().String()
+		run.SpanID = sc.SpanID().String()
+	}
+
+	output, taskErr := task(ctx, example)
+	run.EndTime = time.Now()
+	run.Output = output
+	if taskErr != nil {
+		run.Error = taskErr.Error()
+		span.RecordError(taskErr)
+	}
+	span.End()
+
+	outputJSON, err := json.Marshal(output)
+	if err != nil {
+		// Keep the run: record the encoding failure as its error.
+		outputJSON = []byte("null")
+		if run.Error == "" {
+			run.Error = fmt.Sprintf("encoding output: %v", err)
+		}
+	}
+
+	req := &api.CreateExperimentRunRequestBody{
+		DatasetExampleID: example.ID,
+		Output:           outputJSON,
+		RepetitionNumber: rep,
+		StartTime:        run.StartTime,
+		EndTime:          run.EndTime,
+	}
+	if run.Error != "" {
+		req.Error.SetTo(run.Error)
+	}
+	if run.TraceID != "" {
+		req.TraceID.SetTo(run.TraceID)
+	}
+
+	res, err := c.apiClient.CreateExperimentRun(ctx, req, api.CreateExperimentRunParams{
+		ExperimentID: experimentID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp := res.(type) {
+	case *api.CreateExperimentRunResponseBody:
+		run.ID = resp.Data.ID
+		return run, nil
+	case *api.CreateExperimentRunNotFound:
+		return nil, ErrExperimentNotFound
+	default:
+		return nil, c.unexpectedResponseError(ctx)
+	}
+}
+
+// annotateFailedRuns records a CODE annotation scored 0.0 on the span of
+// each failed run that has one.
+func (c *Client) annotateFailedRuns(ctx context.Context, runs []*ExperimentRun) error {
+	annotations := make([]*Annotation, 0, len(runs))
+	for _, run := range runs {
+		if run.SpanID == "" {
+			continue
+		}
+		annotations = append(annotations, &Annotation{
+			SpanID:      run.SpanID,
+			Name:        experimentErrorAnnotationName,
+			Score:       0.0,
+			Label:       "error",
+			Explanation: run.Error,
+			Source:      AnnotatorKindCode,
+		})
+	}
+	return c.CreateSpanAnnotations(ctx, annotations)
+}
@@ -0,0 +1,134 @@
+package phoenix
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestExperimentResultErrors(t *testing.T) {
+	long := strings.Repeat("x", errorSummaryKeyLength)
+	result := &ExperimentResult{Runs: []*ExperimentRun{
+		{DatasetExampleID: "ex1"},
+		{DatasetExampleID: "ex2", Error: "timeout"},
+		{DatasetExampleID: "ex3", Error: long + " after 1s"},
+		{DatasetExampleID: "ex4", Error: long + " after 2s"},
+		{DatasetExampleID: "ex5", Error: "timeout"},
+	}}
+
+	summary := result.ErrorSummary()
+	if len(summary) != 2 || summary["timeout"] != 2 || summary[long] != 2 {
+		t.Errorf("ErrorSummary = %v, want timeout and the truncated error twice each", summary)
+	}
+
+	failed := result.FailedExamples()
+	if len(failed) != 4 || failed[0].DatasetExampleID != "ex2" || failed[3].DatasetExampleID != "ex5" {
+		t.Errorf("FailedExamples = %+v, want ex2 to ex5", failed)
+	}
+	if result.Runs[0].Failed() {
+		t.Error("Failed = true for a run without an error")
+	}
+
+	if got := (&ExperimentResult{}).ErrorSummary(); len(got) != 0 {
+		t.Errorf("ErrorSummary of an empty result = %v", got)
+	}
+}
+
+func TestRunExperimentErrors(t *testing.T) {
+	prev := otel.GetTracerProvider()
+	tp := sdktrace.NewTracerProvider()
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prev)
+		_ = tp.Shutdown(context.Background())
+	})
+
+	type runRequest struct {
+		DatasetExampleID string  `json:"dataset_example_id"`
+		Error            *string `json:"error"`
+		TraceID          string  `json:"trace_id"`
+	}
+	var runs []runRequest
+	var annotations []annotationRequest
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/datasets/{id}/experiments", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, map[string]any{"data": experimentJSON("exp1", 2, 0, 0)})
+	})
+	mux.HandleFunc("GET /v1/datasets/{id}/examples", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, examplesResponse("ds1", "v1",
+			exampleJSON("ex1", map[string]any{"q": "ok"}, nil),
+			exampleJSON("ex2", map[string]any{"q": "fail"}, nil),
+		))
+	})
+	mux.HandleFunc("POST /v1/experiments/{id}/runs", func(w http.ResponseWriter, r *http.Request) {
+		var req runRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding run: %v", err)
+		}
+		runs = append(runs, req)
+		writeJSON(t, w, map[string]any{"data": map[string]any{"id": "run-" + req.DatasetExampleID}})
+	})
+	mux.HandleFunc("POST /v1/span_annotations", func(w http.ResponseWriter, r *http.Request) {
+		var req annotationRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding annotations: %v", err)
+		}
+		annotations = append(annotations, req)
+		writeJSON(t, w, map[string]any{"data": []map[string]any{{"id": "a1"}}})
+	})
+	c := newTestClient(t, mux)
+
+	task := func(_ context.Context, ex *DatasetExample) (any, error) {
+		if ex.ID == "ex2" {
+			return nil, errors.New("model unavailable")
+		}
+		return "answer", nil
+	}
+	result, err := c.RunExperiment(t.Context(), "ds1", task)
+	if err != nil {
+		t.Fatalf("RunExperiment: %v", err)
+	}
+
+	if len(result.Runs) != 2 {
+		t.Fatalf("got %d runs, want 2", len(result.Runs))
+	}
+	ok, failed := result.Runs[0], result.Runs[1]
+	if ok.Failed() || ok.ID != "run-ex1" || ok.Output != "answer" {
+		t.Errorf("successful run = %+v", ok)
+	}
+	if failed.Error != "model unavailable" || failed.ID != "run-ex2" || failed.SpanID == "" {
+		t.Errorf("failed run = %+v", failed)
+	}
+	if got := result.ErrorSummary(); got["model unavailable"] != 1 || len(got) != 1 {
+		t.Errorf("ErrorSummary = %v", got)
+	}
+
+	if len(runs) != 2 || runs[0].Error != nil || runs[1].Error == nil || *runs[1].Error != "model unavailable" {
+		t.Errorf("recorded runs = %+v, want the error on ex2 only", runs)
+	}
+	if runs[1].TraceID != failed.TraceID {
+		t.Errorf("recorded trace ID = %q, want %q", runs[1].TraceID, failed.TraceID)
+	}
+
+	if len(annotations) != 1 || len(annotations[0].Data) != 1 {
+		t.Fatalf("annotations = %+v, want one for the failed run", annotations)
+	}
+	a := annotations[0].Data[0]
+	if a.SpanID != failed.SpanID || a.Name != experimentErrorAnnotationName || a.AnnotatorKind != "CODE" ||
+		a.Result.Score == nil || *a.Result.Score != 0 || a.Result.Explanation != "model unavailable" {
+		t.Errorf("annotation = %+v", a)
+	}
+}
+
+func TestRunExperimentNilTask(t *testing.T) {
+	c := newTestClient(t, http.NewServeMux())
+	if _, err := c.RunExperiment(t.Context(), "ds1", nil); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("RunExperiment(nil) = %v, want %v", err, ErrInvalidInput)
+	}
+}
@@ -0,0 +1,158 @@
+package phoenix
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/agentplexus/omniobserve/llmops"
+)
+
+// ExperimentRunResult is a run streamed by RunExperimentStream, together
+// with the scores of its output.
+type ExperimentRunResult struct {
+	// Run is the recorded run. It is nil if the run could not be recorded.
+	Run *ExperimentRun
+
+	// ExampleID is the ID of the dataset example the run was evaluated on.
+	ExampleID string
+
+	// Output is the task output.
+	Output any
+
+	// Scores holds the metric scores of the output. Metrics are not
+	// evaluated for failed runs.
+	Scores []llmops.MetricScore
+
+	// Err is the task error, a recovered task panic, or the error that
+	// prevented the run from being recorded.
+	Err error
+}
+
+// IsError reports whether the run failed.
+func (r ExperimentRunResult) IsError() bool {
+	return r.Err != nil
+}
+
+// RunExperimentStream is like RunExperiment, but sends the result of each
+// run on the returned channel as soon as it is recorded, so callers can
+// report progress while the experiment runs. Each successful output is
+// scored with metrics, using the example output as the expected value, and
+// the scores are also stored in the run's Scores.
+//
+// The experiment is created and the examples fetched before
+// RunExperimentStream returns; errors doing so are returned directly, and an
+// experiment created before the failure is deleted. The channel is closed
+// once every example has been processed, when a run cannot be recorded
+// (after sending that failure), or when ctx is done. A panic in fn is
+// recovered and reported as the run's error, and a panic in a metric as the
+// error of its score. Callers must drain the channel or cancel ctx.
+func (c *Client) RunExperimentStream(ctx context.Context, datasetID string, fn func(context.Context, DatasetExample) (any, error), metrics ...llmops.Metric) (<-chan ExperimentRunResult, error) {
+	if fn == nil {
+		return nil, fmt.Errorf("%w: task is required", ErrInvalidInput)
+	}
+
+	experiment, err := c.createExperiment(ctx, datasetID, &experimentOptions{repetitions: 1})
+	if err != nil {
+		return nil, err
+	}
+
+	examples, err := c.getDatasetExamplesAtVersion(ctx, datasetID, "")
+	if err != nil {
+		// Do not leave an empty experiment behind.
+		if delErr := c.DeleteExperiment(context.WithoutCancel(ctx), experiment.ID); delErr != nil {
+			slog.Warn("phoenix: deleting experiment", "experiment", experiment.ID, "error", delErr)
+		}
+		return nil, err
+	}
+
+	task := func(ctx context.Context, example *DatasetExample) (output any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("phoenix: experiment task panicked: %v", r)
+			}
+		}()
+		return fn(ctx, *example)
+	}
+
+	results := make(chan ExperimentRunResult)
+	go func() {
+		defer close(results)
+
+		var failed []*ExperimentRun
+		defer func() {
+			if err := c.annotateFailedRuns(context.WithoutCancel(ctx), failed); err != nil {
+				slog.Warn("phoenix: recording failed run annotations", "experiment", experiment.ID, "error", err)
+			}
+		}()
+
+		for _, example := range examples {
+			if ctx.Err() != nil {
+				return
+			}
+
+			result := ExperimentRunResult{ExampleID: example.ID}
+			run, err := c.runExperimentTask(ctx, experiment.ID, example, 1, task)
+			switch {
+			case err != nil:
+				result.Err = err
+			case run.Failed():
+				result.Run, result.Output = run, run.Output
+				result.Err = fmt.Errorf("phoenix: experiment run: %s", run.Error)
+				failed = append(failed, run)
+			default:
+				result.Run, result.Output = run, run.Output
+				result.Scores = scoreExperimentRun(example, run, metrics)
+			}
+
+			select {
+			case results <- result:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return results, nil
+}
+
+// scoreExperimentRun evaluates metrics on the run output and records the
+// scores in run.Scores. A metric error or panic is reported in its score.
+func scoreExperimentRun(example *DatasetExample, run *ExperimentRun, metrics []llmops.Metric) []llmops.MetricScore {
+	if len(metrics) == 0 {
+		return nil
+	}
+
+	input := llmops.EvalInput{
+		Input:    example.Input,
+		Output:   run.Output,
+		Expected: example.Output,
+		Metadata: example.Metadata,
+		TraceID:  run.TraceID,
+		SpanID:   run.SpanID,
+	}
+	scores := make([]llmops.MetricScore, 0, len(metrics))
+	run.Scores = make(map[string]float64, len(metrics))
+	for _, metric := range metrics {
+		score, err := evaluateMetric(metric, input)
+		if err != nil {
+			scores = append(scores, llmops.MetricScore{Name: metric.Name(), Error: err.Error()})
+			continue
+		}
+		scores = append(scores, score)
+		run.Scores[score.Name] = score.Score
+	}
+	return scores
+}
+
+// evaluateMetric is metric.Evaluate, with a panic returned as an error.
+func evaluateMetric(metric llmops.Metric, input llmops.EvalInput) (score llmops.MetricScore, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("phoenix: metric %s panicked: %v", metric.Name(), r)
+		}
+	}()
+	return metric.Evaluate(input)
+}
@@ -0,0 +1,130 @@
+package phoenix
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/agentplexus/omniobserve/llmops"
+)
+
+// funcMetric is a metric that scores with evaluate.
+type funcMetric struct {
+	name     string
+	evaluate func(llmops.EvalInput) (llmops.MetricScore, error)
+}
+
+func (m funcMetric) Name() string { return m.name }
+
+func (m funcMetric) Evaluate(input llmops.EvalInput) (llmops.MetricScore, error) {
+	return m.evaluate(input)
+}
+
+// experimentStreamMux serves dataset ds1 with examples and records deleted
+// experiments.
+func experimentStreamMux(t *testing.T, deleted *atomic.Int32, examples ...map[string]any) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/datasets/{id}/experiments", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, map[string]any{"data": experimentJSON("exp1", len(examples), 0, 0)})
+	})
+	mux.HandleFunc("GET /v1/datasets/{id}/examples", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") != "ds1" {
+			notFound(w)
+			return
+		}
+		writeJSON(t, w, examplesResponse("ds1", "v1", examples...))
+	})
+	mux.HandleFunc("POST /v1/experiments/{id}/runs", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, map[string]any{"data": map[string]any{"id": "run1"}})
+	})
+	mux.HandleFunc("DELETE /v1/experiments/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") == "exp1" {
+			deleted.Add(1)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	return mux
+}
+
+func TestRunExperimentStreamPanics(t *testing.T) {
+	var deleted atomic.Int32
+	mux := experimentStreamMux(t, &deleted,
+		exampleJSON("ex1", map[string]any{"q": "ok"}, nil),
+		exampleJSON("ex2", map[string]any{"q": "panic"}, nil),
+	)
+	c := newTestClient(t, mux)
+
+	task := func(_ context.Context, ex DatasetExample) (any, error) {
+		if ex.ID == "ex2" {
+			panic("task failed")
+		}
+		return "answer", nil
+	}
+	panicking := funcMetric{name: "panicking", evaluate: func(llmops.EvalInput) (llmops.MetricScore, error) {
+		panic("metric failed")
+	}}
+	constant := funcMetric{name: "constant", evaluate: func(llmops.EvalInput) (llmops.MetricScore, error) {
+		return llmops.MetricScore{Name: "constant", Score: 1}, nil
+	}}
+
+	results, err := c.RunExperimentStream(t.Context(), "ds1", task, panicking, constant)
+	if err != nil {
+		t.Fatalf("RunExperimentStream: %v", err)
+	}
+	var got []ExperimentRunResult
+	for result := range results {
+		got = append(got, result)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d results, want 2", len(got))
+	}
+
+	ok := got[0]
+	if ok.IsError() {
+		t.Fatalf("ex1 failed: %v", ok.Err)
+	}
+	if len(ok.Scores) != 2 {
+		t.Fatalf("ex1 scores = %+v, want 2", ok.Scores)
+	}
+	if s := ok.Scores[0]; s.Name != "panicking" || !strings.Contains(s.Error, "metric failed") {
+		t.Errorf("panicking metric score = %+v, want recovered panic", s)
+	}
+	if s := ok.Scores[1]; s.Name != "constant" || s.Score != 1 || s.Error != "" {
+		t.Errorf("constant metric score = %+v", s)
+	}
+
+	failed := got[1]
+	if !failed.IsError() || !strings.Contains(failed.Err.Error(), "task failed") {
+		t.Errorf("ex2 error = %v, want recovered panic", failed.Err)
+	}
+	if failed.Scores != nil {
+		t.Errorf("ex2 scores = %+v, want none for a failed run", failed.Scores)
+	}
+	if n := deleted.Load(); n != 0 {
+		t.Errorf("experiment deleted %d times, want 0", n)
+	}
+}
+
+func TestRunExperimentStreamDeletesOnFailure(t *testing.T) {
+	var deleted atomic.Int32
+	c := newTestClient(t, experimentStreamMux(t, &deleted))
+
+	task := func(context.Context, DatasetExample) (any, error) { return nil, nil }
+	results, err := c.RunExperimentStream(t.Context(), "ds2", task)
+	if !errors.Is(err, ErrDatasetNotFound) {
+		t.Errorf("RunExperimentStream = %v, want %v", err, ErrDatasetNotFound)
+	}
+	if results != nil {
+		t.Error("RunExperimentStream returned a channel on failure")
+	}
+	if n := deleted.Load(); n != 1 {
+		t.Errorf("experiment deleted %d times, want 1", n)
+	}
+}
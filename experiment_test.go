@@ -0,0 +1,74 @@
+package phoenix
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWaitForExperiment(t *testing.T) {
+	polls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/experiments/{id}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.PathValue("id") {
+		case "done":
+			polls++
+			writeJSON(t, w, map[string]any{"data": experimentJSON("done", 3, min(polls, 2), 1)})
+		case "stuck":
+			writeJSON(t, w, map[string]any{"data": experimentJSON("stuck", 3, 0, 0)})
+		case "repeated":
+			// 2 examples run twice: 4 runs, one of them missing.
+			exp := experimentJSON("repeated", 2, 2, 1)
+			exp["repetitions"] = 2
+			exp["missing_run_count"] = 1
+			writeJSON(t, w, map[string]any{"data": exp})
+		default:
+			notFound(w)
+		}
+	})
+	c := newTestClient(t, mux)
+
+	var completed []*Experiment
+	onCompleted := WithExperimentCompletedCallback(func(exp *Experiment) {
+		completed = append(completed, exp)
+	})
+
+	exp, err := c.WaitForExperiment(t.Context(), "done", time.Millisecond, onCompleted)
+	if err != nil {
+		t.Fatalf("WaitForExperiment: %v", err)
+	}
+	if polls != 2 || exp.SuccessfulRunCount != 2 {
+		t.Errorf("completed after %d polls with %d successful runs, want 2 and 2", polls, exp.SuccessfulRunCount)
+	}
+	if len(completed) != 1 || completed[0] != exp {
+		t.Errorf("callback called with %v, want the completed experiment once", completed)
+	}
+
+	// Missing runs count towards completion.
+	exp, err = c.WaitForExperiment(t.Context(), "repeated", time.Millisecond, onCompleted)
+	if err != nil {
+		t.Fatalf("WaitForExperiment: %v", err)
+	}
+	if exp.MissingRunCount != 1 || len(completed) != 2 {
+		t.Errorf("repeated experiment = %+v after %d callbacks, want it completed with a missing run", exp, len(completed))
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.WaitForExperiment(ctx, "stuck", time.Millisecond, onCompleted); !errors.Is(err, ErrExperimentTimeout) {
+		t.Errorf("stuck experiment: err = %v, want ErrExperimentTimeout", err)
+	}
+	if len(completed) != 2 {
+		t.Errorf("callback called %d times, want it skipped on timeout", len(completed))
+	}
+	if _, err := c.WaitForExperiment(t.Context(), "missing", time.Millisecond); !errors.Is(err, ErrExperimentNotFound) {
+		t.Errorf("missing experiment: err = %v, want ErrExperimentNotFound", err)
+	}
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if _, err := c.WaitForExperiment(t.Context(), "done", interval); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("interval %v: err = %v, want ErrInvalidInput", interval, err)
+		}
+	}
+}
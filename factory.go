@@ -0,0 +1,45 @@
+package phoenix
+
+import (
+	phoenixotel "github.com/agentplexus/go-phoenix/otel"
+)
+
+// ClientFactory creates Phoenix clients. Depend on it instead of calling
+// NewClient directly to substitute clients in tests or dependency
+// injection containers.
+type ClientFactory interface {
+	NewClient(opts ...Option) (*Client, error)
+}
+
+// DefaultClientFactory is the ClientFactory that calls NewClient.
+type DefaultClientFactory struct{}
+
+// NewClient creates a client with NewClient.
+func (DefaultClientFactory) NewClient(opts ...Option) (*Client, error) {
+	return NewClient(opts...)
+}
+
+// NewClientFactory returns a DefaultClientFactory.
+func NewClientFactory() ClientFactory {
+	return DefaultClientFactory{}
+}
+
+// TracerProviderFactory creates tracer providers that export to Phoenix.
+// It is the counterpart of ClientFactory for otel.Register.
+type TracerProviderFactory interface {
+	Register(opts ...phoenixotel.Option) (*phoenixotel.TracerProvider, error)
+}
+
+// DefaultTracerProviderFactory is the TracerProviderFactory that calls
+// otel.Register.
+type DefaultTracerProviderFactory struct{}
+
+// Register creates a tracer provider with otel.Register.
+func (DefaultTracerProviderFactory) Register(opts ...phoenixotel.Option) (*phoenixotel.TracerProvider, error) {
+	return phoenixotel.Register(opts...)
+}
+
+// NewTracerProviderFactory returns a DefaultTracerProviderFactory.
+func NewTracerProviderFactory() TracerProviderFactory {
+	return DefaultTracerProviderFactory{}
+}
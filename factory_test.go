@@ -0,0 +1,59 @@
+package phoenix
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	phoenixotel "github.com/agentplexus/go-phoenix/otel"
+)
+
+// failingClientFactory is a ClientFactory substituted in tests.
+type failingClientFactory struct{ calls int }
+
+func (f *failingClientFactory) NewClient(...Option) (*Client, error) {
+	f.calls++
+	return nil, errors.New("no client in tests")
+}
+
+func TestClientFactory(t *testing.T) {
+	c, err := NewClientFactory().NewClient(WithURL("http://phoenix.test"), WithProjectName("factory"))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if c.Config().ProjectName != "factory" {
+		t.Errorf("ProjectName = %q, want factory", c.Config().ProjectName)
+	}
+
+	// Callers depending on the interface accept substitutes.
+	fake := &failingClientFactory{}
+	var factory ClientFactory = fake
+	if _, err := factory.NewClient(); err == nil || fake.calls != 1 {
+		t.Errorf("substituted NewClient = %v after %d calls", err, fake.calls)
+	}
+}
+
+func TestTracerProviderFactory(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	tp, err := NewTracerProviderFactory().Register(
+		phoenixotel.WithEndpoint(srv.URL),
+		phoenixotel.WithBatch(false),
+		phoenixotel.WithGlobalProvider(false),
+	)
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	_, span := tp.Tracer("test").Start(context.Background(), "span")
+	span.End()
+	if n := tp.DiagnosticDump().ExportedSpans; n != 1 {
+		t.Errorf("exported %d spans, want 1", n)
+	}
+}
@@ -0,0 +1,165 @@
+package phoenix
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// listAllExperimentConcurrency caps the number of datasets whose experiments
+// ListAll lists at the same time.
+const listAllExperimentConcurrency = 8
+
+// ListAllOptions selects the resource types fetched by ListAll.
+type ListAllOptions struct {
+	Projects    bool
+	Datasets    bool
+	Prompts     bool
+	Experiments bool
+}
+
+// ListAllResult holds the resources fetched by ListAll. Fields for resource
+// types that were not requested, or that failed, are nil.
+type ListAllResult struct {
+	Projects    []*Project
+	Datasets    []*Dataset
+	Prompts     []*Prompt
+	Experiments []*Experiment
+}
+
+// ListAll fetches every page of the selected resource types concurrently.
+// Experiments belong to datasets, so they are listed for each dataset once
+// the datasets have been fetched.
+//
+// A failure to list one resource type does not stop the others. The result
+// holds whatever was fetched, and the failures are returned together as a
+// *MultiError.
+func ListAll(ctx context.Context, client *Client, opts ListAllOptions) (*ListAllResult, error) {
+	result := &ListAllResult{}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	record := func(resource string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, fmt.Errorf("listing %s: %w", resource, err))
+	}
+
+	if opts.Projects {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			projects, err := collectPages(func(cursor string) ([]*Project, string, error) {
+				return client.ListProjects(ctx, WithCursor(cursor))
+			})
+			if err != nil {
+				record("projects", err)
+				return
+			}
+			result.Projects = projects
+		}()
+	}
+
+	if opts.Prompts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			prompts, err := collectPages(func(cursor string) ([]*Prompt, string, error) {
+				return client.ListPrompts(ctx, WithCursor(cursor))
+			})
+			if err != nil {
+				record("prompts", err)
+				return
+			}
+			result.Prompts = prompts
+		}()
+	}
+
+	if opts.Datasets || opts.Experiments {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			datasets, err := collectPages(func(cursor string) ([]*Dataset, string, error) {
+				return client.ListDatasets(ctx, WithCursor(cursor))
+			})
+			if err != nil {
+				record("datasets", err)
+				return
+			}
+			if opts.Datasets {
+				result.Datasets = datasets
+			}
+			if opts.Experiments {
+				experiments, err := listExperimentsForDatasets(ctx, client, datasets)
+				if err != nil {
+					record("experiments", err)
+				}
+				result.Experiments = experiments
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return result, &MultiError{Errors: errs}
+	}
+	return result, nil
+}
+
+// listExperimentsForDatasets lists the experiments of each dataset
+// concurrently, returning them in dataset order. The experiments of
+// datasets that could not be listed are omitted.
+func listExperimentsForDatasets(ctx context.Context, client *Client, datasets []*Dataset) ([]*Experiment, error) {
+	perDataset := make([][]*Experiment, len(datasets))
+	errs := make([]error, len(datasets))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, listAllExperimentConcurrency)
+	for i, d := range datasets {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			perDataset[i], errs[i] = collectPages(func(cursor string) ([]*Experiment, string, error) {
+				return client.ListExperiments(ctx, d.ID, WithCursor(cursor))
+			})
+		}()
+	}
+	wg.Wait()
+
+	var experiments []*Experiment
+	var firstErr error
+	for i := range datasets {
+		if errs[i] != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("dataset %s: %w", datasets[i].ID, errs[i])
+			}
+			continue
+		}
+		experiments = append(experiments, perDataset[i]...)
+	}
+	return experiments, firstErr
+}
+
+// collectPages calls fetch with successive cursors until the last page and
+// returns the items of every page.
+func collectPages[T any](fetch func(cursor string) ([]*T, string, error)) ([]*T, error) {
+	var all []*T
+	cursor := ""
+	for {
+		items, next, err := fetch(cursor)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if next == "" {
+			return all, nil
+		}
+		cursor = next
+	}
+}
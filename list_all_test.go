@@ -0,0 +1,127 @@
+package phoenix
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// listAllMux serves two pages of projects, datasets ds1 and ds2 with one
+// experiment each and one prompt. Requests for the paths in failing fail
+// with a server error.
+func listAllMux(t *testing.T, requests *sync.Map, failing ...string) *http.ServeMux {
+	datasetJSON := func(id string) map[string]any {
+		return map[string]any{
+			"id":            id,
+			"name":          "dataset-" + id,
+			"description":   nil,
+			"metadata":      map[string]any{},
+			"created_at":    "2025-01-01T00:00:00Z",
+			"updated_at":    "2025-01-01T00:00:00Z",
+			"example_count": 1,
+		}
+	}
+
+	mux := http.NewServeMux()
+	handle := func(pattern string, h func(w http.ResponseWriter, r *http.Request)) {
+		mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+			requests.Store(r.URL.Path, true)
+			for _, path := range failing {
+				if r.URL.Path == path {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+			}
+			h(w, r)
+		})
+	}
+	handle("GET /v1/projects", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "" {
+			writeJSON(t, w, pageJSON([]map[string]any{{"id": "p1", "name": "first"}}, "page2"))
+			return
+		}
+		writeJSON(t, w, pageJSON([]map[string]any{{"id": "p2", "name": "second"}}, ""))
+	})
+	handle("GET /v1/datasets", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, pageJSON([]map[string]any{datasetJSON("ds1"), datasetJSON("ds2")}, ""))
+	})
+	handle("GET /v1/datasets/{id}/experiments", func(w http.ResponseWriter, r *http.Request) {
+		exp := experimentJSON("exp-"+r.PathValue("id"), 1, 1, 0)
+		exp["dataset_id"] = r.PathValue("id")
+		writeJSON(t, w, pageJSON([]map[string]any{exp}, ""))
+	})
+	handle("GET /v1/prompts", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, pageJSON([]map[string]any{{"id": "pr1", "name": "greeting"}}, ""))
+	})
+	return mux
+}
+
+func TestListAll(t *testing.T) {
+	var requests sync.Map
+	c := newTestClient(t, listAllMux(t, &requests))
+
+	result, err := ListAll(t.Context(), c, ListAllOptions{Projects: true, Datasets: true, Prompts: true, Experiments: true})
+	if err != nil {
+		t.Fatalf("ListAll: %v", err)
+	}
+	if len(result.Projects) != 2 || result.Projects[1].Name != "second" {
+		t.Errorf("Projects = %+v, want both pages", result.Projects)
+	}
+	if len(result.Datasets) != 2 {
+		t.Errorf("Datasets = %+v, want ds1 and ds2", result.Datasets)
+	}
+	if len(result.Prompts) != 1 || result.Prompts[0].ID != "pr1" {
+		t.Errorf("Prompts = %+v, want pr1", result.Prompts)
+	}
+	if len(result.Experiments) != 2 || result.Experiments[0].ID != "exp-ds1" || result.Experiments[1].ID != "exp-ds2" {
+		t.Errorf("Experiments = %+v, want one per dataset in dataset order", result.Experiments)
+	}
+}
+
+func TestListAllSelection(t *testing.T) {
+	var requests sync.Map
+	c := newTestClient(t, listAllMux(t, &requests))
+
+	// Experiments need the datasets, which are listed but not returned.
+	result, err := ListAll(t.Context(), c, ListAllOptions{Experiments: true})
+	if err != nil {
+		t.Fatalf("ListAll: %v", err)
+	}
+	if result.Projects != nil || result.Datasets != nil || result.Prompts != nil || len(result.Experiments) != 2 {
+		t.Errorf("ListAll = %+v, want only experiments", result)
+	}
+	for _, path := range []string{"/v1/projects", "/v1/prompts"} {
+		if _, ok := requests.Load(path); ok {
+			t.Errorf("requested %s, which was not selected", path)
+		}
+	}
+}
+
+func TestListAllErrors(t *testing.T) {
+	var requests sync.Map
+	c := newTestClient(t, listAllMux(t, &requests, "/v1/prompts", "/v1/datasets/ds2/experiments"))
+
+	result, err := ListAll(t.Context(), c, ListAllOptions{Projects: true, Datasets: true, Prompts: true, Experiments: true})
+	var multi *MultiError
+	if !errors.As(err, &multi) || len(multi.Errors) != 2 {
+		t.Fatalf("ListAll = %v, want a *MultiError with 2 errors", err)
+	}
+	var messages []string
+	for _, e := range multi.Errors {
+		messages = append(messages, e.Error())
+	}
+	joined := strings.Join(messages, "\n")
+	if !strings.Contains(joined, "listing prompts") || !strings.Contains(joined, "listing experiments: dataset ds2") {
+		t.Errorf("errors = %q, want the prompt and ds2 experiment failures", messages)
+	}
+
+	// The other resources are still returned.
+	if len(result.Projects) != 2 || len(result.Datasets) != 2 || result.Prompts != nil {
+		t.Errorf("ListAll = %+v, want projects and datasets without prompts", result)
+	}
+	if len(result.Experiments) != 1 || result.Experiments[0].ID != "exp-ds1" {
+		t.Errorf("Experiments = %+v, want only those of ds1", result.Experiments)
+	}
+}
@@ -0,0 +1,59 @@
+package llmops
+
+import (
+	phoenixotel "github.com/agentplexus/go-phoenix/otel"
+	"github.com/agentplexus/omniobserve/llmops"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Span event names used for agent steps.
+const (
+	agentStepEventName = "agent_step"
+	planStepEventName  = "plan_step"
+)
+
+// AgentSpan is implemented by spans created by this provider and records the
+// reasoning steps of an agent as span events.
+type AgentSpan interface {
+	llmops.Span
+	RecordAgentStep(thought, action, observation string) error
+	RecordPlanStep(plan string, stepIndex int) error
+}
+
+// RecordAgentStep marks the span as an agent span and records one
+// thought/action/observation step as a span event. Empty fields are omitted.
+func (s *spanWrapper) RecordAgentStep(thought, action, observation string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.spanType = llmops.SpanTypeAgent
+	s.otelSpan.SetAttributes(phoenixotel.WithSpanKind(phoenixotel.SpanKindAgent))
+
+	var attrs []attribute.KeyValue
+	if thought != "" {
+		attrs = append(attrs, attribute.String(phoenixotel.AgentThought, thought))
+	}
+	if action != "" {
+		attrs = append(attrs, attribute.String(phoenixotel.AgentAction, action))
+	}
+	if observation != "" {
+		attrs = append(attrs, attribute.String(phoenixotel.AgentObservation, observation))
+	}
+	s.otelSpan.AddEvent(agentStepEventName, trace.WithAttributes(attrs...))
+
+	return nil
+}
+
+// RecordPlanStep records a step of the agent's plan as a span event.
+func (s *spanWrapper) RecordPlanStep(plan string, stepIndex int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.otelSpan.AddEvent(planStepEventName, trace.WithAttributes(
+		attribute.String(phoenixotel.AgentPlan, plan),
+		attribute.Int(phoenixotel.AgentPlanStepIndex, stepIndex),
+	))
+
+	return nil
+}
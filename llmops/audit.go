@@ -0,0 +1,175 @@
+package llmops
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+
+	phoenixotel "github.com/agentplexus/go-phoenix/otel"
+	"github.com/agentplexus/omniobserve/llmops"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// maxAttributeValueSize is the largest attribute value, in bytes, that
+// Phoenix accepts.
+const maxAttributeValueSize = 1 << 20
+
+// auditValuePreviewLen is the number of bytes of an offending value kept in
+// an AttributeAuditIssue.
+const auditValuePreviewLen = 64
+
+// ErrSpanAttributeValidation is returned by End, with strict validation
+// enabled, when the span has attributes Phoenix would reject.
+var ErrSpanAttributeValidation = errors.New("phoenix: span attribute validation failed")
+
+// AttributeAuditIssue describes a span attribute that does not fit within
+// Phoenix's limits. Value is a short, printable preview of the offending
+// value.
+type AttributeAuditIssue struct {
+	Key   string
+	Issue string
+	Value string
+}
+
+func (i AttributeAuditIssue) Error() string {
+	return fmt.Sprintf("attribute %q: %s", i.Key, i.Issue)
+}
+
+// AuditableSpan is implemented by spans created by this provider and checks
+// their attributes against Phoenix's limits.
+type AuditableSpan interface {
+	llmops.Span
+	Audit() []AttributeAuditIssue
+}
+
+// AttributeStats summarizes the size of a span's attributes.
+type AttributeStats = phoenixotel.AttributeStats
+
+// AttributeStatsSpan is implemented by spans created by this provider and
+// reports the size of their current attributes.
+type AttributeStatsSpan interface {
+	llmops.Span
+	AttributeStats() *AttributeStats
+}
+
+// WithStrictValidation makes End return ErrSpanAttributeValidation when a
+// span has attributes that exceed 1MB, contain NUL bytes or are not valid
+// UTF-8. The span is still ended and exported. Without it, such attributes
+// are logged as warnings. Defaults to false.
+func WithStrictValidation(strict bool) ClientOption {
+	return func(o *providerOptions) {
+		o.strictValidation = strict
+	}
+}
+
+// WithAttributeSizeWarning logs a warning when a span ends with an
+// attribute value larger than thresholdBytes. Values of zero or less
+// disable the warning, which is the default.
+func WithAttributeSizeWarning(thresholdBytes int) ClientOption {
+	return func(o *providerOptions) {
+		o.attributeSizeWarning = thresholdBytes
+	}
+}
+
+// AttributeStats returns the number and size of the span's attributes.
+func (s *spanWrapper) AttributeStats() *AttributeStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return phoenixotel.ComputeAttributeStats(s.attributes())
+}
+
+// attributes returns the span's current attributes, or nil if the
+// underlying span does not expose them.
+func (s *spanWrapper) attributes() []attribute.KeyValue {
+	ro, ok := s.otelSpan.(interface{ Attributes() []attribute.KeyValue })
+	if !ok {
+		return nil
+	}
+	return ro.Attributes()
+}
+
+// Audit reports the span's attributes that exceed 1MB, contain NUL bytes or
+// are not valid UTF-8.
+func (s *spanWrapper) Audit() []AttributeAuditIssue {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.audit()
+}
+
+// audit is Audit for callers holding s.mu.
+func (s *spanWrapper) audit() []AttributeAuditIssue {
+	var issues []AttributeAuditIssue
+	for _, kv := range s.attributes() {
+		key := string(kv.Key)
+		switch kv.Value.Type() {
+		case attribute.STRING:
+			issues = appendValueIssues(issues, key, kv.Value.AsString())
+		case attribute.STRINGSLICE:
+			for _, v := range kv.Value.AsStringSlice() {
+				issues = appendValueIssues(issues, key, v)
+			}
+		}
+	}
+	return issues
+}
+
+func appendValueIssues(issues []AttributeAuditIssue, key, value string) []AttributeAuditIssue {
+	if len(value) > maxAttributeValueSize {
+		issues = append(issues, AttributeAuditIssue{Key: key, Issue: "exceeds 1MB", Value: auditPreview(value)})
+	}
+	if strings.ContainsRune(value, 0) {
+		issues = append(issues, AttributeAuditIssue{Key: key, Issue: "contains NUL bytes", Value: auditPreview(value)})
+	}
+	if !utf8.ValidString(value) {
+		issues = append(issues, AttributeAuditIssue{Key: key, Issue: "is not valid UTF-8", Value: auditPreview(value)})
+	}
+	return issues
+}
+
+// auditPreview returns the start of value as valid, NUL-free UTF-8.
+func auditPreview(value string) string {
+	if len(value) > auditValuePreviewLen {
+		value = value[:auditValuePreviewLen]
+	}
+	value = strings.ToValidUTF8(value, "�")
+	return strings.ReplaceAll(value, "\x00", `\x00`)
+}
+
+// checkAttributes audits the span before it ends. In strict mode the issues
+// are returned as an error; otherwise they are logged.
+func (s *spanWrapper) checkAttributes() error {
+	s.warnLargeAttributes()
+
+	issues := s.audit()
+	if len(issues) == 0 {
+		return nil
+	}
+	if !s.provider.strictValidation {
+		for _, issue := range issues {
+			slog.Warn("phoenix: span attribute may be rejected", "span", s.name, "key", issue.Key, "issue", issue.Issue)
+		}
+		return nil
+	}
+
+	errs := make([]error, len(issues))
+	for i, issue := range issues {
+		errs[i] = issue
+	}
+	return fmt.Errorf("%w: %w", ErrSpanAttributeValidation, errors.Join(errs...))
+}
+
+// warnLargeAttributes logs the attributes larger than the provider's size
+// warning threshold.
+func (s *spanWrapper) warnLargeAttributes() {
+	threshold := s.provider.attributeSizeWarning
+	if threshold <= 0 {
+		return
+	}
+	for _, kv := range s.attributes() {
+		if size := phoenixotel.AttributeValueSize(kv.Value); size > threshold {
+			slog.Warn("phoenix: span attribute exceeds size threshold", "span", s.name, "key", string(kv.Key), "bytes", size, "threshold", threshold)
+		}
+	}
+}
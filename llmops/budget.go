@@ -0,0 +1,166 @@
+package llmops
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"sync/atomic"
+
+	phoenixotel "github.com/agentplexus/go-phoenix/otel"
+	"github.com/agentplexus/omniobserve/llmops"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ErrCostBudgetExceeded is returned by StartSpan for LLM spans once the
+// cost budget set with WithSpanCostBudget is spent.
+var ErrCostBudgetExceeded = errors.New("phoenix: LLM cost budget exceeded")
+
+// WithTokenBudget sets a token budget for the lifetime of the provider.
+// Usage recorded with SetUsage is accumulated when each span ends; once the
+// cumulative prompt or completion tokens exceed their budget, the handler
+// set with WithBudgetExceededHandler is called. A budget of zero or less is
+// unlimited. Spans are never blocked or dropped.
+func WithTokenBudget(promptTokens, completionTokens int64) ClientOption {
+	return func(o *providerOptions) {
+		o.promptTokenBudget = promptTokens
+		o.completionTokenBudget = completionTokens
+	}
+}
+
+// WithBudgetExceededHandler sets the function called, once, when the token
+// budget set with WithTokenBudget is first exceeded. It receives the
+// cumulative total token count and runs on its own goroutine.
+func WithBudgetExceededHandler(fn func(total int64)) ClientOption {
+	return func(o *providerOptions) {
+		o.budgetExceededHandler = fn
+	}
+}
+
+// WithSpanCostBudget sets a budget, in US dollars, for the cumulative
+// llm.cost_usd of the spans ended by the provider. Once the budget is spent,
+// starting an LLM span fails with ErrCostBudgetExceeded; other spans are
+// unaffected. Use it to stop runaway spend in batch evaluation loops. A
+// budget of zero or less is unlimited. See Provider.ResetBudget.
+func WithSpanCostBudget(maxUSD float64) ClientOption {
+	return func(o *providerOptions) {
+		o.costBudget = maxUSD
+	}
+}
+
+// costBudget accumulates span costs against an optional budget. The zero
+// value tracks cost without a budget.
+type costBudget struct {
+	mu    sync.Mutex
+	max   float64
+	spent float64
+}
+
+func (b *costBudget) record(cost float64) {
+	if cost <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.spent += cost
+}
+
+// allow reports whether a span of spanType may start.
+func (b *costBudget) allow(spanType llmops.SpanType) error {
+	if spanType != llmops.SpanTypeLLM {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.max > 0 && b.spent >= b.max {
+		return ErrCostBudgetExceeded
+	}
+	return nil
+}
+
+// RemainingBudget returns the part of the cost budget set with
+// WithSpanCostBudget not yet spent, in US dollars. It is never negative, and
+// is +Inf when there is no budget.
+func (p *Provider) RemainingBudget() float64 {
+	b := &p.cost
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.max <= 0 {
+		return math.Inf(1)
+	}
+	return max(b.max-b.spent, 0)
+}
+
+// ResetBudget sets the cost spent against the budget back to zero, allowing
+// LLM spans to start again.
+func (p *Provider) ResetBudget() {
+	b := &p.cost
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.spent = 0
+}
+
+// spanCost returns the llm.cost_usd attribute of span, or zero if it is not
+// set or the span does not record attributes.
+func spanCost(span trace.Span) float64 {
+	ro, ok := span.(interface{ Attributes() []attribute.KeyValue })
+	if !ok {
+		return 0
+	}
+	cost := 0.0
+	for _, kv := range ro.Attributes() {
+		if kv.Key != phoenixotel.LLMCostUSD {
+			continue
+		}
+		switch kv.Value.Type() {
+		case attribute.FLOAT64:
+			cost = kv.Value.AsFloat64()
+		case attribute.INT64:
+			cost = float64(kv.Value.AsInt64())
+		}
+	}
+	return cost
+}
+
+// tokenUsageTracker accumulates token usage across spans and enforces the
+// optional budget. The zero value tracks usage without a budget.
+type tokenUsageTracker struct {
+	prompt     atomic.Int64
+	completion atomic.Int64
+	total      atomic.Int64
+
+	promptBudget     int64
+	completionBudget int64
+	onExceeded       func(total int64)
+	exceededOnce     sync.Once
+}
+
+// record adds usage to the running totals and checks the budget in the
+// background so that ending a span never waits on the handler.
+func (t *tokenUsageTracker) record(usage llmops.TokenUsage) {
+	prompt := t.prompt.Add(int64(usage.PromptTokens))
+	completion := t.completion.Add(int64(usage.CompletionTokens))
+	total := t.total.Add(int64(usage.TotalTokens))
+
+	if t.onExceeded == nil {
+		return
+	}
+	go t.check(prompt, completion, total)
+}
+
+func (t *tokenUsageTracker) check(prompt, completion, total int64) {
+	exceeded := (t.promptBudget > 0 && prompt > t.promptBudget) ||
+		(t.completionBudget > 0 && completion > t.completionBudget)
+	if exceeded {
+		t.exceededOnce.Do(func() { t.onExceeded(total) })
+	}
+}
+
+// TokenUsage returns the cumulative token usage of all ended spans.
+func (p *Provider) TokenUsage() llmops.TokenUsage {
+	return llmops.TokenUsage{
+		PromptTokens:     int(p.usage.prompt.Load()),
+		CompletionTokens: int(p.usage.completion.Load()),
+		TotalTokens:      int(p.usage.total.Load()),
+	}
+}
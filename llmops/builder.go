@@ -0,0 +1,101 @@
+package llmops
+
+import (
+	"context"
+
+	"github.com/agentplexus/omniobserve/llmops"
+)
+
+// SpanBuilder accumulates span options for a fluent, chainable start call:
+//
+//	ctx, span, err := llmops.NewSpanBuilder(ctx, provider, "chat").
+//		Kind(llmops.SpanTypeLLM).
+//		Model("gpt-4o").
+//		LLMProvider("openai").
+//		Input(prompt).
+//		Start()
+//
+// It works with any llmops.Tracer, not only the Phoenix provider.
+type SpanBuilder struct {
+	ctx      context.Context
+	provider llmops.Tracer
+	name     string
+	cfg      llmops.SpanOptions
+}
+
+// NewSpanBuilder returns a builder for a span named name, started from ctx.
+func NewSpanBuilder(ctx context.Context, provider llmops.Tracer, name string) *SpanBuilder {
+	return &SpanBuilder{
+		ctx:      ctx,
+		provider: provider,
+		name:     name,
+	}
+}
+
+// Kind sets the span type.
+func (b *SpanBuilder) Kind(spanType llmops.SpanType) *SpanBuilder {
+	b.cfg.Type = spanType
+	return b
+}
+
+// Model sets the LLM model name.
+func (b *SpanBuilder) Model(model string) *SpanBuilder {
+	b.cfg.Model = model
+	return b
+}
+
+// LLMProvider sets the LLM provider name, e.g. "openai".
+func (b *SpanBuilder) LLMProvider(provider string) *SpanBuilder {
+	b.cfg.Provider = provider
+	return b
+}
+
+// Input sets the span input.
+func (b *SpanBuilder) Input(input any) *SpanBuilder {
+	b.cfg.Input = input
+	return b
+}
+
+// Output sets the span output. It is recorded right after the span starts.
+func (b *SpanBuilder) Output(output any) *SpanBuilder {
+	b.cfg.Output = output
+	return b
+}
+
+// Usage sets token usage.
+func (b *SpanBuilder) Usage(usage llmops.TokenUsage) *SpanBuilder {
+	b.cfg.Usage = &usage
+	return b
+}
+
+// Tag adds a tag. It may be called multiple times.
+func (b *SpanBuilder) Tag(tag string) *SpanBuilder {
+	b.cfg.Tags = append(b.cfg.Tags, tag)
+	return b
+}
+
+// Metadata sets the span metadata.
+func (b *SpanBuilder) Metadata(metadata map[string]any) *SpanBuilder {
+	b.cfg.Metadata = metadata
+	return b
+}
+
+// Start starts the span with the accumulated options.
+func (b *SpanBuilder) Start() (context.Context, llmops.Span, error) {
+	cfg := b.cfg
+	cfg.Output = nil
+	ctx, span, err := b.provider.StartSpan(b.ctx, b.name, func(o *llmops.SpanOptions) {
+		*o = cfg
+	})
+	if err != nil {
+		return ctx, span, err
+	}
+
+	// Not every provider applies an initial output, so set it explicitly.
+	if b.cfg.Output != nil {
+		if err := span.SetOutput(b.cfg.Output); err != nil {
+			return ctx, span, err
+		}
+	}
+	return ctx, span, nil
+}
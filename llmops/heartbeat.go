@@ -0,0 +1,114 @@
+package llmops
+
+import (
+	"context"
+	"time"
+
+	"github.com/agentplexus/go-phoenix"
+)
+
+// WithHeartbeat makes the provider call GetProject for its project every
+// interval in a background goroutine, so that expired credentials or an
+// unreachable server are noticed before traces are lost. Failures are
+// reported to the handler set with WithHeartbeatErrorHandler. The goroutine
+// stops when the provider is closed. A non-positive interval disables the
+// heartbeat.
+func WithHeartbeat(interval time.Duration) ClientOption {
+	return func(o *providerOptions) {
+		o.heartbeatInterval = interval
+	}
+}
+
+// WithHeartbeatErrorHandler sets the function called with the error of each
+// failed heartbeat.
+func WithHeartbeatErrorHandler(fn func(error)) ClientOption {
+	return func(o *providerOptions) {
+		o.heartbeatErrorHandler = fn
+	}
+}
+
+// WithHeartbeatRecoveryHandler sets the function called when a heartbeat
+// succeeds after one or more failures.
+func WithHeartbeatRecoveryHandler(fn func()) ClientOption {
+	return func(o *providerOptions) {
+		o.heartbeatRecoveryHandler = fn
+	}
+}
+
+// heartbeat periodically checks that the provider's project is reachable.
+type heartbeat struct {
+	client     *phoenix.Client
+	project    string
+	interval   time.Duration
+	onError    func(error)
+	onRecovery func()
+
+	// ctx is cancelled by shutdown, aborting an in-flight check.
+	ctx  context.Context
+	stop context.CancelFunc
+	done chan struct{}
+}
+
+func startHeartbeat(client *phoenix.Client, project string, options *providerOptions) *heartbeat {
+	h := &heartbeat{
+		client:     client,
+		project:    project,
+		interval:   options.heartbeatInterval,
+		onError:    options.heartbeatErrorHandler,
+		onRecovery: options.heartbeatRecoveryHandler,
+		done:       make(chan struct{}),
+	}
+	h.ctx, h.stop = context.WithCancel(context.Background())
+	go h.run()
+	return h
+}
+
+func (h *heartbeat) run() {
+	defer close(h.done)
+
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	failing := false
+	for {
+		select {
+		case <-h.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		err := h.beat()
+		if h.ctx.Err() != nil {
+			return
+		}
+		switch {
+		case err != nil:
+			failing = true
+			if h.onError != nil {
+				h.onError(err)
+			}
+		case failing:
+			failing = false
+			if h.onRecovery != nil {
+				h.onRecovery()
+			}
+		}
+	}
+}
+
+// beat fetches the project, giving up after one interval or when the
+// heartbeat is shut down.
+func (h *heartbeat) beat() error {
+	ctx, cancel := context.WithTimeout(h.ctx, h.interval)
+	defer cancel()
+
+	_, err := h.client.GetProject(ctx, h.project)
+	return err
+}
+
+// shutdown stops the heartbeat, cancelling an in-flight check, and waits
+// for its goroutine to exit.
+func (h *heartbeat) shutdown() {
+	h.stop()
+	<-h.done
+}
@@ -0,0 +1,44 @@
+package llmops
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/agentplexus/go-phoenix"
+)
+
+func TestHeartbeatShutdownCancelsCheck(t *testing.T) {
+	inFlight := make(chan struct{}, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case inFlight <- struct{}{}:
+		default:
+		}
+		<-r.Context().Done()
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := phoenix.NewClient(phoenix.WithURL(srv.URL), phoenix.WithProjectName("test"))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	errs := 0
+	h := startHeartbeat(client, "test", &providerOptions{
+		heartbeatInterval:     200 * time.Millisecond,
+		heartbeatErrorHandler: func(error) { errs++ },
+	})
+	<-inFlight
+
+	// A check may take a whole interval; shutting down must not wait for
+	// it.
+	start := time.Now()
+	h.shutdown()
+	if d := time.Since(start); d > 100*time.Millisecond {
+		t.Errorf("shutdown took %v", d)
+	}
+	if errs != 0 {
+		t.Errorf("error handler called %d times for a cancelled check, want 0", errs)
+	}
+}
@@ -0,0 +1,67 @@
+package llmops
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	phoenixotel "github.com/agentplexus/go-phoenix/otel"
+)
+
+// ErrMetadataValidation is returned, with WithStrictMetadataSchema, when
+// metadata does not match the provider's metadata schema.
+var ErrMetadataValidation = errors.New("phoenix: metadata validation failed")
+
+// WithMetadataSchema validates the metadata of spans and traces against
+// schema, for example one built with phoenixotel.InferMetadataSchema.
+// Mismatches are logged as warnings unless WithStrictMetadataSchema is set.
+func WithMetadataSchema(schema *phoenixotel.MetadataSchema) ClientOption {
+	return func(o *providerOptions) {
+		o.metadataSchema = schema
+	}
+}
+
+// WithStrictMetadataSchema returns metadata that does not match the schema
+// set with WithMetadataSchema as an error wrapping ErrMetadataValidation.
+// SetMetadata still records the metadata before returning the error, while
+// StartTrace and StartSpan reject metadata passed as an option without
+// starting the trace or span. Defaults to false.
+func WithStrictMetadataSchema(strict bool) ClientOption {
+	return func(o *providerOptions) {
+		o.strictMetadataSchema = strict
+	}
+}
+
+// checkInitialMetadata validates metadata passed as an option when starting
+// the span or trace named name.
+func (p *Provider) checkInitialMetadata(name string, metadata map[string]any) error {
+	if metadata == nil {
+		return nil
+	}
+	return p.checkMetadata(name, metadata)
+}
+
+// checkMetadata validates metadata set on the span or trace named name
+// against the provider's metadata schema.
+func (p *Provider) checkMetadata(name string, metadata map[string]any) error {
+	if p.metadataSchema == nil {
+		return nil
+	}
+	verrs := p.metadataSchema.Validate(metadata)
+	if len(verrs) == 0 {
+		return nil
+	}
+	if !p.strictMetadataSchema {
+		for _, verr := range verrs {
+			slog.Warn("phoenix: span metadata does not match schema", "span", name, "key", verr.Attribute, "error", verr.Message)
+		}
+		return nil
+	}
+
+	errs := make([]error, len(verrs))
+	for i, verr := range verrs {
+		verr.SpanName = name
+		errs[i] = verr
+	}
+	return fmt.Errorf("%w: %w", ErrMetadataValidation, errors.Join(errs...))
+}
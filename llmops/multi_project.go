@@ -0,0 +1,301 @@
+package llmops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/agentplexus/omniobserve/llmops"
+)
+
+// DefaultProjectKey is the key of the provider NewMultiProjectProvider
+// delegates to when the context names no project, or a project it has no
+// provider for.
+const DefaultProjectKey = "default"
+
+type projectContextKey struct{}
+
+// WithProjectContext returns a copy of ctx that routes calls made through a
+// multi-project provider to the provider for project.
+func WithProjectContext(ctx context.Context, project string) context.Context {
+	return context.WithValue(ctx, projectContextKey{}, project)
+}
+
+// ProjectFromContext returns the project set with WithProjectContext.
+func ProjectFromContext(ctx context.Context) (string, bool) {
+	project, ok := ctx.Value(projectContextKey{}).(string)
+	return project, ok
+}
+
+// MultiProjectOption configures NewMultiProjectProvider.
+type MultiProjectOption func(*multiProjectProvider)
+
+// WithFallbackProvider sets the provider used when no provider matches the
+// project in the context. It takes precedence over the "default" entry of
+// the providers map.
+func WithFallbackProvider(p llmops.Provider) MultiProjectOption {
+	return func(m *multiProjectProvider) {
+		m.fallback = p
+	}
+}
+
+// multiProjectProvider routes each call to a provider chosen by the project
+// in the call's context.
+type multiProjectProvider struct {
+	providers map[string]llmops.Provider
+	fallback  llmops.Provider
+}
+
+// NewMultiProjectProvider returns a provider that delegates each call to
+// providers[project], where project is read from the context with
+// ProjectFromContext. Calls without a matching provider go to the fallback
+// provider (see WithFallbackProvider), or else to the "default" entry of
+// providers; if neither exists they fail with llmops.ErrProjectNotFound.
+//
+// Use it to send each tenant's traces to its own Phoenix project:
+//
+//	ctx = llmops.WithProjectContext(ctx, tenant)
+//	ctx, trace, err := multi.StartTrace(ctx, "request")
+//
+// Close closes every provider, including the fallback.
+func NewMultiProjectProvider(providers map[string]llmops.Provider, opts ...MultiProjectOption) llmops.Provider {
+	m := &multiProjectProvider{
+		providers: make(map[string]llmops.Provider, len(providers)),
+	}
+	for name, p := range providers {
+		m.providers[name] = p
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if m.fallback == nil {
+		m.fallback = m.providers[DefaultProjectKey]
+	}
+	return m
+}
+
+func (m *multiProjectProvider) route(ctx context.Context) (llmops.Provider, error) {
+	project, _ := ProjectFromContext(ctx)
+	if p, ok := m.providers[project]; ok {
+		return p, nil
+	}
+	if m.fallback != nil {
+		return m.fallback, nil
+	}
+	return nil, fmt.Errorf("%w: no provider for project %q", llmops.ErrProjectNotFound, project)
+}
+
+// Name returns the provider name.
+func (m *multiProjectProvider) Name() string {
+	return ProviderName
+}
+
+// Close closes every provider and returns the errors joined.
+func (m *multiProjectProvider) Close() error {
+	closed := make(map[llmops.Provider]bool, len(m.providers)+1)
+	var errs []error
+	closeOnce := func(p llmops.Provider) {
+		if p == nil || closed[p] {
+			return
+		}
+		closed[p] = true
+		if err := p.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, p := range m.providers {
+		closeOnce(p)
+	}
+	closeOnce(m.fallback)
+	return errors.Join(errs...)
+}
+
+// StartTrace starts a trace in the provider for the context's project.
+func (m *multiProjectProvider) StartTrace(ctx context.Context, name string, opts ...llmops.TraceOption) (context.Context, llmops.Trace, error) {
+	p, err := m.route(ctx)
+	if err != nil {
+		return ctx, nil, err
+	}
+	return p.StartTrace(ctx, name, opts...)
+}
+
+// StartSpan starts a span in the provider for the context's project.
+func (m *multiProjectProvider) StartSpan(ctx context.Context, name string, opts ...llmops.SpanOption) (context.Context, llmops.Span, error) {
+	p, err := m.route(ctx)
+	if err != nil {
+		return ctx, nil, err
+	}
+	return p.StartSpan(ctx, name, opts...)
+}
+
+// TraceFromContext retrieves the current trace from context.
+func (m *multiProjectProvider) TraceFromContext(ctx context.Context) (llmops.Trace, bool) {
+	p, err := m.route(ctx)
+	if err != nil {
+		return nil, false
+	}
+	return p.TraceFromContext(ctx)
+}
+
+// SpanFromContext retrieves the current span from context.
+func (m *multiProjectProvider) SpanFromContext(ctx context.Context) (llmops.Span, bool) {
+	p, err := m.route(ctx)
+	if err != nil {
+		return nil, false
+	}
+	return p.SpanFromContext(ctx)
+}
+
+// Evaluate runs evaluation metrics.
+func (m *multiProjectProvider) Evaluate(ctx context.Context, input llmops.EvalInput, metrics ...llmops.Metric) (*llmops.EvalResult, error) {
+	p, err := m.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.Evaluate(ctx, input, metrics...)
+}
+
+// AddFeedbackScore adds a feedback score.
+func (m *multiProjectProvider) AddFeedbackScore(ctx context.Context, opts llmops.FeedbackScoreOpts) error {
+	p, err := m.route(ctx)
+	if err != nil {
+		return err
+	}
+	return p.AddFeedbackScore(ctx, opts)
+}
+
+// CreatePrompt creates a new prompt.
+func (m *multiProjectProvider) CreatePrompt(ctx context.Context, name string, template string, opts ...llmops.PromptOption) (*llmops.Prompt, error) {
+	p, err := m.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.CreatePrompt(ctx, name, template, opts...)
+}
+
+// GetPrompt retrieves a prompt.
+func (m *multiProjectProvider) GetPrompt(ctx context.Context, name string, version ...string) (*llmops.Prompt, error) {
+	p, err := m.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.GetPrompt(ctx, name, version...)
+}
+
+// ListPrompts lists prompts.
+func (m *multiProjectProvider) ListPrompts(ctx context.Context, opts ...llmops.ListOption) ([]*llmops.Prompt, error) {
+	p, err := m.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.ListPrompts(ctx, opts...)
+}
+
+// CreateDataset creates a new dataset.
+func (m *multiProjectProvider) CreateDataset(ctx context.Context, name string, opts ...llmops.DatasetOption) (*llmops.Dataset, error) {
+	p, err := m.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.CreateDataset(ctx, name, opts...)
+}
+
+// GetDataset retrieves a dataset by name.
+func (m *multiProjectProvider) GetDataset(ctx context.Context, name string) (*llmops.Dataset, error) {
+	p, err := m.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.GetDataset(ctx, name)
+}
+
+// GetDatasetByID retrieves a dataset by ID.
+func (m *multiProjectProvider) GetDatasetByID(ctx context.Context, id string) (*llmops.Dataset, error) {
+	p, err := m.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.GetDatasetByID(ctx, id)
+}
+
+// AddDatasetItems adds items to a dataset.
+func (m *multiProjectProvider) AddDatasetItems(ctx context.Context, datasetName string, items []llmops.DatasetItem) error {
+	p, err := m.route(ctx)
+	if err != nil {
+		return err
+	}
+	return p.AddDatasetItems(ctx, datasetName, items)
+}
+
+// ListDatasets lists datasets.
+func (m *multiProjectProvider) ListDatasets(ctx context.Context, opts ...llmops.ListOption) ([]*llmops.Dataset, error) {
+	p, err := m.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.ListDatasets(ctx, opts...)
+}
+
+// DeleteDataset deletes a dataset by ID.
+func (m *multiProjectProvider) DeleteDataset(ctx context.Context, datasetID string) error {
+	p, err := m.route(ctx)
+	if err != nil {
+		return err
+	}
+	return p.DeleteDataset(ctx, datasetID)
+}
+
+// CreateProject creates a new project.
+func (m *multiProjectProvider) CreateProject(ctx context.Context, name string, opts ...llmops.ProjectOption) (*llmops.Project, error) {
+	p, err := m.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.CreateProject(ctx, name, opts...)
+}
+
+// GetProject retrieves a project by name.
+func (m *multiProjectProvider) GetProject(ctx context.Context, name string) (*llmops.Project, error) {
+	p, err := m.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.GetProject(ctx, name)
+}
+
+// ListProjects lists projects.
+func (m *multiProjectProvider) ListProjects(ctx context.Context, opts ...llmops.ListOption) ([]*llmops.Project, error) {
+	p, err := m.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.ListProjects(ctx, opts...)
+}
+
+// SetProject sets the current project of the provider for the context's
+// project.
+func (m *multiProjectProvider) SetProject(ctx context.Context, name string) error {
+	p, err := m.route(ctx)
+	if err != nil {
+		return err
+	}
+	return p.SetProject(ctx, name)
+}
+
+// CreateAnnotation creates an annotation.
+func (m *multiProjectProvider) CreateAnnotation(ctx context.Context, annotation llmops.Annotation) error {
+	p, err := m.route(ctx)
+	if err != nil {
+		return err
+	}
+	return p.CreateAnnotation(ctx, annotation)
+}
+
+// ListAnnotations lists annotations.
+func (m *multiProjectProvider) ListAnnotations(ctx context.Context, opts llmops.ListAnnotationsOptions) ([]*llmops.Annotation, error) {
+	p, err := m.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.ListAnnotations(ctx, opts)
+}
@@ -0,0 +1,90 @@
+package llmops
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/agentplexus/omniobserve/llmops"
+)
+
+// mockProvider records the traces started through it. Methods not
+// overridden panic through the nil embedded interface.
+type mockProvider struct {
+	llmops.Provider
+	name   string
+	traces []string
+	closed bool
+}
+
+func (m *mockProvider) StartTrace(ctx context.Context, name string, _ ...llmops.TraceOption) (context.Context, llmops.Trace, error) {
+	m.traces = append(m.traces, name)
+	return ctx, nil, nil
+}
+
+func (m *mockProvider) Close() error {
+	m.closed = true
+	return nil
+}
+
+func TestMultiProjectProviderRouting(t *testing.T) {
+	tenantA := &mockProvider{name: "tenant-a"}
+	def := &mockProvider{name: "default"}
+	multi := NewMultiProjectProvider(map[string]llmops.Provider{
+		"tenant-a":        tenantA,
+		DefaultProjectKey: def,
+	})
+
+	ctx := t.Context()
+	if _, _, err := multi.StartTrace(WithProjectContext(ctx, "tenant-a"), "a"); err != nil {
+		t.Fatalf("StartTrace: %v", err)
+	}
+	if _, _, err := multi.StartTrace(WithProjectContext(ctx, "tenant-b"), "b"); err != nil {
+		t.Fatalf("StartTrace: %v", err)
+	}
+	if _, _, err := multi.StartTrace(ctx, "none"); err != nil {
+		t.Fatalf("StartTrace: %v", err)
+	}
+
+	if got := tenantA.traces; len(got) != 1 || got[0] != "a" {
+		t.Errorf("tenant-a traces = %v, want [a]", got)
+	}
+	if got := def.traces; len(got) != 2 || got[0] != "b" || got[1] != "none" {
+		t.Errorf("default traces = %v, want [b none]", got)
+	}
+
+	if err := multi.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if !tenantA.closed || !def.closed {
+		t.Error("Close did not close every provider")
+	}
+}
+
+func TestMultiProjectProviderFallback(t *testing.T) {
+	tenantA := &mockProvider{name: "tenant-a"}
+	def := &mockProvider{name: "default"}
+	fallback := &mockProvider{name: "fallback"}
+	multi := NewMultiProjectProvider(map[string]llmops.Provider{
+		"tenant-a":        tenantA,
+		DefaultProjectKey: def,
+	}, WithFallbackProvider(fallback))
+
+	if _, _, err := multi.StartTrace(WithProjectContext(t.Context(), "tenant-b"), "b"); err != nil {
+		t.Fatalf("StartTrace: %v", err)
+	}
+	if len(fallback.traces) != 1 || len(def.traces) != 0 {
+		t.Errorf("fallback traces = %v, default traces = %v; want the fallback to win", fallback.traces, def.traces)
+	}
+}
+
+func TestMultiProjectProviderNoMatch(t *testing.T) {
+	multi := NewMultiProjectProvider(map[string]llmops.Provider{
+		"tenant-a": &mockProvider{name: "tenant-a"},
+	})
+
+	_, _, err := multi.StartTrace(WithProjectContext(t.Context(), "tenant-b"), "b")
+	if !errors.Is(err, llmops.ErrProjectNotFound) {
+		t.Errorf("StartTrace error = %v, want ErrProjectNotFound", err)
+	}
+}
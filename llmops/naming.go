@@ -0,0 +1,37 @@
+package llmops
+
+import (
+	"context"
+
+	phoenixotel "github.com/agentplexus/go-phoenix/otel"
+	"github.com/agentplexus/omniobserve/llmops"
+)
+
+// AutoNaming starts spans named after the function they instrument, as
+// returned by otel.FuncSpanName, so instrumentation code does not have to
+// choose a name for every function.
+type AutoNaming struct{}
+
+// NewAutoNaming returns an AutoNaming.
+func NewAutoNaming() AutoNaming {
+	return AutoNaming{}
+}
+
+// StartSpan starts a span named after fn as a child of the span in ctx or,
+// if there is none, of the trace in ctx. It returns llmops.ErrNoActiveTrace
+// if ctx holds neither.
+//
+//	func (r *Retriever) Search(ctx context.Context, query string) ([]Doc, error) {
+//		ctx, span, err := naming.StartSpan(ctx, r.Search)
+//		...
+//	}
+func (AutoNaming) StartSpan(ctx context.Context, fn any, opts ...llmops.SpanOption) (context.Context, llmops.Span, error) {
+	name := phoenixotel.FuncSpanName(fn)
+	if s := spanFromContext(ctx); s != nil {
+		return s.StartSpan(ctx, name, opts...)
+	}
+	if t := traceFromContext(ctx); t != nil {
+		return t.StartSpan(ctx, name, opts...)
+	}
+	return ctx, nil, llmops.ErrNoActiveTrace
+}
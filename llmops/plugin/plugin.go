@@ -0,0 +1,83 @@
+// Package llmopsplugin registers llmops providers, such as the Phoenix
+// provider in the llmops package, from values or from Go plugins loaded at
+// run time. It is kept out of the phoenix package so that programs that do
+// not load plugins do not link the plugin runtime.
+package llmopsplugin
+
+import (
+	"fmt"
+	"plugin"
+	"slices"
+
+	"github.com/agentplexus/omniobserve/llmops"
+)
+
+// PluginSymbol is the name of the symbol LoadPlugin looks up in a plugin.
+const PluginSymbol = "Plugin"
+
+// ProviderPlugin supplies an llmops provider together with its metadata.
+// The Phoenix provider in the llmops package is registered as one.
+type ProviderPlugin interface {
+	// Name is the name the provider is registered under for llmops.Open.
+	Name() string
+
+	// Describe returns the provider metadata reported by
+	// llmops.GetProviderInfo. Its Name should match Name.
+	Describe() llmops.ProviderInfo
+
+	// New creates a provider instance.
+	New(opts ...llmops.ClientOption) (llmops.Provider, error)
+}
+
+// RegisterPlugin registers p with llmops so that it can be opened with
+// llmops.Open(p.Name()). Like llmops.Register, it panics if a provider with
+// the same name is already registered.
+func RegisterPlugin(p ProviderPlugin) {
+	llmops.Register(p.Name(), p.New)
+	llmops.RegisterInfo(p.Describe())
+}
+
+// LoadPlugin opens the Go plugin at soPath and registers the ProviderPlugin
+// it exports. Unlike RegisterPlugin, it returns an error if the provider
+// name is already taken.
+//
+// The plugin must be built with -buildmode=plugin and export a package-level
+// variable named Plugin whose type implements ProviderPlugin:
+//
+//	package main
+//
+//	var Plugin llmopsplugin.ProviderPlugin = myPlugin{}
+//
+// The Go plugin ABI requires the plugin and the host program to be built
+// with the same Go toolchain, the same build flags and tags, and identical
+// versions of every package they share, including go-phoenix and
+// omniobserve. Plugins are only supported on Linux, FreeBSD and macOS with
+// cgo enabled, and cannot be unloaded.
+func LoadPlugin(soPath string) error {
+	so, err := plugin.Open(soPath)
+	if err != nil {
+		return fmt.Errorf("phoenix: loading plugin: %w", err)
+	}
+
+	sym, err := so.Lookup(PluginSymbol)
+	if err != nil {
+		return fmt.Errorf("phoenix: loading plugin %s: %w", soPath, err)
+	}
+
+	var p ProviderPlugin
+	switch v := sym.(type) {
+	case *ProviderPlugin:
+		p = *v
+	case ProviderPlugin:
+		p = v
+	}
+	if p == nil {
+		return fmt.Errorf("phoenix: loading plugin %s: symbol %s of type %T does not implement ProviderPlugin", soPath, PluginSymbol, sym)
+	}
+
+	if slices.Contains(llmops.Providers(), p.Name()) {
+		return fmt.Errorf("phoenix: loading plugin %s: provider %q is already registered", soPath, p.Name())
+	}
+	RegisterPlugin(p)
+	return nil
+}
@@ -0,0 +1,43 @@
+package llmopsplugin_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	phoenixllmops "github.com/agentplexus/go-phoenix/llmops"
+	llmopsplugin "github.com/agentplexus/go-phoenix/llmops/plugin"
+	"github.com/agentplexus/omniobserve/llmops"
+)
+
+var _ llmopsplugin.ProviderPlugin = phoenixllmops.Plugin
+
+type testPlugin struct{}
+
+func (testPlugin) Name() string { return "plugin-test" }
+
+func (testPlugin) Describe() llmops.ProviderInfo {
+	return llmops.ProviderInfo{Name: "plugin-test", Description: "test provider"}
+}
+
+func (testPlugin) New(...llmops.ClientOption) (llmops.Provider, error) {
+	return nil, nil
+}
+
+func TestRegisterPlugin(t *testing.T) {
+	llmopsplugin.RegisterPlugin(testPlugin{})
+	t.Cleanup(func() { llmops.Unregister("plugin-test") })
+
+	info, ok := llmops.GetProviderInfo("plugin-test")
+	if !ok || info.Description != "test provider" {
+		t.Errorf("GetProviderInfo = %+v, %v, want registered info", info, ok)
+	}
+	if _, err := llmops.Open("plugin-test"); err != nil {
+		t.Errorf("Open: %v", err)
+	}
+}
+
+func TestLoadPluginMissing(t *testing.T) {
+	if err := llmopsplugin.LoadPlugin(filepath.Join(t.TempDir(), "missing.so")); err == nil {
+		t.Error("LoadPlugin(missing) = nil, want error")
+	}
+}
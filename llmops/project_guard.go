@@ -0,0 +1,67 @@
+package llmops
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/agentplexus/go-phoenix"
+)
+
+// projectCheckTTL is how long the result of a project existence check is
+// trusted before StartTrace checks again.
+const projectCheckTTL = 5 * time.Minute
+
+// projectCheckTimeout bounds the API calls made by a project check.
+const projectCheckTimeout = 10 * time.Second
+
+// projectGuard records when the provider last verified that its project
+// exists in Phoenix.
+type projectGuard struct {
+	mu          sync.Mutex
+	checkedAt   time.Time
+	autoCreate  bool
+	description string
+}
+
+// checkProject verifies, at most once every five minutes, that the
+// provider's project exists. A missing project is created when
+// WithAutoCreateProject is set; otherwise a warning is logged, since Phoenix
+// drops traces sent to a project that does not exist. Tracing is never
+// blocked by a failed check, and only the caller that runs the check waits
+// for it.
+func (p *Provider) checkProject(ctx context.Context) {
+	if p.client == nil || p.projectName == "" {
+		return
+	}
+
+	// Claim the check under the lock but run it outside, so concurrent
+	// callers do not wait on the API calls.
+	g := &p.project
+	g.mu.Lock()
+	if !g.checkedAt.IsZero() && time.Since(g.checkedAt) < projectCheckTTL {
+		g.mu.Unlock()
+		return
+	}
+	g.checkedAt = time.Now()
+	g.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), projectCheckTimeout)
+	defer cancel()
+
+	if g.autoCreate {
+		if err := createProjectIfMissing(ctx, p.client, p.projectName, g.description); err != nil {
+			slog.Warn("phoenix: could not create project", "project", p.projectName, "error", err)
+		}
+		return
+	}
+
+	_, err := p.client.GetProject(ctx, p.projectName)
+	switch {
+	case phoenix.IsNotFound(err):
+		slog.Warn("phoenix: project does not exist, traces may be dropped", "project", p.projectName)
+	case err != nil:
+		slog.Warn("phoenix: could not verify project", "project", p.projectName, "error", err)
+	}
+}
@@ -0,0 +1,64 @@
+package llmops
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/agentplexus/go-phoenix"
+)
+
+func TestCheckProjectDoesNotBlockConcurrentCallers(t *testing.T) {
+	release := make(chan struct{})
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"id":"p1","name":"test"}}`))
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() {
+		select {
+		case <-release:
+		default:
+			close(release)
+		}
+	})
+
+	client, err := phoenix.NewClient(phoenix.WithURL(srv.URL), phoenix.WithProjectName("test"))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	p, _ := newTestProvider(t)
+	p.client = client
+	p.projectName = "test"
+
+	first := make(chan struct{})
+	go func() {
+		defer close(first)
+		p.checkProject(t.Context())
+	}()
+	for requests.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	second := make(chan struct{})
+	go func() {
+		defer close(second)
+		p.checkProject(t.Context())
+	}()
+	select {
+	case <-second:
+	case <-time.After(time.Second):
+		t.Fatal("checkProject blocked while another check was in flight")
+	}
+
+	close(release)
+	<-first
+	if n := requests.Load(); n != 1 {
+		t.Errorf("made %d project requests, want 1", n)
+	}
+}
@@ -0,0 +1,27 @@
+package llmops
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// InjectHTTPHeaders injects the trace context from ctx into headers so that
+// downstream services can continue the trace.
+//
+// It uses the global OTEL text map propagator, which is a no-op until one is
+// installed, e.g. with otel.SetTextMapPropagator(propagation.TraceContext{}).
+func (p *Provider) InjectHTTPHeaders(ctx context.Context, headers http.Header) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(headers))
+}
+
+// ExtractFromHTTPHeaders returns a copy of ctx carrying the remote trace
+// context found in headers. Spans started from the returned context become
+// children of the caller's span.
+//
+// Like InjectHTTPHeaders, it uses the global OTEL text map propagator.
+func (p *Provider) ExtractFromHTTPHeaders(ctx context.Context, headers http.Header) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(headers))
+}
@@ -15,6 +15,8 @@ package llmops
 
 import (
 	"context"
+	"errors"
+	"log/slog"
 	"time"
 
 	"github.com/agentplexus/go-phoenix"
@@ -26,8 +28,23 @@ import (
 const ProviderName = "phoenix"
 
 func init() {
-	llmops.Register(ProviderName, New)
-	llmops.RegisterInfo(llmops.ProviderInfo{
+	llmops.Register(Plugin.Name(), Plugin.New)
+	llmops.RegisterInfo(Plugin.Describe())
+}
+
+// Plugin is the Phoenix provider as an llmopsplugin.ProviderPlugin, for
+// export from a Go plugin. It is registered directly, so that importing this
+// package does not link the plugin runtime.
+var Plugin = providerPlugin{}
+
+type providerPlugin struct{}
+
+func (providerPlugin) Name() string {
+	return ProviderName
+}
+
+func (providerPlugin) Describe() llmops.ProviderInfo {
+	return llmops.ProviderInfo{
 		Name:        ProviderName,
 		Description: "Arize Phoenix - Open-source LLM observability platform",
 		Website:     "https://phoenix.arize.com",
@@ -40,7 +57,11 @@ func init() {
 			llmops.CapabilityExperiments,
 			llmops.CapabilityOTel,
 		},
-	})
+	}
+}
+
+func (providerPlugin) New(opts ...llmops.ClientOption) (llmops.Provider, error) {
+	return New(opts...)
 }
 
 // Provider implements llmops.Provider for Phoenix using phoenix-otel for tracing.
@@ -51,12 +72,122 @@ type Provider struct {
 	projectName  string
 	serviceName  string
 	batchEnabled bool
+
+	spanNameSanitizer    func(name string) string
+	usage                tokenUsageTracker
+	project              projectGuard
+	heartbeat            *heartbeat
+	errorPropagation     bool
+	encoding             phoenixotel.AttributeEncoding
+	strictValidation     bool
+	attributeSizeWarning int
+	cost                 costBudget
+	inputSerializer      func(any) (string, error)
+	outputSerializer     func(any) (string, error)
+	metadataSchema       *phoenixotel.MetadataSchema
+	strictMetadataSchema bool
+}
+
+// ClientOption configures Phoenix-specific provider behavior that has no
+// equivalent in llmops.ClientOption. Pass these to NewProvider.
+type ClientOption func(*providerOptions)
+
+// providerOptions holds Phoenix-specific provider configuration.
+type providerOptions struct {
+	spanNameSanitizer  func(name string) string
+	autoCreateProject  bool
+	projectDescription string
+
+	promptTokenBudget     int64
+	completionTokenBudget int64
+	budgetExceededHandler func(total int64)
+
+	heartbeatInterval        time.Duration
+	heartbeatErrorHandler    func(error)
+	heartbeatRecoveryHandler func()
+
+	errorPropagation bool
+
+	attributeEncoding phoenixotel.AttributeEncoding
+	inputSerializer   func(any) (string, error)
+	outputSerializer  func(any) (string, error)
+
+	strictValidation     bool
+	attributeSizeWarning int
+	metadataSchema       *phoenixotel.MetadataSchema
+	strictMetadataSchema bool
+
+	costBudget float64
+}
+
+// WithSpanNameSanitizer sets a function applied to every trace and span name
+// before the span is started. Use it to strip dynamic identifiers that would
+// otherwise make span names high-cardinality in the Phoenix UI.
+// See TruncateSanitizer, RegexReplaceSanitizer and TemplateSanitizer.
+func WithSpanNameSanitizer(fn func(name string) string) ClientOption {
+	return func(o *providerOptions) {
+		o.spanNameSanitizer = fn
+	}
+}
+
+// WithAutoCreateProject creates the configured project when the provider is
+// created if it does not already exist in Phoenix. Defaults to false.
+func WithAutoCreateProject(autoCreate bool) ClientOption {
+	return func(o *providerOptions) {
+		o.autoCreateProject = autoCreate
+	}
+}
+
+// WithProjectDescription sets the description used when the project is
+// auto-created. See WithAutoCreateProject.
+func WithProjectDescription(desc string) ClientOption {
+	return func(o *providerOptions) {
+		o.projectDescription = desc
+	}
+}
+
+// WithErrorPropagation makes a span that ends with an error (see
+// llmops.WithEndError) mark its ancestors as failed: each parent span, up
+// to and including the trace, gets an error status and a child_error event
+// naming the failed span. Following OTEL semantics, ancestors already marked
+// OK are not changed.
+func WithErrorPropagation(enabled bool) ClientOption {
+	return func(o *providerOptions) {
+		o.errorPropagation = enabled
+	}
+}
+
+// WithAttributeEncoding sets how span input, output and metadata values are
+// encoded. With phoenixotel.EncodingAuto the provider queries the Phoenix
+// server version when it is created and falls back to
+// phoenixotel.EncodingJSON if the query fails. Defaults to
+// phoenixotel.EncodingJSON.
+func WithAttributeEncoding(enc phoenixotel.AttributeEncoding) ClientOption {
+	return func(o *providerOptions) {
+		o.attributeEncoding = enc
+	}
 }
 
 // New creates a new Phoenix provider.
+// It is registered as the llmops factory for ProviderName.
 func New(opts ...llmops.ClientOption) (llmops.Provider, error) {
+	p, err := NewProvider(opts)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// NewProvider creates a new Phoenix provider from generic llmops client
+// options and Phoenix-specific options.
+func NewProvider(opts []llmops.ClientOption, providerOpts ...ClientOption) (*Provider, error) {
 	cfg := llmops.ApplyClientOptions(opts...)
 
+	options := &providerOptions{}
+	for _, opt := range providerOpts {
+		opt(options)
+	}
+
 	// Map llmops options to phoenix REST client options
 	phoenixOpts := []phoenix.Option{}
 	if cfg.Endpoint != "" {
@@ -81,6 +212,12 @@ func New(opts ...llmops.ClientOption) (llmops.Provider, error) {
 		return nil, err
 	}
 
+	if options.autoCreateProject && cfg.ProjectName != "" {
+		if err := ensureProject(client, cfg.ProjectName, options.projectDescription, cfg.Timeout); err != nil {
+			return nil, err
+		}
+	}
+
 	// Map llmops options to phoenix-otel options
 	otelOpts := []phoenixotel.Option{
 		phoenixotel.WithBatch(true),           // Enable batching by default
@@ -106,6 +243,9 @@ func New(opts ...llmops.ClientOption) (llmops.Provider, error) {
 		serviceName = "phoenix-llmops"
 	}
 	otelOpts = append(otelOpts, phoenixotel.WithServiceName(serviceName))
+	if options.attributeEncoding != "" {
+		otelOpts = append(otelOpts, phoenixotel.WithAttributeEncoding(options.attributeEncoding))
+	}
 
 	// Register phoenix-otel tracer provider
 	tp, err := phoenixotel.Register(otelOpts...)
@@ -113,14 +253,100 @@ func New(opts ...llmops.ClientOption) (llmops.Provider, error) {
 		return nil, err
 	}
 
-	return &Provider{
+	p := &Provider{
 		client:       client,
 		tp:           tp,
 		tracer:       tp.Tracer(serviceName),
 		projectName:  cfg.ProjectName,
 		serviceName:  serviceName,
 		batchEnabled: true,
-	}, nil
+
+		spanNameSanitizer:    options.spanNameSanitizer,
+		errorPropagation:     options.errorPropagation,
+		encoding:             tp.AttributeEncoding(),
+		strictValidation:     options.strictValidation,
+		attributeSizeWarning: options.attributeSizeWarning,
+		inputSerializer:      options.inputSerializer,
+		outputSerializer:     options.outputSerializer,
+		metadataSchema:       options.metadataSchema,
+		strictMetadataSchema: options.strictMetadataSchema,
+	}
+	if p.encoding == phoenixotel.EncodingAuto {
+		p.encoding = detectAttributeEncoding(client, cfg.Timeout)
+	}
+	p.usage.promptBudget = options.promptTokenBudget
+	p.usage.completionBudget = options.completionTokenBudget
+	p.usage.onExceeded = options.budgetExceededHandler
+	p.cost.max = options.costBudget
+	p.project.autoCreate = options.autoCreateProject
+	p.project.description = options.projectDescription
+	if options.autoCreateProject {
+		p.project.checkedAt = time.Now()
+	}
+	if options.heartbeatInterval > 0 && cfg.ProjectName != "" {
+		p.heartbeat = startHeartbeat(client, cfg.ProjectName, options)
+	}
+	return p, nil
+}
+
+// detectAttributeEncoding resolves phoenixotel.EncodingAuto from the server
+// version, falling back to phoenixotel.EncodingJSON.
+func detectAttributeEncoding(client *phoenix.Client, timeout time.Duration) phoenixotel.AttributeEncoding {
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	report, err := client.CheckAPICompatibility(ctx)
+	if err != nil {
+		slog.Warn("phoenix: could not detect attribute encoding, using JSON", "error", err)
+		return phoenixotel.EncodingJSON
+	}
+	return report.AttributeEncoding()
+}
+
+// ensureProject creates the named project if it does not exist.
+// A conflict on creation means another caller created it first and is
+// treated as success.
+func ensureProject(client *phoenix.Client, name, description string, timeout time.Duration) error {
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return createProjectIfMissing(ctx, client, name, description)
+}
+
+// createProjectIfMissing creates the named project if GetProject reports
+// that it does not exist.
+func createProjectIfMissing(ctx context.Context, client *phoenix.Client, name, description string) error {
+	_, err := client.GetProject(ctx, name)
+	if err == nil {
+		return nil
+	}
+	if !phoenix.IsNotFound(err) {
+		return err
+	}
+
+	slog.Warn("phoenix: project not found, creating it", "project", name)
+
+	var opts []phoenix.ProjectOption
+	if description != "" {
+		opts = append(opts, phoenix.WithDescription(description))
+	}
+	if _, err := client.CreateProject(ctx, name, opts...); err != nil && !phoenix.IsConflict(err) {
+		return err
+	}
+	return nil
+}
+
+// spanName applies the configured span name sanitizer, if any.
+func (p *Provider) spanName(name string) string {
+	if p.spanNameSanitizer == nil {
+		return name
+	}
+	return p.spanNameSanitizer(name)
 }
 
 // Name returns the provider name.
@@ -130,6 +356,9 @@ func (p *Provider) Name() string {
 
 // Close closes the provider and flushes pending traces.
 func (p *Provider) Close() error {
+	if p.heartbeat != nil {
+		p.heartbeat.shutdown()
+	}
 	if p.tp != nil {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
@@ -141,6 +370,13 @@ func (p *Provider) Close() error {
 // StartTrace starts a new trace.
 func (p *Provider) StartTrace(ctx context.Context, name string, opts ...llmops.TraceOption) (context.Context, llmops.Trace, error) {
 	cfg := llmops.ApplyTraceOptions(opts...)
+	name = p.spanName(name)
+	if err := p.checkInitialMetadata(name, cfg.Metadata); err != nil {
+		return ctx, nil, err
+	}
+
+	// Verify the project exists so traces are not silently dropped
+	p.checkProject(ctx)
 
 	// Start OTEL span as root
 	ctx, otelSpan := p.tracer.Start(ctx, name)
@@ -156,7 +392,16 @@ func (p *Provider) StartTrace(ctx context.Context, name string, opts ...llmops.T
 
 // StartSpan starts a new span.
 func (p *Provider) StartSpan(ctx context.Context, name string, opts ...llmops.SpanOption) (context.Context, llmops.Span, error) {
-	cfg := llmops.ApplySpanOptions(opts...)
+	// The budget applies to LLM spans only, so it is checked once the
+	// options have set the span type. Options keep no state outside cfg.
+	cfg := applySpanOptions(opts...)
+	if err := p.cost.allow(cfg.Type); err != nil {
+		return ctx, nil, err
+	}
+	name = p.spanName(name)
+	if err := p.checkInitialMetadata(name, cfg.Metadata); err != nil {
+		return ctx, nil, err
+	}
 
 	// Get parent info from context
 	var parentTraceID, parentSpanID string
@@ -171,10 +416,11 @@ func (p *Provider) StartSpan(ctx context.Context, name string, opts ...llmops.Sp
 	}
 
 	// Start OTEL span (automatically links to parent via context)
+	parentCtx := ctx
 	ctx, otelSpan := p.tracer.Start(ctx, name)
 
 	// Create our span wrapper
-	s := newSpan(p, name, otelSpan, parentTraceID, parentSpanID, cfg)
+	s := newSpan(parentCtx, p, name, otelSpan, parentTraceID, parentSpanID, cfg)
 
 	// Store span in context
 	ctx = contextWithSpan(ctx, s)
@@ -182,6 +428,49 @@ func (p *Provider) StartSpan(ctx context.Context, name string, opts ...llmops.Sp
 	return ctx, s, nil
 }
 
+// WrapFunc runs fn in a new span named name, started as by StartSpan with
+// the span's context passed to fn. If fn returns an error, it is recorded
+// on the span and the span's status is set to error. The span is ended
+// before WrapFunc returns fn's error, including when fn panics.
+func (p *Provider) WrapFunc(ctx context.Context, name string, fn func(ctx context.Context) error, opts ...llmops.SpanOption) error {
+	_, err := p.WrapFuncResult(ctx, name, func(ctx context.Context) (any, error) {
+		return nil, fn(ctx)
+	}, opts...)
+	return err
+}
+
+// WrapFuncResult is like WrapFunc for functions that return a value. A
+// non-nil value returned without an error is set as the span's output.
+func (p *Provider) WrapFuncResult(ctx context.Context, name string, fn func(ctx context.Context) (any, error), opts ...llmops.SpanOption) (result any, err error) {
+	ctx, started, err := p.StartSpan(ctx, name, opts...)
+	if err != nil {
+		return nil, err
+	}
+	span := started.(*spanWrapper)
+
+	ended := false
+	defer func() {
+		if !ended {
+			_ = span.SetStatusError("panic")
+			_ = span.End()
+		}
+	}()
+
+	result, err = fn(ctx)
+	ended = true
+	if err != nil {
+		_ = span.SetStatusError(err.Error())
+		_ = span.End(llmops.WithEndError(err))
+		return result, err
+	}
+	if result != nil {
+		_ = span.End(llmops.WithEndOutput(result))
+	} else {
+		_ = span.End()
+	}
+	return result, nil
+}
+
 // TraceFromContext gets the current trace from context.
 func (p *Provider) TraceFromContext(ctx context.Context) (llmops.Trace, bool) {
 	t := traceFromContext(ctx)
@@ -354,25 +643,22 @@ func (p *Provider) CreateDataset(ctx context.Context, name string, opts ...llmop
 
 // GetDataset gets a dataset by name.
 func (p *Provider) GetDataset(ctx context.Context, name string) (*llmops.Dataset, error) {
-	// List datasets and find by name
-	datasets, _, err := p.client.ListDatasets(ctx)
+	ds, err := p.client.GetDatasetByName(ctx, name)
+	if errors.Is(err, phoenix.ErrDatasetNotFound) {
+		return nil, llmops.ErrDatasetNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	for _, ds := range datasets {
-		if ds.Name == name {
-			return &llmops.Dataset{
-				ID:          ds.ID,
-				Name:        ds.Name,
-				Description: ds.Description,
-				ItemCount:   ds.ExampleCount,
-				CreatedAt:   ds.CreatedAt,
-				UpdatedAt:   ds.UpdatedAt,
-			}, nil
-		}
-	}
-	return nil, llmops.ErrDatasetNotFound
+	return &llmops.Dataset{
+		ID:          ds.ID,
+		Name:        ds.Name,
+		Description: ds.Description,
+		ItemCount:   ds.ExampleCount,
+		CreatedAt:   ds.CreatedAt,
+		UpdatedAt:   ds.UpdatedAt,
+	}, nil
 }
 
 // GetDatasetByID gets a dataset by ID.
@@ -533,17 +819,24 @@ func (p *Provider) CreateAnnotation(ctx context.Context, annotation llmops.Annot
 	return &phoenix.APIError{Message: "either SpanID or TraceID must be set"}
 }
 
-// ListAnnotations lists annotations for spans or traces.
+// ListAnnotations lists all annotations for spans or traces.
+// Its signature is fixed by llmops.Provider; use ListSpanAnnotations to page
+// through span annotations.
 func (p *Provider) ListAnnotations(ctx context.Context, opts llmops.ListAnnotationsOptions) ([]*llmops.Annotation, error) {
 	var result []*llmops.Annotation
 
 	if len(opts.SpanIDs) > 0 {
-		annotations, err := p.client.ListSpanAnnotations(ctx, opts.SpanIDs)
-		if err != nil {
-			return nil, err
-		}
-		for _, ann := range annotations {
-			result = append(result, convertAnnotation(ann))
+		cursor := ""
+		for {
+			annotations, next, err := p.ListSpanAnnotations(ctx, opts.SpanIDs, phoenix.WithCursor(cursor))
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, annotations...)
+			if next == "" {
+				break
+			}
+			cursor = next
 		}
 	}
 
@@ -560,6 +853,20 @@ func (p *Provider) ListAnnotations(ctx context.Context, opts llmops.ListAnnotati
 	return result, nil
 }
 
+// ListSpanAnnotations lists one page of annotations for the given span IDs.
+// Pass the returned cursor to phoenix.WithCursor to fetch the next page.
+func (p *Provider) ListSpanAnnotations(ctx context.Context, spanIDs []string, opts ...phoenix.ListOption) ([]*llmops.Annotation, string, error) {
+	annotations, next, err := p.client.ListSpanAnnotations(ctx, spanIDs, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	result := make([]*llmops.Annotation, 0, len(annotations))
+	for _, ann := range annotations {
+		result = append(result, convertAnnotation(ann))
+	}
+	return result, next, nil
+}
+
 func convertAnnotation(ann *phoenix.Annotation) *llmops.Annotation {
 	if ann == nil {
 		return nil
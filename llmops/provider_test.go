@@ -9,7 +9,7 @@ import (
 	"time"
 
 	phoenix "github.com/agentplexus/go-phoenix"
-	_ "github.com/agentplexus/go-phoenix/llmops"
+	phoenixllmops "github.com/agentplexus/go-phoenix/llmops"
 	"github.com/agentplexus/omniobserve/llmops"
 )
 
@@ -375,6 +375,47 @@ func TestSpanTypes(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("retrieval documents", func(t *testing.T) {
+		ctx, trace, err := provider.StartTrace(ctx, "trace-retrieval-documents")
+		if err != nil {
+			t.Fatalf("failed to start trace: %v", err)
+		}
+
+		_, span, err := provider.StartSpan(ctx, "span-retrieval-documents",
+			phoenixllmops.WithRetrievalDocuments([]phoenixllmops.RetrievalDocument{
+				{DocumentID: "doc-1", Content: "Paris is the capital of France.", Score: 0.92},
+				{DocumentID: "doc-2", Content: "Lyon is a city in France.", Score: 0.41},
+			}),
+		)
+		if err != nil {
+			t.Fatalf("failed to start span: %v", err)
+		}
+
+		if span.Type() != llmops.SpanTypeRetrieval {
+			t.Errorf("expected span type %q, got %q", llmops.SpanTypeRetrieval, span.Type())
+		}
+
+		rs, ok := span.(phoenixllmops.RetrievalSpan)
+		if !ok {
+			t.Fatal("expected span to implement RetrievalSpan")
+		}
+		if err := rs.AddRetrievalDocument(phoenixllmops.RetrievalDocument{
+			DocumentID: "doc-3",
+			Content:    "Marseille is a port city in France.",
+			Score:      0.27,
+			Metadata:   map[string]any{"source": "wiki"},
+		}); err != nil {
+			t.Errorf("failed to add retrieval document: %v", err)
+		}
+
+		if err := span.End(); err != nil {
+			t.Errorf("failed to end span: %v", err)
+		}
+		if err := trace.End(); err != nil {
+			t.Errorf("failed to end trace: %v", err)
+		}
+	})
 }
 
 func TestSpanWithLLMMetadata(t *testing.T) {
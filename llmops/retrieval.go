@@ -0,0 +1,42 @@
+package llmops
+
+import (
+	phoenixotel "github.com/agentplexus/go-phoenix/otel"
+	"github.com/agentplexus/omniobserve/llmops"
+)
+
+// RetrievalDocument is a ranked document returned by a retrieval span.
+type RetrievalDocument = phoenixotel.RetrievalDocument
+
+// RetrievalSpan is implemented by spans created by this provider and allows
+// documents to be added to a retrieval span as they are retrieved.
+type RetrievalSpan interface {
+	llmops.Span
+	AddRetrievalDocument(doc RetrievalDocument) error
+}
+
+// WithRetrievalDocuments records ranked retrieval documents on the span and
+// marks it as a retrieval span. Documents are recorded in the given order.
+// The option only has an effect on spans started by this provider.
+func WithRetrievalDocuments(docs []RetrievalDocument) llmops.SpanOption {
+	return func(o *llmops.SpanOptions) {
+		if o.Type == "" || o.Type == llmops.SpanTypeGeneral {
+			o.Type = llmops.SpanTypeRetrieval
+		}
+		if cfg := applyingSpanConfig(o); cfg != nil {
+			cfg.retrievalDocuments = docs
+		}
+	}
+}
+
+// AddRetrievalDocument appends a document to the span's retrieval documents,
+// after any set with WithRetrievalDocuments.
+func (s *spanWrapper) AddRetrievalDocument(doc RetrievalDocument) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.otelSpan.SetAttributes(phoenixotel.WithRetrievalDocument(s.documentCount, doc)...)
+	s.documentCount++
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package llmops
+
+import (
+	"regexp"
+	"unicode/utf8"
+)
+
+// TruncateSanitizer returns a span name sanitizer that truncates names to at
+// most maxLen runes.
+func TruncateSanitizer(maxLen int) func(string) string {
+	return func(name string) string {
+		if maxLen < 0 || utf8.RuneCountInString(name) <= maxLen {
+			return name
+		}
+		return string([]rune(name)[:maxLen])
+	}
+}
+
+// RegexReplaceSanitizer returns a span name sanitizer that replaces all
+// matches of pattern with replacement, as regexp.Regexp.ReplaceAllString does.
+// It panics if pattern does not compile, like regexp.MustCompile.
+func RegexReplaceSanitizer(pattern, replacement string) func(string) string {
+	re := regexp.MustCompile(pattern)
+	return func(name string) string {
+		return re.ReplaceAllString(name, replacement)
+	}
+}
+
+var (
+	uuidPattern      = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+	numericIDPattern = regexp.MustCompile(`\b\d{3,}\b`)
+)
+
+// TemplateSanitizer returns a span name sanitizer that replaces UUIDs and
+// numeric IDs with an {id} placeholder, e.g. "get-user-12345" becomes
+// "get-user-{id}". Only runs of three or more digits are treated as IDs so
+// that names such as "gpt-4" are left intact.
+func TemplateSanitizer() func(string) string {
+	return func(name string) string {
+		name = uuidPattern.ReplaceAllString(name, "{id}")
+		return numericIDPattern.ReplaceAllString(name, "{id}")
+	}
+}
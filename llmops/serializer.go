@@ -0,0 +1,78 @@
+package llmops
+
+import (
+	"log/slog"
+	"unicode/utf8"
+
+	phoenixotel "github.com/agentplexus/go-phoenix/otel"
+)
+
+// maxSerializedValueSize is the largest value, in bytes, DefaultSerializer
+// returns.
+const maxSerializedValueSize = 64 << 10
+
+// WithInputSerializer sets the function that converts span and trace inputs
+// to the input.value attribute. If fn returns an error, DefaultSerializer is
+// used instead and a warning is logged. Use it to plug in another JSON
+// encoder or a custom marshaler. By default, inputs are converted with
+// DefaultSerializer, or with fmt.Sprint under phoenixotel.EncodingString.
+func WithInputSerializer(fn func(any) (string, error)) ClientOption {
+	return func(o *providerOptions) {
+		o.inputSerializer = fn
+	}
+}
+
+// WithOutputSerializer sets the function that converts span and trace
+// outputs to the output.value attribute. See WithInputSerializer.
+func WithOutputSerializer(fn func(any) (string, error)) ClientOption {
+	return func(o *providerOptions) {
+		o.outputSerializer = fn
+	}
+}
+
+// DefaultSerializer converts v to an attribute string. Strings and byte
+// slices are used as is and nil becomes "". Other values are JSON-encoded,
+// or formatted with fmt.Sprintf("%+v") if they cannot be, such as values
+// holding channels or cycles. The result is truncated to 64KB, so large
+// values may not be valid JSON. It never returns an error.
+func DefaultSerializer(v any) (string, error) {
+	return truncateValue(phoenixotel.EncodingJSON.EncodeValue(v), maxSerializedValueSize), nil
+}
+
+// truncateValue shortens s to at most n bytes without splitting a UTF-8
+// character.
+func truncateValue(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// serializeInput converts an input value with the provider's input
+// serializer.
+func (p *Provider) serializeInput(v any) string {
+	return p.serialize(p.inputSerializer, "input", v)
+}
+
+// serializeOutput converts an output value with the provider's output
+// serializer.
+func (p *Provider) serializeOutput(v any) string {
+	return p.serialize(p.outputSerializer, "output", v)
+}
+
+func (p *Provider) serialize(fn func(any) (string, error), kind string, v any) string {
+	if fn != nil {
+		s, err := fn(v)
+		if err == nil {
+			return s
+		}
+		slog.Warn("phoenix: could not serialize span "+kind+", using default serializer", "error", err)
+	} else if p.encoding == phoenixotel.EncodingString {
+		return p.encoding.EncodeValue(v)
+	}
+	s, _ := DefaultSerializer(v)
+	return s
+}
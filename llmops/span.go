@@ -3,30 +3,89 @@ package llmops
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
 	"sync"
 	"time"
 
 	phoenixotel "github.com/agentplexus/go-phoenix/otel"
 	"github.com/agentplexus/omniobserve/llmops"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 )
 
+// GuardrailResult is the outcome of a guardrail check.
+type GuardrailResult = phoenixotel.GuardrailResult
+
+// GuardrailSpan is implemented by spans created by this provider and records
+// the structured result of a guardrail check.
+type GuardrailSpan interface {
+	llmops.Span
+	SetGuardrailResult(r GuardrailResult) error
+}
+
+// LatencySpan is implemented by spans created by this provider and records
+// the latency breakdown of an LLM call.
+type LatencySpan interface {
+	llmops.Span
+	RecordLatencyBreakdown(ttfb, totalDuration time.Duration) error
+	SetFirstTokenTime(t time.Time) error
+}
+
+// CloneableSpan is implemented by spans created by this provider and starts
+// a sibling span with the same kind, model and LLM provider, for example to
+// instrument a retry.
+type CloneableSpan interface {
+	llmops.Span
+	Clone(ctx context.Context, nameSuffix string) (context.Context, llmops.Span, error)
+}
+
+// TaggableSpan is implemented by spans created by this provider and gives
+// access to the span's tags.
+type TaggableSpan interface {
+	llmops.Span
+	RemoveTag(tag string) error
+	Tags() []string
+}
+
+// HTTPSpan is implemented by spans created by this provider and records the
+// HTTP request and response of an outgoing call.
+type HTTPSpan interface {
+	llmops.Span
+	RecordHTTPRequest(r *http.Request) error
+	RecordHTTPResponse(resp *http.Response) error
+}
+
 // spanWrapper implements llmops.Span wrapping an OTEL span.
 type spanWrapper struct {
-	provider     *Provider
-	otelSpan     trace.Span
-	traceID      string
-	parentSpanID string
-	name         string
-	spanType     llmops.SpanType
-	startTime    time.Time
-	endTime      *time.Time
-	mu           sync.RWMutex
-}
-
-func newSpan(provider *Provider, name string, otelSpan trace.Span, traceID, parentSpanID string, cfg *llmops.SpanOptions) *spanWrapper {
+	// parentCtx is the context the span was started in, holding its
+	// parent span or trace. It is used to propagate errors.
+	parentCtx     context.Context
+	provider      *Provider
+	otelSpan      trace.Span
+	traceID       string
+	parentSpanID  string
+	name          string
+	spanType      llmops.SpanType
+	startTime     time.Time
+	endTime       *time.Time
+	statusCode    codes.Code
+	documentCount int
+	usage         *llmops.TokenUsage
+	tags          []string
+	mu            sync.RWMutex
+
+	// The latency breakdown is recorded at most once.
+	ttfbOnce  sync.Once
+	totalOnce sync.Once
+}
+
+func newSpan(parentCtx context.Context, provider *Provider, name string, otelSpan trace.Span, traceID, parentSpanID string, cfg *spanConfig) *spanWrapper {
 	s := &spanWrapper{
+		parentCtx:    parentCtx,
 		provider:     provider,
 		otelSpan:     otelSpan,
 		traceID:      traceID,
@@ -36,6 +95,11 @@ func newSpan(provider *Provider, name string, otelSpan trace.Span, traceID, pare
 		startTime:    time.Now(),
 	}
 
+	// Apply context defaults first so span options can override them
+	if defaults := spanDefaultsFromContext(parentCtx); len(defaults) > 0 {
+		_ = s.SetAttributes(defaults)
+	}
+
 	// Set span kind based on type
 	if cfg.Type != "" {
 		otelKind := mapSpanTypeToOpenInference(cfg.Type)
@@ -46,8 +110,9 @@ func newSpan(provider *Provider, name string, otelSpan trace.Span, traceID, pare
 	if cfg.Input != nil {
 		_ = s.SetInput(cfg.Input)
 	}
+	// Initial metadata was validated by the caller.
 	if cfg.Metadata != nil {
-		_ = s.SetMetadata(cfg.Metadata)
+		otelSpan.SetAttributes(provider.encoding.WithMetadata(cfg.Metadata)...)
 	}
 	if len(cfg.Tags) > 0 {
 		for _, tag := range cfg.Tags {
@@ -63,10 +128,38 @@ func newSpan(provider *Provider, name string, otelSpan trace.Span, traceID, pare
 	if cfg.Usage != nil {
 		_ = s.SetUsage(*cfg.Usage)
 	}
+	for _, doc := range cfg.retrievalDocuments {
+		_ = s.AddRetrievalDocument(doc)
+	}
 
 	return s
 }
 
+type spanDefaultsContextKey struct{}
+
+// WithSpanDefaults returns a copy of ctx whose spans get the given
+// attributes when they start, before the attributes set by span options.
+// Use it to tag every span of a request, for example with a tenant ID,
+// without changing instrumentation call sites. Defaults from an enclosing
+// WithSpanDefaults are kept unless overridden by key. Values are converted
+// as by SetAttributes.
+func WithSpanDefaults(ctx context.Context, defaults map[string]any) context.Context {
+	merged := maps.Clone(spanDefaultsFromContext(ctx))
+	if merged == nil {
+		merged = make(map[string]any, len(defaults))
+	}
+	maps.Copy(merged, defaults)
+	return context.WithValue(ctx, spanDefaultsContextKey{}, merged)
+}
+
+func spanDefaultsFromContext(ctx context.Context) map[string]any {
+	if ctx == nil {
+		return nil
+	}
+	defaults, _ := ctx.Value(spanDefaultsContextKey{}).(map[string]any)
+	return defaults
+}
+
 // mapSpanTypeToOpenInference maps llmops.SpanType to OpenInference span kind.
 func mapSpanTypeToOpenInference(spanType llmops.SpanType) string {
 	switch spanType {
@@ -113,18 +206,28 @@ func (s *spanWrapper) Name() string {
 
 // Type returns the span type.
 func (s *spanWrapper) Type() llmops.SpanType {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return s.spanType
 }
 
 // StartSpan creates a child span within this span.
 func (s *spanWrapper) StartSpan(ctx context.Context, name string, opts ...llmops.SpanOption) (context.Context, llmops.Span, error) {
-	cfg := llmops.ApplySpanOptions(opts...)
+	cfg := applySpanOptions(opts...)
+	if err := s.provider.cost.allow(cfg.Type); err != nil {
+		return ctx, nil, err
+	}
+	name = s.provider.spanName(name)
+	if err := s.provider.checkInitialMetadata(name, cfg.Metadata); err != nil {
+		return ctx, nil, err
+	}
 
 	// Start child span using the provider's tracer
+	parentCtx := contextWithSpan(ctx, s)
 	ctx, otelSpan := s.provider.tracer.Start(ctx, name)
 
 	// Create span wrapper
-	child := newSpan(s.provider, name, otelSpan, s.TraceID(), s.ID(), cfg)
+	child := newSpan(parentCtx, s.provider, name, otelSpan, s.TraceID(), s.ID(), cfg)
 
 	// Store in context
 	ctx = contextWithSpan(ctx, child)
@@ -132,13 +235,44 @@ func (s *spanWrapper) StartSpan(ctx context.Context, name string, opts ...llmops
 	return ctx, child, nil
 }
 
+// Clone starts a new span under the same parent as s, named after s with
+// nameSuffix appended (e.g. "-retry-1"), and copies its span kind, model and
+// LLM provider. Values of ctx, such as span defaults, apply to the clone,
+// but its parent is always the parent of s.
+func (s *spanWrapper) Clone(ctx context.Context, nameSuffix string) (context.Context, llmops.Span, error) {
+	s.mu.RLock()
+	cfg := &spanConfig{SpanOptions: llmops.SpanOptions{
+		Type:     s.spanType,
+		Model:    spanAttribute(s.otelSpan, phoenixotel.LLMModelName),
+		Provider: spanAttribute(s.otelSpan, phoenixotel.LLMProvider),
+	}}
+	name := s.name + nameSuffix
+	s.mu.RUnlock()
+
+	if err := s.provider.cost.allow(cfg.Type); err != nil {
+		return ctx, nil, err
+	}
+
+	// Re-parent ctx: the clone is a sibling of s, never its child
+	parentCtx := trace.ContextWithSpan(ctx, trace.SpanFromContext(s.parentCtx))
+	parentCtx = contextWithSpan(parentCtx, spanFromContext(s.parentCtx))
+	if t := traceFromContext(s.parentCtx); t != nil {
+		parentCtx = contextWithTrace(parentCtx, t)
+	}
+
+	ctx, otelSpan := s.provider.tracer.Start(parentCtx, name)
+	clone := newSpan(parentCtx, s.provider, name, otelSpan, s.traceID, s.parentSpanID, cfg)
+	ctx = contextWithSpan(ctx, clone)
+
+	return ctx, clone, nil
+}
+
 // SetInput sets the span input data using OpenInference attributes.
 func (s *spanWrapper) SetInput(input any) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	inputStr := toString(input)
-	s.otelSpan.SetAttributes(phoenixotel.WithInput(inputStr))
+	s.otelSpan.SetAttributes(phoenixotel.WithInput(s.provider.serializeInput(input)))
 
 	return nil
 }
@@ -148,22 +282,20 @@ func (s *spanWrapper) SetOutput(output any) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	outputStr := toString(output)
-	s.otelSpan.SetAttributes(phoenixotel.WithOutput(outputStr))
+	s.otelSpan.SetAttributes(phoenixotel.WithOutput(s.provider.serializeOutput(output)))
 
 	return nil
 }
 
-// SetMetadata sets additional metadata on the span.
+// SetMetadata sets additional metadata on the span. See WithMetadataSchema
+// for validating it.
 func (s *spanWrapper) SetMetadata(metadata map[string]any) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if data, err := json.Marshal(metadata); err == nil {
-		s.otelSpan.SetAttributes(phoenixotel.WithMetadata(string(data)))
-	}
+	s.otelSpan.SetAttributes(s.provider.encoding.WithMetadata(metadata)...)
 
-	return nil
+	return s.provider.checkMetadata(s.name, metadata)
 }
 
 // SetModel sets the LLM model name using OpenInference attributes.
@@ -197,20 +329,159 @@ func (s *spanWrapper) SetUsage(usage llmops.TokenUsage) error {
 		usage.TotalTokens,
 	)
 	s.otelSpan.SetAttributes(attrs...)
+	s.usage = &usage
+
+	return nil
+}
+
+// SetAttributes sets multiple attributes on the span from a flat map.
+// See toAttributes for how values are converted.
+func (s *spanWrapper) SetAttributes(attrs map[string]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.otelSpan.SetAttributes(toAttributes(attrs)...)
+
+	return nil
+}
+
+// SetGuardrailResult marks the span as a guardrail span (see
+// llmops.SpanTypeGuardrail) and records r as guardrail.* attributes.
+func (s *spanWrapper) SetGuardrailResult(r GuardrailResult) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.spanType = llmops.SpanTypeGuardrail
+	s.otelSpan.SetAttributes(phoenixotel.WithSpanKind(phoenixotel.SpanKindGuardrail))
+	s.otelSpan.SetAttributes(phoenixotel.WithGuardrailResult(r)...)
+
+	return nil
+}
+
+// RecordHTTPRequest records the method, URL, scheme and host of r as http.*
+// attributes. Credentials in the URL are redacted.
+func (s *spanWrapper) RecordHTTPRequest(r *http.Request) error {
+	if r == nil || r.URL == nil {
+		return fmt.Errorf("%w: request is required", llmops.ErrInvalidInput)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	host := r.Host
+	if host == "" {
+		host = r.URL.Host
+	}
+	s.otelSpan.SetAttributes(
+		attribute.String(phoenixotel.HTTPMethod, r.Method),
+		attribute.String(phoenixotel.HTTPURL, r.URL.Redacted()),
+		attribute.String(phoenixotel.HTTPScheme, r.URL.Scheme),
+		attribute.String(phoenixotel.HTTPHost, host),
+	)
 
 	return nil
 }
 
-// AddTag adds a tag to the span.
+// RecordHTTPResponse records the status code of resp and, when known, its
+// content length as http.* attributes.
+func (s *spanWrapper) RecordHTTPResponse(resp *http.Response) error {
+	if resp == nil {
+		return fmt.Errorf("%w: response is required", llmops.ErrInvalidInput)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.otelSpan.SetAttributes(attribute.Int(phoenixotel.HTTPStatusCode, resp.StatusCode))
+	if resp.ContentLength >= 0 {
+		s.otelSpan.SetAttributes(attribute.Int64(phoenixotel.HTTPResponseContentLength, resp.ContentLength))
+	}
+
+	return nil
+}
+
+// RecordLatencyBreakdown records the time to first token and the total
+// duration of an LLM call as llm.latency.* attributes, in milliseconds.
+// Values already recorded, including a time to first token set with
+// SetFirstTokenTime, are not overwritten.
+func (s *spanWrapper) RecordLatencyBreakdown(ttfb, totalDuration time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.setTTFB(ttfb)
+	s.totalOnce.Do(func() {
+		s.otelSpan.SetAttributes(attribute.Float64(phoenixotel.LLMLatencyTotalMs, durationMs(totalDuration)))
+	})
+
+	return nil
+}
+
+// SetFirstTokenTime records the time to first token as the time elapsed
+// between the span start and t. It has no effect once a time to first
+// token has been recorded.
+func (s *spanWrapper) SetFirstTokenTime(t time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.setTTFB(t.Sub(s.startTime))
+
+	return nil
+}
+
+func (s *spanWrapper) setTTFB(ttfb time.Duration) {
+	s.ttfbOnce.Do(func() {
+		s.otelSpan.SetAttributes(attribute.Float64(phoenixotel.LLMLatencyTTFBMs, durationMs(ttfb)))
+	})
+}
+
+// durationMs converts d to fractional milliseconds.
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// AddTag adds a tag to the span. Tags are recorded as a JSON array in the
+// tag.tags attribute when the span ends; adding a tag twice has no effect.
 func (s *spanWrapper) AddTag(tag string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.otelSpan.SetAttributes(attribute.String(phoenixotel.TagsKey, tag))
+	s.tags = addTag(s.tags, tag)
 
 	return nil
 }
 
+// RemoveTag removes a tag from the span. Removing a tag the span does not
+// have has no effect.
+func (s *spanWrapper) RemoveTag(tag string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tags = slices.DeleteFunc(s.tags, func(t string) bool { return t == tag })
+
+	return nil
+}
+
+// Tags returns the span's tags in the order they were added.
+func (s *spanWrapper) Tags() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.tags)
+}
+
+// addTag appends tag to tags unless it is already present.
+func addTag(tags []string, tag string) []string {
+	if slices.Contains(tags, tag) {
+		return tags
+	}
+	return append(tags, tag)
+}
+
+// tagsAttribute encodes tags as the JSON array Phoenix expects in tag.tags.
+func tagsAttribute(tags []string) attribute.KeyValue {
+	data, _ := json.Marshal(tags)
+	return attribute.String(phoenixotel.TagsKey, string(data))
+}
+
 // AddFeedbackScore adds a feedback score to this span.
 func (s *spanWrapper) AddFeedbackScore(ctx context.Context, name string, score float64, opts ...llmops.FeedbackOption) error {
 	s.mu.Lock()
@@ -239,31 +510,114 @@ func (s *spanWrapper) End(opts ...llmops.EndOption) error {
 
 	// Set final output if provided
 	if cfg.Output != nil {
-		outputStr := toString(cfg.Output)
-		s.otelSpan.SetAttributes(phoenixotel.WithOutput(outputStr))
+		s.otelSpan.SetAttributes(phoenixotel.WithOutput(s.provider.serializeOutput(cfg.Output)))
 	}
 
 	// Set final metadata if provided
 	if cfg.Metadata != nil {
-		if data, err := json.Marshal(cfg.Metadata); err == nil {
-			s.otelSpan.SetAttributes(phoenixotel.WithMetadata(string(data)))
-		}
+		s.otelSpan.SetAttributes(s.provider.encoding.WithMetadata(cfg.Metadata)...)
 	}
 
 	// Record error if provided
 	if cfg.Error != nil {
 		s.otelSpan.RecordError(cfg.Error)
+		if s.provider.errorPropagation && s.endTime == nil {
+			propagateError(s.parentCtx, cfg.Error)
+		}
+	}
+
+	if len(s.tags) > 0 {
+		s.otelSpan.SetAttributes(tagsAttribute(s.tags))
+	}
+
+	// Audit attributes once, before they are exported
+	var err error
+	if s.endTime == nil {
+		err = s.checkAttributes()
 	}
 
 	// End the OTEL span
 	s.otelSpan.End()
 
+	// Count the span's usage towards the provider's running total
+	if s.usage != nil && s.endTime == nil {
+		s.provider.usage.record(*s.usage)
+	}
+
+	// Count the span's cost towards the provider's cost budget
+	if s.endTime == nil {
+		s.provider.cost.record(spanCost(s.otelSpan))
+	}
+
 	now := time.Now()
 	s.endTime = &now
 
+	return err
+}
+
+// SetStatusOK marks the span as successfully completed.
+func (s *spanWrapper) SetStatusOK() error {
+	return s.setStatus(codes.Ok, "")
+}
+
+// SetStatusError marks the span as failed with the given message.
+func (s *spanWrapper) SetStatusError(msg string) error {
+	return s.setStatus(codes.Error, msg)
+}
+
+// SetStatusUnset sets the span status to unset. Following OTEL semantics,
+// this has no effect once an OK or error status has been set.
+func (s *spanWrapper) SetStatusUnset() error {
+	return s.setStatus(codes.Unset, "")
+}
+
+// IsError reports whether the span status is currently set to error.
+func (s *spanWrapper) IsError() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.statusCode == codes.Error
+}
+
+func (s *spanWrapper) setStatus(code codes.Code, msg string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Mirror the OTEL SDK precedence (Unset < Error < Ok): a status can
+	// only be raised, so SetStatusUnset has no effect once a status is set.
+	s.otelSpan.SetStatus(code, msg)
+	if code > s.statusCode {
+		s.statusCode = code
+	}
+
 	return nil
 }
 
+// childErrorEventName is the name of the event recorded on ancestors of a
+// failed span when error propagation is enabled.
+const childErrorEventName = "child_error"
+
+// propagateError marks the span or trace in ctx, and each of its ancestors,
+// as failed with err.
+func propagateError(ctx context.Context, err error) {
+	for ctx != nil {
+		if s := spanFromContext(ctx); s != nil {
+			s.otelSpan.AddEvent(childErrorEventName, trace.WithAttributes(
+				attribute.String("exception.message", err.Error()),
+			))
+			_ = s.setStatus(codes.Error, err.Error())
+			ctx = s.parentCtx
+			continue
+		}
+		if t := traceFromContext(ctx); t != nil {
+			t.otelSpan.AddEvent(childErrorEventName, trace.WithAttributes(
+				attribute.String("exception.message", err.Error()),
+			))
+			_ = t.setStatus(codes.Error, err.Error())
+		}
+		return
+	}
+}
+
 // EndTime returns when the span ended.
 func (s *spanWrapper) EndTime() *time.Time {
 	s.mu.RLock()
@@ -0,0 +1,45 @@
+package llmops
+
+import "github.com/agentplexus/omniobserve/llmops"
+
+// spanConfig is the configuration of a span started by this provider: the
+// llmops span options plus settings llmops.SpanOptions has no field for.
+type spanConfig struct {
+	llmops.SpanOptions
+	retrievalDocuments []RetrievalDocument
+}
+
+// spanConfigCarrier hands the spanConfig being built to options specific to
+// this provider. applySpanOptions places it in SpanOptions.Input while each
+// option runs and restores the input afterwards, unless the option set one.
+type spanConfigCarrier struct {
+	cfg *spanConfig
+}
+
+// applySpanOptions is llmops.ApplySpanOptions for spans started by this
+// provider.
+func applySpanOptions(opts ...llmops.SpanOption) *spanConfig {
+	cfg := &spanConfig{SpanOptions: llmops.SpanOptions{Type: llmops.SpanTypeGeneral}}
+	carrier := &spanConfigCarrier{cfg: cfg}
+
+	for _, opt := range opts {
+		input := cfg.Input
+		cfg.Input = carrier
+		opt(&cfg.SpanOptions)
+		if c, ok := cfg.Input.(*spanConfigCarrier); ok && c == carrier {
+			cfg.Input = input
+		}
+	}
+	return cfg
+}
+
+// applyingSpanConfig returns the spanConfig o belongs to, or nil if o is not
+// being built by applySpanOptions, e.g. when an option of this provider is
+// applied by another one.
+func applyingSpanConfig(o *llmops.SpanOptions) *spanConfig {
+	c, ok := o.Input.(*spanConfigCarrier)
+	if !ok {
+		return nil
+	}
+	return c.cfg
+}
@@ -2,24 +2,47 @@ package llmops
 
 import (
 	"context"
-	"encoding/json"
+	"slices"
+	"sort"
 	"sync"
 	"time"
 
 	phoenixotel "github.com/agentplexus/go-phoenix/otel"
 	"github.com/agentplexus/omniobserve/llmops"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 )
 
+// ConversationTrace is implemented by traces created by this provider and
+// exposes the identifiers used to correlate the traces of a multi-turn
+// conversation.
+type ConversationTrace interface {
+	llmops.Trace
+	ThreadID() string
+	UserID() string
+	SetThreadID(id string) error
+	SetUserID(id string) error
+}
+
+// TaggableTrace is implemented by traces created by this provider and gives
+// access to the trace's tags.
+type TaggableTrace interface {
+	llmops.Trace
+	RemoveTag(tag string) error
+	Tags() []string
+}
+
 // traceWrapper implements llmops.Trace wrapping an OTEL span.
 type traceWrapper struct {
-	provider  *Provider
-	otelSpan  trace.Span
-	name      string
-	startTime time.Time
-	endTime   *time.Time
-	mu        sync.RWMutex
+	provider   *Provider
+	otelSpan   trace.Span
+	name       string
+	startTime  time.Time
+	endTime    *time.Time
+	statusCode codes.Code
+	tags       []string
+	mu         sync.RWMutex
 }
 
 func newTrace(provider *Provider, name string, otelSpan trace.Span, cfg *llmops.TraceOptions) *traceWrapper {
@@ -34,8 +57,9 @@ func newTrace(provider *Provider, name string, otelSpan trace.Span, cfg *llmops.
 	if cfg.Input != nil {
 		_ = t.SetInput(cfg.Input)
 	}
+	// Initial metadata was validated by the caller.
 	if cfg.Metadata != nil {
-		_ = t.SetMetadata(cfg.Metadata)
+		otelSpan.SetAttributes(provider.encoding.WithMetadata(cfg.Metadata)...)
 	}
 	if len(cfg.Tags) > 0 {
 		for _, tag := range cfg.Tags {
@@ -61,13 +85,21 @@ func (t *traceWrapper) Name() string {
 
 // StartSpan creates a child span within this trace.
 func (t *traceWrapper) StartSpan(ctx context.Context, name string, opts ...llmops.SpanOption) (context.Context, llmops.Span, error) {
-	cfg := llmops.ApplySpanOptions(opts...)
+	cfg := applySpanOptions(opts...)
+	if err := t.provider.cost.allow(cfg.Type); err != nil {
+		return ctx, nil, err
+	}
+	name = t.provider.spanName(name)
+	if err := t.provider.checkInitialMetadata(name, cfg.Metadata); err != nil {
+		return ctx, nil, err
+	}
 
 	// Start child span using the provider's tracer
+	parentCtx := contextWithTrace(ctx, t)
 	ctx, otelSpan := t.provider.tracer.Start(ctx, name)
 
 	// Create span wrapper
-	s := newSpan(t.provider, name, otelSpan, t.ID(), "", cfg)
+	s := newSpan(parentCtx, t.provider, name, otelSpan, t.ID(), "", cfg)
 
 	// Store in context
 	ctx = contextWithSpan(ctx, s)
@@ -81,8 +113,7 @@ func (t *traceWrapper) SetInput(input any) error {
 	defer t.mu.Unlock()
 
 	// Convert to string for OTEL attribute
-	inputStr := toString(input)
-	t.otelSpan.SetAttributes(phoenixotel.WithInput(inputStr))
+	t.otelSpan.SetAttributes(phoenixotel.WithInput(t.provider.serializeInput(input)))
 
 	return nil
 }
@@ -92,36 +123,100 @@ func (t *traceWrapper) SetOutput(output any) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	outputStr := toString(output)
-	t.otelSpan.SetAttributes(phoenixotel.WithOutput(outputStr))
+	t.otelSpan.SetAttributes(phoenixotel.WithOutput(t.provider.serializeOutput(output)))
 
 	return nil
 }
 
-// SetMetadata sets additional metadata on the trace.
+// SetMetadata sets additional metadata on the trace. See WithMetadataSchema
+// for validating it.
 func (t *traceWrapper) SetMetadata(metadata map[string]any) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	// Serialize metadata to JSON for OTEL attribute
-	if data, err := json.Marshal(metadata); err == nil {
-		t.otelSpan.SetAttributes(phoenixotel.WithMetadata(string(data)))
-	}
+	// Flatten metadata into typed metadata.* attributes, unless the
+	// provider encodes it as a single string
+	t.otelSpan.SetAttributes(t.provider.encoding.WithMetadata(metadata)...)
+
+	return t.provider.checkMetadata(t.name, metadata)
+}
+
+// SetAttributes sets multiple attributes on the trace from a flat map.
+// See toAttributes for how values are converted.
+func (t *traceWrapper) SetAttributes(attrs map[string]any) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.otelSpan.SetAttributes(toAttributes(attrs)...)
 
 	return nil
 }
 
-// AddTag adds a tag to the trace.
+// ThreadID returns the conversation thread ID of the trace, set with
+// llmops.WithThreadID or SetThreadID, read from its session.id attribute.
+func (t *traceWrapper) ThreadID() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return spanAttribute(t.otelSpan, phoenixotel.SessionID)
+}
+
+// UserID returns the user ID of the trace, set with SetUserID, read from its
+// user.id attribute.
+func (t *traceWrapper) UserID() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return spanAttribute(t.otelSpan, phoenixotel.UserID)
+}
+
+// SetThreadID sets the conversation thread ID of the trace.
+func (t *traceWrapper) SetThreadID(id string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.otelSpan.SetAttributes(phoenixotel.WithSessionID(id))
+
+	return nil
+}
+
+// SetUserID sets the user ID of the trace.
+func (t *traceWrapper) SetUserID(id string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.otelSpan.SetAttributes(phoenixotel.WithUserID(id))
+
+	return nil
+}
+
+// AddTag adds a tag to the trace. Tags are recorded as a JSON array in the
+// tag.tags attribute when the trace ends; adding a tag twice has no effect.
 func (t *traceWrapper) AddTag(tag string) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	// Add as OTEL attribute
-	t.otelSpan.SetAttributes(attribute.String(phoenixotel.TagsKey, tag))
+	t.tags = addTag(t.tags, tag)
 
 	return nil
 }
 
+// RemoveTag removes a tag from the trace. Removing a tag the trace does not
+// have has no effect.
+func (t *traceWrapper) RemoveTag(tag string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.tags = slices.DeleteFunc(t.tags, func(s string) bool { return s == tag })
+
+	return nil
+}
+
+// Tags returns the trace's tags in the order they were added.
+func (t *traceWrapper) Tags() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return slices.Clone(t.tags)
+}
+
 // AddFeedbackScore adds a feedback score to this trace.
 func (t *traceWrapper) AddFeedbackScore(ctx context.Context, name string, score float64, opts ...llmops.FeedbackOption) error {
 	t.mu.Lock()
@@ -150,15 +245,12 @@ func (t *traceWrapper) End(opts ...llmops.EndOption) error {
 
 	// Set final output if provided
 	if cfg.Output != nil {
-		outputStr := toString(cfg.Output)
-		t.otelSpan.SetAttributes(phoenixotel.WithOutput(outputStr))
+		t.otelSpan.SetAttributes(phoenixotel.WithOutput(t.provider.serializeOutput(cfg.Output)))
 	}
 
 	// Set final metadata if provided
 	if cfg.Metadata != nil {
-		if data, err := json.Marshal(cfg.Metadata); err == nil {
-			t.otelSpan.SetAttributes(phoenixotel.WithMetadata(string(data)))
-		}
+		t.otelSpan.SetAttributes(t.provider.encoding.WithMetadata(cfg.Metadata)...)
 	}
 
 	// Record error if provided
@@ -166,6 +258,10 @@ func (t *traceWrapper) End(opts ...llmops.EndOption) error {
 		t.otelSpan.RecordError(cfg.Error)
 	}
 
+	if len(t.tags) > 0 {
+		t.otelSpan.SetAttributes(tagsAttribute(t.tags))
+	}
+
 	// End the OTEL span
 	t.otelSpan.End()
 
@@ -175,6 +271,43 @@ func (t *traceWrapper) End(opts ...llmops.EndOption) error {
 	return nil
 }
 
+// SetStatusOK marks the trace as successfully completed.
+func (t *traceWrapper) SetStatusOK() error {
+	return t.setStatus(codes.Ok, "")
+}
+
+// SetStatusError marks the trace as failed with the given message.
+func (t *traceWrapper) SetStatusError(msg string) error {
+	return t.setStatus(codes.Error, msg)
+}
+
+// SetStatusUnset sets the trace status to unset. Following OTEL semantics,
+// this has no effect once an OK or error status has been set.
+func (t *traceWrapper) SetStatusUnset() error {
+	return t.setStatus(codes.Unset, "")
+}
+
+// IsError reports whether the trace status is currently set to error.
+func (t *traceWrapper) IsError() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.statusCode == codes.Error
+}
+
+func (t *traceWrapper) setStatus(code codes.Code, msg string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// Mirror the OTEL SDK precedence (Unset < Error < Ok): a status can
+	// only be raised, so SetStatusUnset has no effect once a status is set.
+	t.otelSpan.SetStatus(code, msg)
+	if code > t.statusCode {
+		t.statusCode = code
+	}
+
+	return nil
+}
+
 // EndTime returns when the trace ended.
 func (t *traceWrapper) EndTime() *time.Time {
 	t.mu.RLock()
@@ -219,22 +352,59 @@ func spanFromContext(ctx context.Context) *spanWrapper {
 	return nil
 }
 
-// toString converts any value to string for OTEL attributes.
-func toString(v any) string {
-	if v == nil {
+// spanAttribute returns the string value of the attribute key on span, or
+// "" if it is not set or the span does not record attributes.
+func spanAttribute(span trace.Span, key string) string {
+	ro, ok := span.(interface{ Attributes() []attribute.KeyValue })
+	if !ok {
 		return ""
 	}
-	switch val := v.(type) {
-	case string:
-		return val
-	case []byte:
-		return string(val)
-	default:
-		if data, err := json.Marshal(val); err == nil {
-			return string(data)
+	value := ""
+	for _, kv := range ro.Attributes() {
+		if string(kv.Key) == key {
+			value = kv.Value.Emit()
+		}
+	}
+	return value
+}
+
+// toString converts any value to string for OTEL attributes. See
+// DefaultSerializer.
+func toString(v any) string {
+	s, _ := DefaultSerializer(v)
+	return s
+}
+
+// toAttributes converts a flat map to OTEL attributes, sorted by key.
+// Strings, integers, floats, booleans and string slices map to their typed
+// attribute equivalents; any other value is converted with toString.
+func toAttributes(attrs map[string]any) []attribute.KeyValue {
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+	for _, k := range keys {
+		switch v := attrs[k].(type) {
+		case string:
+			kvs = append(kvs, attribute.String(k, v))
+		case int:
+			kvs = append(kvs, attribute.Int(k, v))
+		case int64:
+			kvs = append(kvs, attribute.Int64(k, v))
+		case float64:
+			kvs = append(kvs, attribute.Float64(k, v))
+		case bool:
+			kvs = append(kvs, attribute.Bool(k, v))
+		case []string:
+			kvs = append(kvs, attribute.StringSlice(k, v))
+		default:
+			kvs = append(kvs, attribute.String(k, toString(v)))
 		}
-		return ""
 	}
+	return kvs
 }
 
 // buildFeedbackAttrs builds OTEL attributes for feedback scores.
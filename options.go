@@ -1,8 +1,11 @@
 package phoenix
 
 import (
+	"context"
 	"net/http"
 	"time"
+
+	phoenixotel "github.com/agentplexus/go-phoenix/otel"
 )
 
 // Option is a functional option for configuring the Client.
@@ -10,15 +13,23 @@ type Option func(*clientOptions)
 
 // clientOptions holds the options for creating a Client.
 type clientOptions struct {
-	config     *Config
-	httpClient *http.Client
-	timeout    time.Duration
+	config      *Config
+	httpClient  *http.Client
+	timeout     time.Duration
+	retryPolicy *RetryPolicy
+	transport   *phoenixotel.TransportConfig
+
+	warmUpCtx      context.Context
+	warmUpRequired bool
+
+	maxConcurrentRequests int
 }
 
 func defaultClientOptions() *clientOptions {
 	return &clientOptions{
-		config:  LoadConfig(),
-		timeout: 60 * time.Second,
+		config:                LoadConfig(),
+		timeout:               60 * time.Second,
+		maxConcurrentRequests: DefaultMaxConcurrentRequests,
 	}
 }
 
@@ -73,12 +84,75 @@ func WithTimeout(timeout time.Duration) Option {
 	}
 }
 
+// WithHTTPTransport sets the connection pooling limits of the HTTP transport
+// used for API requests and optionally enables HTTP/2. It replaces the
+// transport of a client given with WithHTTPClient. A limit of zero means no
+// limit.
+//
+// Recommended settings:
+//   - Low traffic: the defaults are sufficient.
+//   - Moderate traffic (hundreds of requests per second): 100, 20, 0, false.
+//   - High traffic (thousands of requests per second): 500, 100, 100, true.
+//
+// To tune span export as well, pass otel.WithHTTPTransport to otel.Register.
+func WithHTTPTransport(maxIdleConns, maxIdleConnsPerHost, maxConnsPerHost int, h2 bool) Option {
+	return func(o *clientOptions) {
+		o.transport = &phoenixotel.TransportConfig{
+			MaxIdleConns:        maxIdleConns,
+			MaxIdleConnsPerHost: maxIdleConnsPerHost,
+			MaxConnsPerHost:     maxConnsPerHost,
+			HTTP2:               h2,
+		}
+	}
+}
+
+// WithMaxConcurrentRequests limits the number of API requests the client has
+// in flight at once to n; further requests wait for a free slot or for their
+// context to end. The default is DefaultMaxConcurrentRequests, and n <= 0
+// removes the limit.
+//
+// A low limit protects the Phoenix server during bulk operations such as
+// DeleteAllTracesInProject or large annotation imports, at the cost of
+// throughput: requests queue in the client instead of running in parallel.
+// Raise it for high-throughput workloads against a server that can take the
+// load. Each retry attempt takes its own slot.
+func WithMaxConcurrentRequests(n int) Option {
+	return func(o *clientOptions) {
+		o.maxConcurrentRequests = n
+	}
+}
+
+// WithWarmUp makes NewClient send a lightweight request (listing a single
+// project) using ctx, so that the connection is established before the
+// first real request. A failed warm-up is logged as a warning unless
+// WithWarmUpRequired is set.
+func WithWarmUp(ctx context.Context) Option {
+	return func(o *clientOptions) {
+		o.warmUpCtx = ctx
+	}
+}
+
+// WithWarmUpRequired makes NewClient return an error when the warm-up
+// requested with WithWarmUp fails.
+func WithWarmUpRequired(required bool) Option {
+	return func(o *clientOptions) {
+		o.warmUpRequired = required
+	}
+}
+
 // ListOption is a functional option for list operations.
 type ListOption func(*listOptions)
 
 type listOptions struct {
-	cursor string
-	limit  int
+	cursor          string
+	limit           int
+	includeArchived bool
+	versionFilter   func(*VersionSummary) bool
+	namePrefix      string
+	nameContains    string
+
+	versionCreatedBefore time.Time
+	versionCreatedAfter  time.Time
 }
 
 func defaultListOptions() *listOptions {
@@ -100,3 +174,63 @@ func WithLimit(limit int) ListOption {
 		o.limit = limit
 	}
 }
+
+// WithIncludeArchived includes archived resources in list results.
+// By default, datasets and prompts marked as archived are filtered out.
+func WithIncludeArchived(include bool) ListOption {
+	return func(o *listOptions) {
+		o.includeArchived = include
+	}
+}
+
+// WithProjectNamePrefix keeps only the projects whose name starts with
+// prefix. It applies to ListProjects and is ignored by other list
+// operations. Filtering happens on the client, after each page is fetched,
+// so a page may hold fewer projects than the limit while a next cursor is
+// still returned.
+func WithProjectNamePrefix(prefix string) ListOption {
+	return func(o *listOptions) {
+		o.namePrefix = prefix
+	}
+}
+
+// WithProjectNameContains keeps only the projects whose name contains
+// substr. Like WithProjectNamePrefix, it applies to ListProjects and
+// filters on the client.
+func WithProjectNameContains(substr string) ListOption {
+	return func(o *listOptions) {
+		o.nameContains = substr
+	}
+}
+
+// WithVersionCreatedBefore keeps only the prompt versions created before t.
+// It applies to ListPromptVersions and is ignored by other list operations.
+//
+// Phoenix does not currently report when prompt versions were created, so
+// ListPromptVersions returns ErrNotSupported when this option is set.
+func WithVersionCreatedBefore(t time.Time) ListOption {
+	return func(o *listOptions) {
+		o.versionCreatedBefore = t
+	}
+}
+
+// WithVersionCreatedAfter keeps only the prompt versions created after t.
+// See WithVersionCreatedBefore for current API limitations.
+func WithVersionCreatedAfter(t time.Time) ListOption {
+	return func(o *listOptions) {
+		o.versionCreatedAfter = t
+	}
+}
+
+// WithVersionFilter keeps only the prompt versions for which fn returns
+// true. It applies to ListPromptVersions and is ignored by other list
+// operations. Filtering happens on the client, after each page is fetched.
+//
+// Building each VersionSummary takes one extra request per version on the
+// page to fetch its tags, so prefer a small page size with WithLimit when
+// listing prompts with many versions.
+func WithVersionFilter(fn func(*VersionSummary) bool) ListOption {
+	return func(o *listOptions) {
+		o.versionFilter = fn
+	}
+}
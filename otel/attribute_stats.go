@@ -0,0 +1,50 @@
+package otel
+
+import "go.opentelemetry.io/otel/attribute"
+
+// AttributeStats summarizes the size of a set of span attributes.
+type AttributeStats struct {
+	// TotalKeys is the number of distinct attribute keys.
+	TotalKeys int
+	// TotalBytes is the combined size of the keys and values.
+	TotalBytes int
+	// LargestKey is the key with the largest value, and LargestValueBytes
+	// the size of that value.
+	LargestKey        string
+	LargestValueBytes int
+}
+
+// ComputeAttributeStats returns the size statistics of kvs. When a key is
+// repeated, its last value is used. See AttributeValueSize for how values
+// are measured.
+func ComputeAttributeStats(kvs []attribute.KeyValue) *AttributeStats {
+	stats := &AttributeStats{}
+	for k, v := range attributeMap(kvs) {
+		size := AttributeValueSize(v)
+		stats.TotalKeys++
+		stats.TotalBytes += len(k) + size
+		if size > stats.LargestValueBytes || (size == stats.LargestValueBytes && k < stats.LargestKey) {
+			stats.LargestKey = k
+			stats.LargestValueBytes = size
+		}
+	}
+	return stats
+}
+
+// AttributeValueSize returns the size of v in bytes: the length of a string,
+// the combined length of the elements of a string slice, and the length of
+// the value's text form for other types.
+func AttributeValueSize(v attribute.Value) int {
+	switch v.Type() {
+	case attribute.STRING:
+		return len(v.AsString())
+	case attribute.STRINGSLICE:
+		n := 0
+		for _, s := range v.AsStringSlice() {
+			n += len(s)
+		}
+		return n
+	default:
+		return len(v.Emit())
+	}
+}
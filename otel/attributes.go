@@ -1,6 +1,11 @@
 package otel
 
-import "go.opentelemetry.io/otel/attribute"
+import (
+	"encoding/json"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+)
 
 // OpenInference semantic conventions for LLM observability.
 // These attributes are compatible with Phoenix and the OpenInference specification.
@@ -44,6 +49,14 @@ const (
 	LLMTokenCountPrompt     = "llm.token_count.prompt"     //nolint:gosec // Not a credential
 	LLMTokenCountCompletion = "llm.token_count.completion" //nolint:gosec // Not a credential
 	LLMTokenCountTotal      = "llm.token_count.total"      //nolint:gosec // Not a credential
+	LLMCostUSD              = "llm.cost_usd"
+	LLMLatencyTTFBMs        = "llm.latency.ttfb_ms"
+	LLMLatencyTotalMs       = "llm.latency.total_ms"
+
+	// Prompt attributes, identifying the Phoenix prompt a span rendered
+	LLMPromptName    = "llm.prompt.name"
+	LLMPromptVersion = "llm.prompt.version_id"
+	LLMPromptTag     = "llm.prompt.tag"
 
 	// Message attributes
 	LLMInputMessages  = "llm.input_messages"
@@ -57,6 +70,19 @@ const (
 	// Retrieval attributes
 	RetrievalDocuments = "retrieval.documents"
 
+	// Agent step event attributes
+	AgentThought       = "agent.thought"
+	AgentAction        = "agent.action"
+	AgentObservation   = "agent.observation"
+	AgentPlan          = "agent.plan"
+	AgentPlanStepIndex = "agent.plan.step_index"
+
+	// Guardrail attributes
+	GuardrailPassed         = "guardrail.passed"
+	GuardrailViolationTypes = "guardrail.violation_types"
+	GuardrailScore          = "guardrail.score"
+	GuardrailExplanation    = "guardrail.explanation"
+
 	// Embedding attributes
 	EmbeddingModelName  = "embedding.model_name"
 	EmbeddingEmbeddings = "embedding.embeddings"
@@ -69,6 +95,14 @@ const (
 	// Session/Thread attributes
 	SessionID = "session.id"
 	UserID    = "user.id"
+
+	// HTTP attributes, following the OTEL HTTP semantic conventions
+	HTTPMethod                = "http.method"
+	HTTPURL                   = "http.url"
+	HTTPScheme                = "http.scheme"
+	HTTPHost                  = "http.host"
+	HTTPStatusCode            = "http.status_code"
+	HTTPResponseContentLength = "http.response_content_length"
 )
 
 // Attribute helper functions for common LLM attributes.
@@ -78,6 +112,19 @@ func WithSpanKind(kind string) attribute.KeyValue {
 	return attribute.String(OpenInferenceSpanKind, kind)
 }
 
+// WithPromptReference identifies the Phoenix prompt used by a span. Empty
+// versionID or tag values are omitted.
+func WithPromptReference(name, versionID, tag string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{attribute.String(LLMPromptName, name)}
+	if versionID != "" {
+		attrs = append(attrs, attribute.String(LLMPromptVersion, versionID))
+	}
+	if tag != "" {
+		attrs = append(attrs, attribute.String(LLMPromptTag, tag))
+	}
+	return attrs
+}
+
 // WithInput sets the input value attribute.
 func WithInput(input string) attribute.KeyValue {
 	return attribute.String(InputValue, input)
@@ -127,6 +174,65 @@ func WithMetadata(metadata string) attribute.KeyValue {
 	return attribute.String(MetadataKey, metadata)
 }
 
+// RetrievalDocument is a document returned by a retrieval step.
+type RetrievalDocument struct {
+	Content    string
+	DocumentID string
+	Score      float64
+	Metadata   map[string]any
+}
+
+// WithRetrievalDocuments sets the retrieval.documents attributes for docs,
+// in rank order.
+func WithRetrievalDocuments(docs []RetrievalDocument) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	for i, doc := range docs {
+		attrs = append(attrs, WithRetrievalDocument(i, doc)...)
+	}
+	return attrs
+}
+
+// WithRetrievalDocument sets the retrieval.documents attributes for the
+// document at index i. Metadata is encoded as a JSON string.
+func WithRetrievalDocument(i int, doc RetrievalDocument) []attribute.KeyValue {
+	prefix := RetrievalDocuments + "." + strconv.Itoa(i) + ".document."
+	attrs := []attribute.KeyValue{
+		attribute.String(prefix+"content", doc.Content),
+		attribute.Float64(prefix+"score", doc.Score),
+	}
+	if doc.DocumentID != "" {
+		attrs = append(attrs, attribute.String(prefix+"id", doc.DocumentID))
+	}
+	if len(doc.Metadata) > 0 {
+		if data, err := json.Marshal(doc.Metadata); err == nil {
+			attrs = append(attrs, attribute.String(prefix+"metadata", string(data)))
+		}
+	}
+	return attrs
+}
+
+// GuardrailResult is the outcome of a guardrail check.
+type GuardrailResult struct {
+	Passed         bool
+	ViolationTypes []string
+	Score          float64
+	Explanation    string
+}
+
+// WithGuardrailResult sets the guardrail.* attributes for r. An empty
+// explanation is omitted.
+func WithGuardrailResult(r GuardrailResult) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.Bool(GuardrailPassed, r.Passed),
+		attribute.StringSlice(GuardrailViolationTypes, r.ViolationTypes),
+		attribute.Float64(GuardrailScore, r.Score),
+	}
+	if r.Explanation != "" {
+		attrs = append(attrs, attribute.String(GuardrailExplanation, r.Explanation))
+	}
+	return attrs
+}
+
 // LLMSpanAttributes returns common attributes for an LLM span.
 func LLMSpanAttributes(model, provider string, promptTokens, completionTokens int) []attribute.KeyValue {
 	return []attribute.KeyValue{
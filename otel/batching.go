@@ -0,0 +1,220 @@
+package otel
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Limits used when sizing batches. They follow the OpenTelemetry batch span
+// processor defaults: a 5s schedule delay and a 2048-span queue, which also
+// caps the batch size.
+const (
+	maxBatchTimeout = 5 * time.Second
+	minBatchTimeout = 500 * time.Millisecond
+	minBatchSize    = 32
+	maxBatchSize    = 2048
+)
+
+// Adaptive batching parameters.
+const (
+	adaptiveInterval   = 30 * time.Second
+	adaptiveStep       = 0.10
+	adaptiveQueueSize  = maxBatchSize
+	adaptiveExportWait = 30 * time.Second
+)
+
+// BatchConfigForThroughput recommends a batch size and timeout for an
+// expected span rate. Following the OpenTelemetry batch processor defaults,
+// it sizes a batch to collect the spans produced within the 5s schedule
+// delay, bounded by the 2048-span queue, then shortens the timeout so a
+// full batch is exported as soon as it fills, but not below 500ms.
+func BatchConfigForThroughput(spansPerSecond int) (batchSize int, timeout time.Duration) {
+	if spansPerSecond <= 0 {
+		return minBatchSize, maxBatchTimeout
+	}
+
+	batchSize = spansPerSecond * int(maxBatchTimeout/time.Second)
+	batchSize = min(max(batchSize, minBatchSize), maxBatchSize)
+
+	timeout = time.Duration(float64(batchSize) / float64(spansPerSecond) * float64(time.Second))
+	timeout = min(max(timeout, minBatchTimeout), maxBatchTimeout)
+	return batchSize, timeout
+}
+
+// adaptiveBatchProcessor exports spans in batches whose size follows the
+// observed export latency. Every 30 seconds the mean latency of the
+// preceding exports is compared with the batch timeout: when exports take
+// less than a tenth of it the batch size grows by 10%, and when they take
+// more than half of it the batch size shrinks by 10%.
+type adaptiveBatchProcessor struct {
+	exporter sdktrace.SpanExporter
+	timeout  time.Duration
+
+	queue    chan sdktrace.ReadOnlySpan
+	flushReq chan chan error
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+	stopped  atomic.Bool
+
+	batchSize atomic.Int64
+
+	// Export latency since the last adjustment; only used by run.
+	latencySum time.Duration
+	exports    int
+}
+
+func newAdaptiveBatchProcessor(exporter sdktrace.SpanExporter, batchSize int, timeout time.Duration) *adaptiveBatchProcessor {
+	if timeout <= 0 {
+		timeout = maxBatchTimeout
+	}
+	p := &adaptiveBatchProcessor{
+		exporter: exporter,
+		timeout:  timeout,
+		queue:    make(chan sdktrace.ReadOnlySpan, adaptiveQueueSize),
+		flushReq: make(chan chan error),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	p.batchSize.Store(int64(min(max(batchSize, minBatchSize), maxBatchSize)))
+	go p.run()
+	return p
+}
+
+func (p *adaptiveBatchProcessor) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+// OnEnd queues a sampled span for export. Spans are dropped when the queue
+// is full or the processor has been shut down.
+func (p *adaptiveBatchProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if p.stopped.Load() || !s.SpanContext().IsSampled() {
+		return
+	}
+	select {
+	case p.queue <- s:
+	default:
+	}
+}
+
+// ForceFlush exports all queued spans.
+func (p *adaptiveBatchProcessor) ForceFlush(ctx context.Context) error {
+	result := make(chan error, 1)
+	select {
+	case p.flushReq <- result:
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Shutdown exports all queued spans and shuts down the exporter.
+func (p *adaptiveBatchProcessor) Shutdown(ctx context.Context) error {
+	p.stopOnce.Do(func() {
+		p.stopped.Store(true)
+		close(p.stop)
+	})
+	select {
+	case <-p.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return p.exporter.Shutdown(ctx)
+}
+
+func (p *adaptiveBatchProcessor) run() {
+	defer close(p.done)
+
+	timer := time.NewTimer(p.timeout)
+	defer timer.Stop()
+	ticker := time.NewTicker(adaptiveInterval)
+	defer ticker.Stop()
+
+	var batch []sdktrace.ReadOnlySpan
+	for {
+		select {
+		case s := <-p.queue:
+			batch = append(batch, s)
+			if len(batch) >= int(p.batchSize.Load()) {
+				_ = p.export(batch)
+				batch = nil
+				timer.Reset(p.timeout)
+			}
+		case <-timer.C:
+			_ = p.export(batch)
+			batch = nil
+			timer.Reset(p.timeout)
+		case result := <-p.flushReq:
+			result <- p.export(p.drain(batch))
+			batch = nil
+		case <-ticker.C:
+			p.adjust()
+		case <-p.stop:
+			_ = p.export(p.drain(batch))
+			return
+		}
+	}
+}
+
+// drain appends every span currently queued to batch.
+func (p *adaptiveBatchProcessor) drain(batch []sdktrace.ReadOnlySpan) []sdktrace.ReadOnlySpan {
+	for {
+		select {
+		case s := <-p.queue:
+			batch = append(batch, s)
+		default:
+			return batch
+		}
+	}
+}
+
+// export sends spans to the exporter in batches of the current size.
+func (p *adaptiveBatchProcessor) export(spans []sdktrace.ReadOnlySpan) error {
+	var firstErr error
+	for len(spans) > 0 {
+		n := min(len(spans), int(p.batchSize.Load()))
+
+		ctx, cancel := context.WithTimeout(context.Background(), adaptiveExportWait)
+		start := time.Now()
+		err := p.exporter.ExportSpans(ctx, spans[:n])
+		p.latencySum += time.Since(start)
+		p.exports++
+		cancel()
+
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		spans = spans[n:]
+	}
+	return firstErr
+}
+
+// adjust resizes the batch according to the mean export latency since the
+// last adjustment.
+func (p *adaptiveBatchProcessor) adjust() {
+	if p.exports == 0 {
+		return
+	}
+	mean := p.latencySum / time.Duration(p.exports)
+	p.latencySum, p.exports = 0, 0
+
+	size := float64(p.batchSize.Load())
+	switch {
+	case mean < p.timeout/10:
+		size *= 1 + adaptiveStep
+	case mean > p.timeout/2:
+		size *= 1 - adaptiveStep
+	default:
+		return
+	}
+	p.batchSize.Store(int64(min(max(int(size), minBatchSize), maxBatchSize)))
+}
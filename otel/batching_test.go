@@ -0,0 +1,177 @@
+package otel
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// batchRecorder is an in-memory exporter that also records the size of each
+// exported batch. Unlike the in-memory exporter, it keeps its spans on
+// shutdown.
+type batchRecorder struct {
+	*tracetest.InMemoryExporter
+
+	mu       sync.Mutex
+	batches  []int
+	shutdown bool
+}
+
+func newBatchRecorder() *batchRecorder {
+	return &batchRecorder{InMemoryExporter: tracetest.NewInMemoryExporter()}
+}
+
+func (e *batchRecorder) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	e.batches = append(e.batches, len(spans))
+	e.mu.Unlock()
+	return e.InMemoryExporter.ExportSpans(ctx, spans)
+}
+
+func (e *batchRecorder) Shutdown(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.shutdown = true
+	return nil
+}
+
+func (e *batchRecorder) batchSizes() []int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]int(nil), e.batches...)
+}
+
+// endSpans starts and ends n spans with tp.
+func endSpans(tp *sdktrace.TracerProvider, n int) {
+	tracer := tp.Tracer("test")
+	for range n {
+		_, span := tracer.Start(context.Background(), "span")
+		span.End()
+	}
+}
+
+func TestAdaptiveBatchProcessorShutdown(t *testing.T) {
+	exporter := newBatchRecorder()
+	p := newAdaptiveBatchProcessor(exporter, minBatchSize, time.Hour)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(p))
+
+	endSpans(tp, 5)
+	if err := tp.Shutdown(t.Context()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if n := len(exporter.GetSpans()); n != 5 {
+		t.Errorf("exported %d spans on shutdown, want 5", n)
+	}
+	if !exporter.shutdown {
+		t.Error("exporter not shut down")
+	}
+
+	// Spans ending after shutdown are dropped.
+	p.OnEnd(exporter.GetSpans()[0].Snapshot())
+	if err := p.ForceFlush(t.Context()); err != nil {
+		t.Errorf("ForceFlush after shutdown: %v", err)
+	}
+	if n := len(exporter.GetSpans()); n != 5 {
+		t.Errorf("exported %d spans after shutdown, want 5", n)
+	}
+}
+
+func TestAdaptiveBatchProcessorBatches(t *testing.T) {
+	exporter := newBatchRecorder()
+	p := newAdaptiveBatchProcessor(exporter, minBatchSize, time.Hour)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(p))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	// A full batch is exported without waiting for the timeout.
+	endSpans(tp, minBatchSize)
+	deadline := time.Now().Add(5 * time.Second)
+	for len(exporter.batchSizes()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := exporter.batchSizes(); len(got) != 1 || got[0] != minBatchSize {
+		t.Fatalf("batches = %v, want one full batch of %d", got, minBatchSize)
+	}
+
+	// ForceFlush exports the rest in batches of at most the batch size.
+	endSpans(tp, 2*minBatchSize+6)
+	if err := tp.ForceFlush(t.Context()); err != nil {
+		t.Fatalf("ForceFlush: %v", err)
+	}
+	total := 0
+	for _, n := range exporter.batchSizes() {
+		if n > minBatchSize {
+			t.Errorf("exported batch of %d spans, want at most %d", n, minBatchSize)
+		}
+		total += n
+	}
+	if total != 3*minBatchSize+6 {
+		t.Errorf("exported %d spans, want %d", total, 3*minBatchSize+6)
+	}
+}
+
+func TestAdaptiveBatchProcessorTimeout(t *testing.T) {
+	exporter := newBatchRecorder()
+	p := newAdaptiveBatchProcessor(exporter, maxBatchSize, 20*time.Millisecond)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(p))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	endSpans(tp, 3)
+	deadline := time.Now().Add(5 * time.Second)
+	for len(exporter.GetSpans()) < 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := len(exporter.GetSpans()); n != 3 {
+		t.Errorf("exported %d spans after the timeout, want 3", n)
+	}
+}
+
+func TestAdaptiveBatchProcessorAdjust(t *testing.T) {
+	const timeout = time.Second
+	tests := []struct {
+		name    string
+		size    int
+		latency time.Duration
+		exports int
+		want    int
+	}{
+		{"fast exports grow", 100, 50 * time.Millisecond, 2, 110},
+		{"slow exports shrink", 100, 600 * time.Millisecond, 2, 90},
+		{"moderate exports keep", 100, 300 * time.Millisecond, 2, 100},
+		{"no exports keep", 100, 0, 0, 100},
+		{"growth capped", maxBatchSize, time.Millisecond, 1, maxBatchSize},
+		{"shrinking floored", minBatchSize, time.Second, 1, minBatchSize},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &adaptiveBatchProcessor{timeout: timeout}
+			p.batchSize.Store(int64(tt.size))
+			p.latencySum = tt.latency * time.Duration(tt.exports)
+			p.exports = tt.exports
+
+			p.adjust()
+			if got := int(p.batchSize.Load()); got != tt.want {
+				t.Errorf("batch size = %d, want %d", got, tt.want)
+			}
+			if p.exports != 0 || p.latencySum != 0 {
+				t.Errorf("latency not reset: %v over %d exports", p.latencySum, p.exports)
+			}
+		})
+	}
+}
+
+func TestNewAdaptiveBatchProcessorBounds(t *testing.T) {
+	for _, tt := range []struct{ size, want int }{{0, minBatchSize}, {100, 100}, {1 << 20, maxBatchSize}} {
+		p := newAdaptiveBatchProcessor(newBatchRecorder(), tt.size, 0)
+		if got := int(p.batchSize.Load()); got != tt.want {
+			t.Errorf("batch size for %d = %d, want %d", tt.size, got, tt.want)
+		}
+		if p.timeout != maxBatchTimeout {
+			t.Errorf("timeout = %v, want %v", p.timeout, maxBatchTimeout)
+		}
+		_ = p.Shutdown(context.Background())
+	}
+}
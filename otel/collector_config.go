@@ -0,0 +1,93 @@
+package otel
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// collectorAPIKeyHeader is the Authorization value written to generated
+// collector configs. The collector expands it from its own environment so
+// that the API key is not stored in the config file.
+const collectorAPIKeyHeader = "Bearer ${env:" + EnvAPIKey + "}"
+
+// GenerateCollectorConfig returns an OpenTelemetry Collector configuration
+// snippet defining a Phoenix exporter equivalent to cfg, for forwarding
+// spans from a collector instead of exporting them from the SDK.
+//
+// With Protocol set to ProtocolGRPC, or inferred as gRPC from the endpoint
+// port, the snippet defines an otlp/phoenix (gRPC) exporter; otherwise it
+// defines an otlphttp/phoenix exporter. TLS is disabled for http://
+// endpoints and, for gRPC, when cfg.Insecure is set. When cfg has an API
+// key, the Authorization header refers to the PHOENIX_API_KEY environment
+// variable of the collector rather than embedding the key.
+//
+// The snippet contains only the exporters section; add the exporter to a
+// traces pipeline to use it. Unix socket endpoints are not supported.
+func GenerateCollectorConfig(cfg *Config) (string, error) {
+	if cfg == nil {
+		cfg = DefaultConfig()
+	}
+	endpoint := cfg.EffectiveEndpoint()
+	if strings.HasPrefix(endpoint, unixEndpointPrefix) {
+		return "", fmt.Errorf("otel: collector config: unix socket endpoints are not supported")
+	}
+	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+		endpoint = "http://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("otel: collector config: invalid endpoint URL: %w", err)
+	}
+
+	protocol := cfg.Protocol
+	if protocol == "" || protocol == ProtocolInfer {
+		protocol = inferProtocol(u)
+	}
+	insecure := u.Scheme == "http" || (protocol == ProtocolGRPC && cfg.Insecure)
+
+	name := "otlphttp/phoenix"
+	exporterEndpoint := strings.TrimSuffix(u.String(), "/")
+	if protocol == ProtocolGRPC {
+		name = "otlp/phoenix"
+		port := u.Port()
+		if port == "" {
+			port = strconv.Itoa(DefaultGRPCPort)
+		}
+		exporterEndpoint = net.JoinHostPort(u.Hostname(), port)
+	}
+
+	headers := make(map[string]string, len(cfg.Headers)+2)
+	for k, v := range cfg.Headers {
+		headers[k] = v
+	}
+	if cfg.APIKey != "" {
+		headers["authorization"] = collectorAPIKeyHeader
+	}
+	if cfg.ProjectName != "" {
+		headers[projectNameHeader] = cfg.ProjectName
+	}
+
+	var b strings.Builder
+	b.WriteString("exporters:\n")
+	fmt.Fprintf(&b, "  %s:\n", name)
+	fmt.Fprintf(&b, "    endpoint: %s\n", strconv.Quote(exporterEndpoint))
+	if len(headers) > 0 {
+		b.WriteString("    headers:\n")
+		keys := make([]string, 0, len(headers))
+		for k := range headers {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			fmt.Fprintf(&b, "      %s: %s\n", strconv.Quote(k), strconv.Quote(headers[k]))
+		}
+	}
+	b.WriteString("    tls:\n")
+	fmt.Fprintf(&b, "      insecure: %t\n", insecure)
+
+	return b.String(), nil
+}
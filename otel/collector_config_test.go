@@ -0,0 +1,77 @@
+package otel
+
+import "testing"
+
+func TestGenerateCollectorConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+		want string
+	}{
+		{
+			name: "http",
+			cfg: &Config{
+				Endpoint:    "https://app.phoenix.arize.com/",
+				SpaceID:     "team",
+				APIKey:      "secret",
+				ProjectName: "billing",
+				Headers:     map[string]string{"x-env": "prod"},
+			},
+			want: "exporters:\n" +
+				"  otlphttp/phoenix:\n" +
+				"    endpoint: \"https://app.phoenix.arize.com/s/team\"\n" +
+				"    headers:\n" +
+				"      \"authorization\": \"Bearer ${env:PHOENIX_API_KEY}\"\n" +
+				"      \"x-env\": \"prod\"\n" +
+				"      \"x-phoenix-project-name\": \"billing\"\n" +
+				"    tls:\n" +
+				"      insecure: false\n",
+		},
+		{
+			name: "plain http",
+			cfg:  &Config{Endpoint: "localhost:6006"},
+			want: "exporters:\n" +
+				"  otlphttp/phoenix:\n" +
+				"    endpoint: \"http://localhost:6006\"\n" +
+				"    tls:\n" +
+				"      insecure: true\n",
+		},
+		{
+			name: "inferred grpc",
+			cfg:  &Config{Endpoint: "https://collector.example.com:4317", Insecure: true},
+			want: "exporters:\n" +
+				"  otlp/phoenix:\n" +
+				"    endpoint: \"collector.example.com:4317\"\n" +
+				"    tls:\n" +
+				"      insecure: true\n",
+		},
+		{
+			name: "explicit grpc",
+			cfg:  &Config{Endpoint: "https://collector.example.com", Protocol: ProtocolGRPC},
+			want: "exporters:\n" +
+				"  otlp/phoenix:\n" +
+				"    endpoint: \"collector.example.com:4317\"\n" +
+				"    tls:\n" +
+				"      insecure: false\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GenerateCollectorConfig(tt.cfg)
+			if err != nil {
+				t.Fatalf("GenerateCollectorConfig: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GenerateCollectorConfig =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateCollectorConfigErrors(t *testing.T) {
+	for _, endpoint := range []string{"unix:/tmp/phoenix.sock", "http://[::1"} {
+		if _, err := GenerateCollectorConfig(&Config{Endpoint: endpoint}); err == nil {
+			t.Errorf("GenerateCollectorConfig(%q) = nil error", endpoint)
+		}
+	}
+}
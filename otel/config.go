@@ -2,7 +2,10 @@ package otel
 
 import (
 	"os"
+	"strings"
 	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 // Environment variable names matching Phoenix Python SDK.
@@ -26,6 +29,9 @@ const (
 	DefaultHTTPPath    = "/v1/traces"
 )
 
+// unixEndpointPrefix marks an endpoint as a Unix domain socket path.
+const unixEndpointPrefix = "unix:"
+
 // Config holds the configuration for Phoenix OTEL integration.
 type Config struct {
 	// Endpoint is the Phoenix collector endpoint.
@@ -45,6 +51,10 @@ type Config struct {
 	// Headers are additional headers to send with requests.
 	Headers map[string]string
 
+	// ProjectHeaders maps project names to headers that replace the
+	// request headers of exports for that project. See WithProjectHeaders.
+	ProjectHeaders map[string]map[string]string
+
 	// Protocol specifies the transport protocol (http or grpc).
 	Protocol Protocol
 
@@ -57,6 +67,10 @@ type Config struct {
 	// BatchSize is the maximum number of spans to batch.
 	BatchSize int
 
+	// AdaptiveBatching adjusts BatchSize to the observed export latency.
+	// See WithAdaptiveBatching.
+	AdaptiveBatching bool
+
 	// SetGlobalProvider sets the tracer provider as global.
 	SetGlobalProvider bool
 
@@ -68,6 +82,37 @@ type Config struct {
 
 	// Insecure disables TLS for gRPC connections.
 	Insecure bool
+
+	// SchemaVersion enables OpenInference schema validation of ended spans
+	// when set. See WithSchemaValidation.
+	SchemaVersion string
+
+	// Transport configures connection pooling for the OTLP exporter.
+	// When nil, the exporter's default transport is used.
+	Transport *TransportConfig
+
+	// IDGenerator generates trace and span IDs. When nil, IDs are random.
+	IDGenerator sdktrace.IDGenerator
+
+	// Sampler decides which spans are recorded and exported. When nil,
+	// every span is sampled. See NewErrorSampler and NewCostSampler.
+	Sampler sdktrace.Sampler
+
+	// AttributeNamespace, when set, prefixes custom span attributes.
+	// See WithAttributeNamespace.
+	AttributeNamespace string
+
+	// AttributeNamespaceExclude lists key prefixes exempt from
+	// AttributeNamespace.
+	AttributeNamespaceExclude []string
+
+	// QueueDepthExceededHandler is called when the batch export queue
+	// rises above 80% of its capacity. See QueueDepthExceededHandler.
+	QueueDepthExceededHandler func(depth, max int)
+
+	// AttributeEncoding selects how input, output and metadata values are
+	// encoded. See WithAttributeEncoding.
+	AttributeEncoding AttributeEncoding
 }
 
 // Protocol specifies the OTLP transport protocol.
@@ -95,6 +140,7 @@ func DefaultConfig() *Config {
 		BatchSize:         512,
 		SetGlobalProvider: true,
 		Insecure:          false,
+		AttributeEncoding: EncodingJSON,
 	}
 
 	// Load from environment
@@ -124,7 +170,7 @@ func DefaultConfig() *Config {
 // EffectiveEndpoint returns the full endpoint with space ID if configured.
 func (c *Config) EffectiveEndpoint() string {
 	endpoint := c.Endpoint
-	if c.SpaceID != "" {
+	if c.SpaceID != "" && !strings.HasPrefix(endpoint, unixEndpointPrefix) {
 		// Remove trailing slash if present
 		for len(endpoint) > 0 && endpoint[len(endpoint)-1] == '/' {
 			endpoint = endpoint[:len(endpoint)-1]
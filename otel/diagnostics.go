@@ -0,0 +1,87 @@
+package otel
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// OtelDiagnosticInfo is a snapshot of a TracerProvider's export pipeline.
+type OtelDiagnosticInfo struct {
+	// ExporterType identifies the span exporter, e.g. "otlp-http".
+	ExporterType string
+
+	// Endpoint is the effective collector endpoint.
+	Endpoint string
+
+	// Batch reports whether spans are exported in batches.
+	Batch bool
+
+	// BatchSize is the maximum number of spans per export batch.
+	BatchSize int
+
+	// QueueDepth is the number of spans in the batch export queue, as
+	// reported by QueueStats. It is zero when spans are exported
+	// synchronously.
+	QueueDepth int64
+
+	// ExportedSpans is the number of spans handed to the exporter.
+	ExportedSpans int64
+
+	// ExportErrors is the number of export calls that failed.
+	ExportErrors int64
+
+	// LastExportLatency is how long the most recent export call took.
+	LastExportLatency time.Duration
+}
+
+// DiagnosticDump returns a snapshot of the tracer provider's exporter
+// configuration and queue state.
+func (tp *TracerProvider) DiagnosticDump() *OtelDiagnosticInfo {
+	info := &OtelDiagnosticInfo{
+		ExporterType: exporterTypeOTLPHTTP,
+		Endpoint:     tp.config.EffectiveEndpoint(),
+		Batch:        tp.config.Batch,
+	}
+	if tp.config.Batch {
+		info.BatchSize = tp.config.BatchSize
+	}
+	info.QueueDepth = int64(tp.QueueStats().CurrentDepth)
+	if tp.stats != nil {
+		info.ExportedSpans = tp.stats.exported.Load()
+		info.ExportErrors = tp.stats.exportErrors.Load()
+		info.LastExportLatency = time.Duration(tp.stats.lastExportNanos.Load())
+	}
+	return info
+}
+
+// exporterTypeOTLPHTTP names the exporter built by createExporter, which
+// currently only supports HTTP/protobuf.
+const exporterTypeOTLPHTTP = "otlp-http"
+
+// pipelineStats counts the spans leaving the export pipeline.
+type pipelineStats struct {
+	exported        atomic.Int64
+	exportErrors    atomic.Int64
+	lastExportNanos atomic.Int64
+}
+
+// countingExporter counts the spans passed to the wrapped exporter and
+// records the outcome and latency of each export.
+type countingExporter struct {
+	sdktrace.SpanExporter
+	stats *pipelineStats
+}
+
+func (e *countingExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.stats.exported.Add(int64(len(spans)))
+	start := time.Now()
+	err := e.SpanExporter.ExportSpans(ctx, spans)
+	e.stats.lastExportNanos.Store(int64(time.Since(start)))
+	if err != nil {
+		e.stats.exportErrors.Add(1)
+	}
+	return err
+}
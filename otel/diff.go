@@ -0,0 +1,114 @@
+package otel
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ANSI escape codes used by AttributeDiff.String.
+const (
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+	ansiReset  = "\x1b[0m"
+)
+
+// AttributeChange is an attribute whose value differs between two spans.
+type AttributeChange struct {
+	From attribute.Value
+	To   attribute.Value
+}
+
+// AttributeDiff describes how the attributes of span B differ from those
+// of span A. See DiffSpanAttributes.
+type AttributeDiff struct {
+	// Added holds the attributes of B that A does not have.
+	Added map[string]attribute.Value
+	// Removed holds the attributes of A that B does not have.
+	Removed map[string]attribute.Value
+	// Changed holds the attributes of both with different values.
+	Changed map[string]AttributeChange
+}
+
+// DiffSpanAttributes compares two attribute sets, such as the attributes of
+// the same span in two runs. When a key is repeated, its last value is used.
+func DiffSpanAttributes(a, b []attribute.KeyValue) *AttributeDiff {
+	from, to := attributeMap(a), attributeMap(b)
+
+	d := &AttributeDiff{
+		Added:   make(map[string]attribute.Value),
+		Removed: make(map[string]attribute.Value),
+		Changed: make(map[string]AttributeChange),
+	}
+	for k, v := range from {
+		w, ok := to[k]
+		switch {
+		case !ok:
+			d.Removed[k] = v
+		case v.Type() != w.Type() || v.Emit() != w.Emit():
+			d.Changed[k] = AttributeChange{From: v, To: w}
+		}
+	}
+	for k, w := range to {
+		if _, ok := from[k]; !ok {
+			d.Added[k] = w
+		}
+	}
+	return d
+}
+
+func attributeMap(kvs []attribute.KeyValue) map[string]attribute.Value {
+	m := make(map[string]attribute.Value, len(kvs))
+	for _, kv := range kvs {
+		m[string(kv.Key)] = kv.Value
+	}
+	return m
+}
+
+// Empty reports whether the attribute sets are the same.
+func (d *AttributeDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// String renders the diff for a terminal, one attribute per line sorted by
+// key: removed attributes in red prefixed with "-", added attributes in
+// green prefixed with "+", and changed attributes in yellow prefixed with
+// "~". Use Text for output without colors.
+func (d *AttributeDiff) String() string {
+	return d.render(true)
+}
+
+// Text renders the diff like String, without colors.
+func (d *AttributeDiff) Text() string {
+	return d.render(false)
+}
+
+func (d *AttributeDiff) render(color bool) string {
+	type line struct {
+		key, text, color string
+	}
+	lines := make([]line, 0, len(d.Added)+len(d.Removed)+len(d.Changed))
+	for k, v := range d.Removed {
+		lines = append(lines, line{k, fmt.Sprintf("- %s: %s", k, v.Emit()), ansiRed})
+	}
+	for k, v := range d.Added {
+		lines = append(lines, line{k, fmt.Sprintf("+ %s: %s", k, v.Emit()), ansiGreen})
+	}
+	for k, c := range d.Changed {
+		lines = append(lines, line{k, fmt.Sprintf("~ %s: %s -> %s", k, c.From.Emit(), c.To.Emit()), ansiYellow})
+	}
+	sort.Slice(lines, func(i, j int) bool { return lines[i].key < lines[j].key })
+
+	var sb strings.Builder
+	for _, l := range lines {
+		if color {
+			sb.WriteString(l.color + l.text + ansiReset + "\n")
+		} else {
+			sb.WriteString(l.text + "\n")
+		}
+	}
+	return sb.String()
+}
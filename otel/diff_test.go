@@ -0,0 +1,75 @@
+package otel
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestDiffSpanAttributes(t *testing.T) {
+	a := []attribute.KeyValue{
+		attribute.String("model", "gpt-4o"),
+		attribute.Int("count", 1),
+		attribute.String("removed", "x"),
+		attribute.Bool("same", true),
+		attribute.String("repeated", "first"),
+		attribute.String("repeated", "last"),
+	}
+	b := []attribute.KeyValue{
+		attribute.String("model", "gpt-4o-mini"),
+		attribute.String("count", "1"),
+		attribute.Float64("added", 0.5),
+		attribute.Bool("same", true),
+		attribute.String("repeated", "last"),
+	}
+
+	d := DiffSpanAttributes(a, b)
+	if d.Empty() {
+		t.Fatal("Empty = true, want false")
+	}
+	if len(d.Changed) != 2 {
+		t.Errorf("Changed = %v, want model and count", d.Changed)
+	}
+	if c := d.Changed["model"]; c.From.AsString() != "gpt-4o" || c.To.AsString() != "gpt-4o-mini" {
+		t.Errorf("Changed[model] = %v", c)
+	}
+	// Values of different types differ even if they render the same.
+	if c, ok := d.Changed["count"]; !ok || c.From.Type() != attribute.INT64 || c.To.Type() != attribute.STRING {
+		t.Errorf("Changed[count] = %v, want INT64 -> STRING", c)
+	}
+	if v, ok := d.Removed["removed"]; !ok || v.AsString() != "x" || len(d.Removed) != 1 {
+		t.Errorf("Removed = %v, want removed", d.Removed)
+	}
+	if v, ok := d.Added["added"]; !ok || v.AsFloat64() != 0.5 || len(d.Added) != 1 {
+		t.Errorf("Added = %v, want added", d.Added)
+	}
+
+	wantText := "+ added: 0.5\n" +
+		"~ count: 1 -> 1\n" +
+		"~ model: gpt-4o -> gpt-4o-mini\n" +
+		"- removed: x\n"
+	if got := d.Text(); got != wantText {
+		t.Errorf("Text = %q, want %q", got, wantText)
+	}
+	wantString := ansiGreen + "+ added: 0.5" + ansiReset + "\n" +
+		ansiYellow + "~ count: 1 -> 1" + ansiReset + "\n" +
+		ansiYellow + "~ model: gpt-4o -> gpt-4o-mini" + ansiReset + "\n" +
+		ansiRed + "- removed: x" + ansiReset + "\n"
+	if got := d.String(); got != wantString {
+		t.Errorf("String = %q, want %q", got, wantString)
+	}
+}
+
+func TestDiffSpanAttributesEmpty(t *testing.T) {
+	kvs := []attribute.KeyValue{attribute.String("k", "v"), attribute.Int("n", 1)}
+	d := DiffSpanAttributes(kvs, []attribute.KeyValue{attribute.Int("n", 1), attribute.String("k", "v")})
+	if !d.Empty() {
+		t.Errorf("Empty = false for reordered attributes: %v", d)
+	}
+	if got := d.Text(); got != "" {
+		t.Errorf("Text = %q, want empty", got)
+	}
+	if !DiffSpanAttributes(nil, nil).Empty() {
+		t.Error("Empty = false for nil attribute sets")
+	}
+}
@@ -0,0 +1,76 @@
+package otel
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// AttributeEncoding selects how non-string values are encoded in the
+// input.value, output.value and metadata attributes.
+type AttributeEncoding string
+
+const (
+	// EncodingJSON JSON-encodes non-string values and, besides the JSON
+	// metadata attribute, flattens metadata into typed metadata.*
+	// attributes. This is the default.
+	EncodingJSON AttributeEncoding = "json"
+
+	// EncodingString formats every value with fmt.Sprint, for older
+	// Phoenix versions that expect raw strings.
+	EncodingString AttributeEncoding = "string"
+
+	// EncodingAuto selects EncodingJSON or EncodingString from the Phoenix
+	// server version. Register cannot query the server, so callers holding
+	// a REST client resolve it with APICompatibilityReport.AttributeEncoding;
+	// until then it behaves like EncodingJSON.
+	EncodingAuto AttributeEncoding = "auto"
+)
+
+// EncodeValue encodes v as an attribute string. Strings and byte slices are
+// used as is and nil becomes "". Other values are JSON-encoded, or formatted
+// with fmt.Sprintf("%+v") if they cannot be, such as values holding
+// channels or cycles.
+func (e AttributeEncoding) EncodeValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case []byte:
+		return string(val)
+	}
+	if e == EncodingString {
+		return fmt.Sprint(v)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%+v", v)
+	}
+	return string(data)
+}
+
+// WithMetadata sets the metadata attributes for v. The metadata attribute
+// read by Phoenix is always set to v encoded with e; unless e is
+// EncodingString, v is also flattened into metadata.* attributes with
+// MarshalAttributes so that its fields keep their types.
+func (e AttributeEncoding) WithMetadata(v any) []attribute.KeyValue {
+	if v == nil {
+		return nil
+	}
+	kvs := []attribute.KeyValue{WithMetadata(e.EncodeValue(v))}
+	if e == EncodingString {
+		return kvs
+	}
+	return append(kvs, MarshalAttributes(MetadataKey, v)...)
+}
+
+// AttributeEncoding returns the attribute encoding configured with
+// WithAttributeEncoding.
+func (tp *TracerProvider) AttributeEncoding() AttributeEncoding {
+	if tp.config.AttributeEncoding == "" {
+		return EncodingJSON
+	}
+	return tp.config.AttributeEncoding
+}
@@ -0,0 +1,213 @@
+package otel
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// MarshalAttributes converts value into typed attributes under key.
+//
+// Nested maps are flattened using dot notation, so
+// {"user": {"id": 7}} under "metadata" becomes metadata.user.id=7. Slices
+// of a single primitive type become slice attributes; other slices are
+// flattened by index (key.0, key.1, ...). Structs and other values are
+// converted through their JSON encoding. Nil values and values that cannot
+// be JSON-encoded are omitted. Attributes are sorted by key.
+func MarshalAttributes(key string, value any) []attribute.KeyValue {
+	var kvs []attribute.KeyValue
+	marshalAttribute(&kvs, key, value, true)
+	return kvs
+}
+
+func marshalAttribute(kvs *[]attribute.KeyValue, key string, value any, convert bool) {
+	switch v := value.(type) {
+	case nil:
+	case string:
+		*kvs = append(*kvs, attribute.String(key, v))
+	case bool:
+		*kvs = append(*kvs, attribute.Bool(key, v))
+	case int:
+		*kvs = append(*kvs, attribute.Int(key, v))
+	case int32:
+		*kvs = append(*kvs, attribute.Int64(key, int64(v)))
+	case int64:
+		*kvs = append(*kvs, attribute.Int64(key, v))
+	case float32:
+		*kvs = append(*kvs, attribute.Float64(key, float64(v)))
+	case float64:
+		*kvs = append(*kvs, attribute.Float64(key, v))
+	case []string:
+		*kvs = append(*kvs, attribute.StringSlice(key, v))
+	case []bool:
+		*kvs = append(*kvs, attribute.BoolSlice(key, v))
+	case []int:
+		*kvs = append(*kvs, attribute.IntSlice(key, v))
+	case []int64:
+		*kvs = append(*kvs, attribute.Int64Slice(key, v))
+	case []float64:
+		*kvs = append(*kvs, attribute.Float64Slice(key, v))
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			marshalAttribute(kvs, joinAttributeKey(key, k), v[k], true)
+		}
+	case []any:
+		if kv, ok := primitiveSlice(key, v); ok {
+			*kvs = append(*kvs, kv)
+			return
+		}
+		for i, elem := range v {
+			marshalAttribute(kvs, joinAttributeKey(key, strconv.Itoa(i)), elem, true)
+		}
+	default:
+		if !convert {
+			return
+		}
+		// Normalize structs, typed maps and slices through JSON.
+		data, err := json.Marshal(v)
+		if err != nil {
+			return
+		}
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		var generic any
+		if err := dec.Decode(&generic); err != nil {
+			return
+		}
+		marshalAttribute(kvs, key, normalizeJSONNumbers(generic), false)
+	}
+}
+
+// normalizeJSONNumbers replaces json.Number values with int64 when they are
+// integral and float64 otherwise, so integer fields keep their type.
+func normalizeJSONNumbers(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		for k, child := range v {
+			v[k] = normalizeJSONNumbers(child)
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = normalizeJSONNumbers(child)
+		}
+	}
+	return v
+}
+
+// primitiveSlice returns a slice attribute for a non-empty slice whose
+// elements all share one primitive type.
+func primitiveSlice(key string, values []any) (attribute.KeyValue, bool) {
+	if len(values) == 0 {
+		return attribute.KeyValue{}, false
+	}
+	switch values[0].(type) {
+	case string:
+		s, ok := sliceOf[string](values)
+		return attribute.StringSlice(key, s), ok
+	case bool:
+		s, ok := sliceOf[bool](values)
+		return attribute.BoolSlice(key, s), ok
+	case float64:
+		s, ok := sliceOf[float64](values)
+		return attribute.Float64Slice(key, s), ok
+	case int64:
+		s, ok := sliceOf[int64](values)
+		return attribute.Int64Slice(key, s), ok
+	case int:
+		s, ok := sliceOf[int](values)
+		return attribute.IntSlice(key, s), ok
+	}
+	return attribute.KeyValue{}, false
+}
+
+func sliceOf[T any](values []any) ([]T, bool) {
+	out := make([]T, len(values))
+	for i, v := range values {
+		t, ok := v.(T)
+		if !ok {
+			return nil, false
+		}
+		out[i] = t
+	}
+	return out, true
+}
+
+func joinAttributeKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// UnmarshalAttributes is the inverse of MarshalAttributes: it rebuilds a
+// nested map from the attributes whose keys start with prefix followed by a
+// dot, with the prefix removed. An empty prefix selects every attribute.
+// Maps whose keys are exactly 0..n-1 are returned as slices. Because map
+// keys containing dots are indistinguishable from nesting, they come back
+// as nested maps.
+func UnmarshalAttributes(kvs []attribute.KeyValue, prefix string) map[string]any {
+	root := make(map[string]any)
+	for _, kv := range kvs {
+		key := string(kv.Key)
+		if prefix != "" {
+			rest, ok := strings.CutPrefix(key, prefix+".")
+			if !ok {
+				continue
+			}
+			key = rest
+		}
+
+		parts := strings.Split(key, ".")
+		node := root
+		for _, part := range parts[:len(parts)-1] {
+			child, ok := node[part].(map[string]any)
+			if !ok {
+				child = make(map[string]any)
+				node[part] = child
+			}
+			node = child
+		}
+		node[parts[len(parts)-1]] = kv.Value.AsInterface()
+	}
+
+	for k, v := range root {
+		root[k] = indexedToSlices(v)
+	}
+	return root
+}
+
+// indexedToSlices converts nested maps keyed 0..n-1 into slices.
+func indexedToSlices(v any) any {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return v
+	}
+	for k, child := range m {
+		m[k] = indexedToSlices(child)
+	}
+
+	s := make([]any, len(m))
+	for k, child := range m {
+		i, err := strconv.Atoi(k)
+		if err != nil || i < 0 || i >= len(m) || strconv.Itoa(i) != k {
+			return m
+		}
+		s[i] = child
+	}
+	return s
+}
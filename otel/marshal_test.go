@@ -0,0 +1,138 @@
+package otel
+
+import (
+	"reflect"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestMarshalAttributesRoundtrip(t *testing.T) {
+	type user struct {
+		ID    int    `json:"id"`
+		Email string `json:"email"`
+	}
+	tests := []struct {
+		name  string
+		value any
+		want  map[string]any
+	}{
+		{
+			name:  "primitives",
+			value: map[string]any{"s": "x", "b": true, "i": 7, "f": 1.5},
+			want:  map[string]any{"s": "x", "b": true, "i": int64(7), "f": 1.5},
+		},
+		{
+			name:  "nested maps",
+			value: map[string]any{"user": map[string]any{"id": int64(7), "tier": "pro"}},
+			want:  map[string]any{"user": map[string]any{"id": int64(7), "tier": "pro"}},
+		},
+		{
+			name:  "primitive slice",
+			value: map[string]any{"tags": []any{"a", "b"}},
+			want:  map[string]any{"tags": []string{"a", "b"}},
+		},
+		{
+			name:  "mixed slice",
+			value: map[string]any{"mixed": []any{"a", int64(1)}},
+			want:  map[string]any{"mixed": []any{"a", int64(1)}},
+		},
+		{
+			name:  "struct",
+			value: map[string]any{"user": user{ID: 3, Email: "a@b.c"}},
+			want:  map[string]any{"user": map[string]any{"id": int64(3), "email": "a@b.c"}},
+		},
+		{
+			name:  "nil dropped",
+			value: map[string]any{"kept": "x", "dropped": nil},
+			want:  map[string]any{"kept": "x"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kvs := MarshalAttributes(MetadataKey, tt.value)
+			got := UnmarshalAttributes(kvs, MetadataKey)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("round trip = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarshalAttributesKeys(t *testing.T) {
+	kvs := MarshalAttributes("metadata", map[string]any{
+		"user":  map[string]any{"id": 7},
+		"steps": []any{map[string]any{"name": "a"}, map[string]any{"name": "b"}},
+	})
+	want := []attribute.KeyValue{
+		attribute.String("metadata.steps.0.name", "a"),
+		attribute.String("metadata.steps.1.name", "b"),
+		attribute.Int("metadata.user.id", 7),
+	}
+	if !reflect.DeepEqual(kvs, want) {
+		t.Errorf("MarshalAttributes = %v, want %v", kvs, want)
+	}
+}
+
+func TestEncodingWithMetadata(t *testing.T) {
+	metadata := map[string]any{"user": map[string]any{"id": 7}}
+	tests := []struct {
+		encoding AttributeEncoding
+		want     []attribute.KeyValue
+	}{
+		{EncodingJSON, []attribute.KeyValue{
+			attribute.String(MetadataKey, `{"user":{"id":7}}`),
+			attribute.Int("metadata.user.id", 7),
+		}},
+		{EncodingString, []attribute.KeyValue{
+			attribute.String(MetadataKey, "map[user:map[id:7]]"),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.encoding), func(t *testing.T) {
+			if got := tt.encoding.WithMetadata(metadata); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WithMetadata = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if got := EncodingJSON.WithMetadata(nil); got != nil {
+		t.Errorf("WithMetadata(nil) = %v, want nil", got)
+	}
+}
+
+func TestEncodeValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoding AttributeEncoding
+		v        any
+		want     string
+	}{
+		{"nil", EncodingJSON, nil, ""},
+		{"string", EncodingJSON, "hi", "hi"},
+		{"bytes", EncodingJSON, []byte("hi"), "hi"},
+		{"map", EncodingJSON, map[string]int{"a": 1}, `{"a":1}`},
+		{"unencodable", EncodingJSON, struct{ C chan int }{}, "{C:<nil>}"},
+		{"string encoding", EncodingString, map[string]int{"a": 1}, "map[a:1]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.encoding.EncodeValue(tt.v); got != tt.want {
+				t.Errorf("EncodeValue = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTracerProviderAttributeEncoding(t *testing.T) {
+	tp := &TracerProvider{config: &Config{}}
+	if got := tp.AttributeEncoding(); got != EncodingJSON {
+		t.Errorf("default AttributeEncoding = %q, want %q", got, EncodingJSON)
+	}
+
+	cfg := &Config{}
+	WithAttributeEncoding(EncodingAuto)(cfg)
+	tp = &TracerProvider{config: cfg}
+	if got := tp.AttributeEncoding(); got != EncodingAuto {
+		t.Errorf("AttributeEncoding = %q, want %q", got, EncodingAuto)
+	}
+}
@@ -0,0 +1,221 @@
+package otel
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Message roles.
+const (
+	MessageRoleSystem    = "system"
+	MessageRoleUser      = "user"
+	MessageRoleAssistant = "assistant"
+	MessageRoleTool      = "tool"
+)
+
+// Message content types, as reported in MessageContent.Type.
+const (
+	MessageContentTypeText  = "text"
+	MessageContentTypeImage = "image"
+)
+
+// OpenInference message attributes, relative to an
+// llm.input_messages.{i} or llm.output_messages.{i} prefix.
+const (
+	messageRole       = "message.role"
+	messageContent    = "message.content"
+	messageName       = "message.name"
+	messageToolCallID = "message.tool_call_id"
+	messageContents   = "message.contents"
+	messageToolCalls  = "message.tool_calls"
+
+	messageContentType     = "message_content.type"
+	messageContentText     = "message_content.text"
+	messageContentImageURL = "message_content.image.image.url"
+
+	toolCallID                = "tool_call.id"
+	toolCallFunctionName      = "tool_call.function.name"
+	toolCallFunctionArguments = "tool_call.function.arguments"
+)
+
+// LLMMessage is a chat message sent to or received from an LLM.
+type LLMMessage struct {
+	Role string
+	// Content is the text of a single-part message.
+	Content string
+	// Contents holds the parts of a multi-part message.
+	Contents []MessageContent
+	// Name is the name of the participant, or of the function a tool
+	// message responds to.
+	Name string
+	// ToolCallID is the ID of the tool call a tool message responds to.
+	ToolCallID string
+	// ToolCalls holds the tools an assistant message calls.
+	ToolCalls []ToolCall
+}
+
+// MessageContent is a part of a multi-part message.
+type MessageContent struct {
+	// Type is MessageContentTypeText or MessageContentTypeImage.
+	Type     string
+	Text     string
+	ImageURL string
+}
+
+// ToolCall is a tool invocation requested by an LLM.
+type ToolCall struct {
+	ID                string
+	FunctionName      string
+	FunctionArguments string
+}
+
+// SerializeMessages returns the llm.input_messages attributes for msgs. See
+// SerializeMessagesAt for output messages.
+func SerializeMessages(msgs []LLMMessage) []attribute.KeyValue {
+	return SerializeMessagesAt(LLMInputMessages, msgs)
+}
+
+// SerializeMessagesAt returns OpenInference attributes for msgs under
+// prefix, typically LLMInputMessages or LLMOutputMessages. Empty fields are
+// omitted. DeserializeMessages reverses it.
+func SerializeMessagesAt(prefix string, msgs []LLMMessage) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	add := func(key, value string) {
+		if value != "" {
+			attrs = append(attrs, attribute.String(key, value))
+		}
+	}
+	for i, msg := range msgs {
+		p := prefix + "." + strconv.Itoa(i) + "."
+		add(p+messageRole, msg.Role)
+		add(p+messageContent, msg.Content)
+		add(p+messageName, msg.Name)
+		add(p+messageToolCallID, msg.ToolCallID)
+		for j, c := range msg.Contents {
+			cp := p + messageContents + "." + strconv.Itoa(j) + "."
+			add(cp+messageContentType, c.Type)
+			add(cp+messageContentText, c.Text)
+			add(cp+messageContentImageURL, c.ImageURL)
+		}
+		for j, tc := range msg.ToolCalls {
+			tp := p + messageToolCalls + "." + strconv.Itoa(j) + "."
+			add(tp+toolCallID, tc.ID)
+			add(tp+toolCallFunctionName, tc.FunctionName)
+			add(tp+toolCallFunctionArguments, tc.FunctionArguments)
+		}
+	}
+	return attrs
+}
+
+// DeserializeMessages rebuilds the messages serialized under prefix, such
+// as LLMInputMessages, from kvs. Attributes outside prefix and unknown
+// message attributes are ignored. Messages, contents and tool calls are
+// returned in index order; missing indexes are skipped. It returns an error
+// if an index is not a number or a known attribute is not a string.
+func DeserializeMessages(kvs []attribute.KeyValue, prefix string) ([]LLMMessage, error) {
+	type indexedMessage struct {
+		msg       LLMMessage
+		contents  map[int]*MessageContent
+		toolCalls map[int]*ToolCall
+	}
+	messages := make(map[int]*indexedMessage)
+
+	for _, kv := range kvs {
+		key, ok := strings.CutPrefix(string(kv.Key), prefix+".")
+		if !ok {
+			continue
+		}
+		index, field, _ := strings.Cut(key, ".")
+		i, err := parseMessageIndex(index)
+		if err != nil {
+			return nil, fmt.Errorf("otel: message attribute %q: %w", kv.Key, err)
+		}
+		if kv.Value.Type() != attribute.STRING {
+			return nil, fmt.Errorf("otel: message attribute %q: want string, got %s", kv.Key, kv.Value.Type())
+		}
+		value := kv.Value.AsString()
+
+		m, ok := messages[i]
+		if !ok {
+			m = &indexedMessage{contents: make(map[int]*MessageContent), toolCalls: make(map[int]*ToolCall)}
+			messages[i] = m
+		}
+
+		switch field {
+		case messageRole:
+			m.msg.Role = value
+		case messageContent:
+			m.msg.Content = value
+		case messageName:
+			m.msg.Name = value
+		case messageToolCallID:
+			m.msg.ToolCallID = value
+		default:
+			if rest, ok := strings.CutPrefix(field, messageContents+"."); ok {
+				index, part, _ := strings.Cut(rest, ".")
+				j, err := parseMessageIndex(index)
+				if err != nil {
+					return nil, fmt.Errorf("otel: message attribute %q: %w", kv.Key, err)
+				}
+				c, ok := m.contents[j]
+				if !ok {
+					c = &MessageContent{}
+					m.contents[j] = c
+				}
+				switch part {
+				case messageContentType:
+					c.Type = value
+				case messageContentText:
+					c.Text = value
+				case messageContentImageURL:
+					c.ImageURL = value
+				}
+			} else if rest, ok := strings.CutPrefix(field, messageToolCalls+"."); ok {
+				index, part, _ := strings.Cut(rest, ".")
+				j, err := parseMessageIndex(index)
+				if err != nil {
+					return nil, fmt.Errorf("otel: message attribute %q: %w", kv.Key, err)
+				}
+				tc, ok := m.toolCalls[j]
+				if !ok {
+					tc = &ToolCall{}
+					m.toolCalls[j] = tc
+				}
+				switch part {
+				case toolCallID:
+					tc.ID = value
+				case toolCallFunctionName:
+					tc.FunctionName = value
+				case toolCallFunctionArguments:
+					tc.FunctionArguments = value
+				}
+			}
+		}
+	}
+
+	msgs := make([]LLMMessage, 0, len(messages))
+	for _, i := range slices.Sorted(maps.Keys(messages)) {
+		m := messages[i]
+		for _, j := range slices.Sorted(maps.Keys(m.contents)) {
+			m.msg.Contents = append(m.msg.Contents, *m.contents[j])
+		}
+		for _, j := range slices.Sorted(maps.Keys(m.toolCalls)) {
+			m.msg.ToolCalls = append(m.msg.ToolCalls, *m.toolCalls[j])
+		}
+		msgs = append(msgs, m.msg)
+	}
+	return msgs, nil
+}
+
+func parseMessageIndex(s string) (int, error) {
+	i, err := strconv.Atoi(s)
+	if err != nil || i < 0 {
+		return 0, fmt.Errorf("invalid index %q", s)
+	}
+	return i, nil
+}
@@ -0,0 +1,107 @@
+package otel
+
+import (
+	"reflect"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestMessagesRoundtrip(t *testing.T) {
+	tests := []struct {
+		name string
+		msgs []LLMMessage
+	}{
+		{
+			name: "system and user",
+			msgs: []LLMMessage{
+				{Role: MessageRoleSystem, Content: "You are a helpful assistant."},
+				{Role: MessageRoleUser, Content: "What is the weather in Paris?"},
+			},
+		},
+		{
+			name: "tool calls",
+			msgs: []LLMMessage{
+				{Role: MessageRoleUser, Content: "What is the weather in Paris?"},
+				{Role: MessageRoleAssistant, ToolCalls: []ToolCall{
+					{ID: "call_1", FunctionName: "get_weather", FunctionArguments: `{"city":"Paris"}`},
+					{ID: "call_2", FunctionName: "get_time", FunctionArguments: `{"tz":"Europe/Paris"}`},
+				}},
+				{Role: MessageRoleTool, Name: "get_weather", ToolCallID: "call_1", Content: `{"temp":18}`},
+			},
+		},
+		{
+			name: "multi-part content",
+			msgs: []LLMMessage{
+				{Role: MessageRoleUser, Contents: []MessageContent{
+					{Type: MessageContentTypeText, Text: "What is in this image?"},
+					{Type: MessageContentTypeImage, ImageURL: "https://example.com/cat.png"},
+				}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DeserializeMessages(SerializeMessages(tt.msgs), LLMInputMessages)
+			if err != nil {
+				t.Fatalf("DeserializeMessages: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.msgs) {
+				t.Errorf("roundtrip = %+v, want %+v", got, tt.msgs)
+			}
+		})
+	}
+}
+
+func TestSerializeMessagesAttributes(t *testing.T) {
+	attrs := SerializeMessagesAt(LLMOutputMessages, []LLMMessage{
+		{Role: MessageRoleAssistant, ToolCalls: []ToolCall{{ID: "call_1", FunctionName: "search"}}},
+	})
+	want := map[string]string{
+		"llm.output_messages.0.message.role":                                 "assistant",
+		"llm.output_messages.0.message.tool_calls.0.tool_call.id":            "call_1",
+		"llm.output_messages.0.message.tool_calls.0.tool_call.function.name": "search",
+	}
+	if len(attrs) != len(want) {
+		t.Fatalf("got %d attributes, want %d: %v", len(attrs), len(want), attrs)
+	}
+	for _, kv := range attrs {
+		if w, ok := want[string(kv.Key)]; !ok || kv.Value.AsString() != w {
+			t.Errorf("%s = %q, want %q", kv.Key, kv.Value.AsString(), w)
+		}
+	}
+}
+
+func TestDeserializeMessagesErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		kv   attribute.KeyValue
+	}{
+		{"message index", attribute.String("llm.input_messages.x.message.role", "user")},
+		{"tool call index", attribute.String("llm.input_messages.0.message.tool_calls.-1.tool_call.id", "call_1")},
+		{"value type", attribute.Int("llm.input_messages.0.message.content", 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DeserializeMessages([]attribute.KeyValue{tt.kv}, LLMInputMessages); err == nil {
+				t.Error("DeserializeMessages succeeded, want an error")
+			}
+		})
+	}
+}
+
+func TestDeserializeMessagesIgnoresOtherAttributes(t *testing.T) {
+	attrs := append(SerializeMessages([]LLMMessage{{Role: MessageRoleUser, Content: "hi"}}),
+		attribute.String(LLMModelName, "gpt-4o"),
+		attribute.String("llm.output_messages.0.message.role", "assistant"),
+	)
+	got, err := DeserializeMessages(attrs, LLMInputMessages)
+	if err != nil {
+		t.Fatalf("DeserializeMessages: %v", err)
+	}
+	want := []LLMMessage{{Role: MessageRoleUser, Content: "hi"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,151 @@
+package otel
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// maxSchemaExamples is the number of distinct example values kept per
+// field by InferMetadataSchema.
+const maxSchemaExamples = 3
+
+// Metadata field types, as reported in FieldInfo.Type. Integers are accepted
+// where floats are expected.
+const (
+	FieldTypeString = "string"
+	FieldTypeInt    = "int"
+	FieldTypeFloat  = "float"
+	FieldTypeBool   = "bool"
+	FieldTypeObject = "object"
+	FieldTypeArray  = "array"
+	// FieldTypeAny is used for fields whose samples have different types.
+	// Values of any type are accepted.
+	FieldTypeAny = "any"
+)
+
+// MetadataSchema describes the expected keys and value types of span
+// metadata. See InferMetadataSchema.
+type MetadataSchema struct {
+	Fields map[string]FieldInfo
+}
+
+// FieldInfo describes a metadata key.
+type FieldInfo struct {
+	// Type is one of the FieldType constants.
+	Type string
+	// Optional reports whether the key may be absent or null.
+	Optional bool
+	// Examples holds up to three distinct values seen for the key.
+	Examples []any
+}
+
+// InferMetadataSchema builds a schema from sample metadata, such as the
+// metadata of existing spans. A key is optional if it is missing or null in
+// any sample. A key whose samples have different types accepts any type,
+// except that integer and float samples make a float field.
+func InferMetadataSchema(samples []map[string]any) *MetadataSchema {
+	schema := &MetadataSchema{Fields: make(map[string]FieldInfo)}
+	counts := make(map[string]int)
+	for _, sample := range samples {
+		for key, value := range sample {
+			if value == nil {
+				continue
+			}
+			counts[key]++
+			typ := metadataFieldType(value)
+			field, ok := schema.Fields[key]
+			if !ok {
+				field.Type = typ
+			} else {
+				field.Type = mergeFieldTypes(field.Type, typ)
+			}
+			if len(field.Examples) < maxSchemaExamples && !slices.ContainsFunc(field.Examples, func(e any) bool {
+				return reflect.DeepEqual(e, value)
+			}) {
+				field.Examples = append(field.Examples, value)
+			}
+			schema.Fields[key] = field
+		}
+	}
+	for key, field := range schema.Fields {
+		if counts[key] < len(samples) {
+			field.Optional = true
+			schema.Fields[key] = field
+		}
+	}
+	return schema
+}
+
+// Validate checks m against the schema and returns one error per missing
+// required key, unknown key or value of the wrong type, sorted by key.
+func (s *MetadataSchema) Validate(m map[string]any) []ValidationError {
+	var errs []ValidationError
+	for _, key := range slices.Sorted(maps.Keys(s.Fields)) {
+		field := s.Fields[key]
+		value, ok := m[key]
+		if !ok || value == nil {
+			if !field.Optional {
+				errs = append(errs, ValidationError{Attribute: key, Message: "required metadata key is missing"})
+			}
+			continue
+		}
+		if typ := metadataFieldType(value); !fieldTypeMatches(field.Type, typ) {
+			errs = append(errs, ValidationError{Attribute: key, Message: fmt.Sprintf("expected %s, got %s", field.Type, typ)})
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(m)) {
+		if _, ok := s.Fields[key]; !ok {
+			errs = append(errs, ValidationError{Attribute: key, Message: "unknown metadata key"})
+		}
+	}
+	slices.SortStableFunc(errs, func(a, b ValidationError) int {
+		return cmp.Compare(a.Attribute, b.Attribute)
+	})
+	return errs
+}
+
+// metadataFieldType returns the FieldType of a non-nil metadata value.
+func metadataFieldType(v any) string {
+	if n, ok := v.(json.Number); ok {
+		if _, err := n.Int64(); err == nil {
+			return FieldTypeInt
+		}
+		return FieldTypeFloat
+	}
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.String:
+		return FieldTypeString
+	case reflect.Bool:
+		return FieldTypeBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return FieldTypeInt
+	case reflect.Float32, reflect.Float64:
+		return FieldTypeFloat
+	case reflect.Slice, reflect.Array:
+		return FieldTypeArray
+	case reflect.Map, reflect.Struct, reflect.Pointer:
+		return FieldTypeObject
+	default:
+		return FieldTypeAny
+	}
+}
+
+func mergeFieldTypes(a, b string) string {
+	switch {
+	case a == b:
+		return a
+	case (a == FieldTypeInt && b == FieldTypeFloat) || (a == FieldTypeFloat && b == FieldTypeInt):
+		return FieldTypeFloat
+	default:
+		return FieldTypeAny
+	}
+}
+
+func fieldTypeMatches(want, got string) bool {
+	return want == got || want == FieldTypeAny || (want == FieldTypeFloat && got == FieldTypeInt)
+}
@@ -0,0 +1,50 @@
+package otel
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInferMetadataSchema(t *testing.T) {
+	schema := InferMetadataSchema([]map[string]any{
+		{"user": "alice", "attempt": 1, "score": 1, "tags": []string{"a"}},
+		{"user": "bob", "attempt": 2, "score": 0.5, "extra": true},
+		{"user": "alice", "attempt": 3, "score": 0.75, "extra": nil},
+	})
+
+	want := map[string]FieldInfo{
+		"user":    {Type: FieldTypeString, Examples: []any{"alice", "bob"}},
+		"attempt": {Type: FieldTypeInt, Examples: []any{1, 2, 3}},
+		"score":   {Type: FieldTypeFloat, Examples: []any{1, 0.5, 0.75}},
+		"tags":    {Type: FieldTypeArray, Optional: true, Examples: []any{[]string{"a"}}},
+		"extra":   {Type: FieldTypeBool, Optional: true, Examples: []any{true}},
+	}
+	if !reflect.DeepEqual(schema.Fields, want) {
+		t.Errorf("Fields = %+v, want %+v", schema.Fields, want)
+	}
+}
+
+func TestMetadataSchemaValidate(t *testing.T) {
+	schema := InferMetadataSchema([]map[string]any{
+		{"user": "alice", "score": 0.5, "tags": []string{"a"}},
+		{"user": "bob", "score": 1},
+	})
+
+	if errs := schema.Validate(map[string]any{"user": "carol", "score": 2}); len(errs) != 0 {
+		t.Errorf("Validate(valid) = %v, want no errors", errs)
+	}
+
+	errs := schema.Validate(map[string]any{"score": "high", "other": 1})
+	var got []string
+	for _, err := range errs {
+		got = append(got, err.Error())
+	}
+	want := []string{
+		`attribute "other": unknown metadata key`,
+		`attribute "score": expected float, got string`,
+		`attribute "user": required metadata key is missing`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate = %q, want %q", got, want)
+	}
+}
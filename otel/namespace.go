@@ -0,0 +1,99 @@
+package otel
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// openInferencePrefixes are the key prefixes of OpenInference attributes,
+// which are never namespaced.
+var openInferencePrefixes = []string{
+	"openinference.", "input.", "output.", "llm.", "message.", "tool.", "tool_call.",
+	"retrieval.", "reranker.", "document.", "embedding.", "session.", "user.",
+	"metadata", "tag.", "prompt_template.", "guardrail.", "agent.",
+}
+
+// AttributeNamespacingProcessor renames custom span attributes to
+// {prefix}.{key} before passing ended spans to the next processor.
+// OpenInference attributes, attributes already under the prefix and
+// attributes matching an excluded prefix keep their keys.
+type AttributeNamespacingProcessor struct {
+	next     sdktrace.SpanProcessor
+	prefix   string
+	excluded []string
+}
+
+// NewAttributeNamespacingProcessor returns a processor that namespaces the
+// custom attributes of ended spans under prefix and forwards them to next.
+// Keys starting with any of exclude are left unchanged.
+func NewAttributeNamespacingProcessor(prefix string, next sdktrace.SpanProcessor, exclude ...string) *AttributeNamespacingProcessor {
+	return &AttributeNamespacingProcessor{
+		next:     next,
+		prefix:   strings.TrimSuffix(prefix, ".") + ".",
+		excluded: exclude,
+	}
+}
+
+// OnStart forwards the span to the next processor.
+func (p *AttributeNamespacingProcessor) OnStart(ctx context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(ctx, s)
+}
+
+// OnEnd forwards the span to the next processor with its custom attributes renamed.
+func (p *AttributeNamespacingProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	attrs := s.Attributes()
+	renamed := make([]attribute.KeyValue, len(attrs))
+	changed := false
+	for i, kv := range attrs {
+		renamed[i] = kv
+		if key := string(kv.Key); p.shouldRename(key) {
+			renamed[i].Key = attribute.Key(p.prefix + key)
+			changed = true
+		}
+	}
+	if !changed {
+		p.next.OnEnd(s)
+		return
+	}
+	p.next.OnEnd(namespacedSpan{ReadOnlySpan: s, attrs: renamed})
+}
+
+// Shutdown shuts down the next processor.
+func (p *AttributeNamespacingProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush flushes the next processor.
+func (p *AttributeNamespacingProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+func (p *AttributeNamespacingProcessor) shouldRename(key string) bool {
+	if strings.HasPrefix(key, p.prefix) {
+		return false
+	}
+	for _, prefix := range openInferencePrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return false
+		}
+	}
+	for _, prefix := range p.excluded {
+		if strings.HasPrefix(key, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// namespacedSpan overrides the attributes of an ended span.
+type namespacedSpan struct {
+	sdktrace.ReadOnlySpan
+	attrs []attribute.KeyValue
+}
+
+func (s namespacedSpan) Attributes() []attribute.KeyValue {
+	return s.attrs
+}
@@ -0,0 +1,45 @@
+package otel
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestAttributeNamespacingProcessor(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	processor := NewAttributeNamespacingProcessor("acme", sdktrace.NewSimpleSpanProcessor(exporter), "http.")
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(processor))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	_, span := tp.Tracer("test").Start(context.Background(), "span")
+	span.SetAttributes(
+		attribute.String("tenant", "t1"),
+		attribute.String("acme.region", "eu"),
+		attribute.String("http.method", "GET"),
+		attribute.String(LLMModelName, "gpt-4o"),
+		attribute.String(MetadataKey, "{}"),
+		attribute.String(AgentThought, "look it up"),
+		attribute.Int(AgentPlanStepIndex, 1),
+	)
+	span.End()
+
+	got := map[string]bool{}
+	for _, kv := range exporter.GetSpans()[0].Attributes {
+		got[string(kv.Key)] = true
+	}
+	for _, key := range []string{
+		"acme.tenant", "acme.region", "http.method", LLMModelName, MetadataKey,
+		AgentThought, AgentPlanStepIndex,
+	} {
+		if !got[key] {
+			t.Errorf("missing attribute %q in %v", key, got)
+		}
+	}
+	if got["tenant"] {
+		t.Error("custom attribute tenant was not namespaced")
+	}
+}
@@ -0,0 +1,43 @@
+package otel
+
+import (
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// closureSuffix matches the suffixes the Go runtime gives anonymous
+// functions, such as ".func1" or ".func2.3".
+var closureSuffix = regexp.MustCompile(`(\.func\d+|\.\d+)+$`)
+
+// FuncSpanName returns a span name of the form pkg.Function for the
+// function fn, using the last element of its package path. Methods are
+// named pkg.Type.Method, and anonymous functions are named after the
+// function that declares them. It returns an empty string if fn is not a
+// non-nil function.
+func FuncSpanName(fn any) string {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return ""
+	}
+	f := runtime.FuncForPC(v.Pointer())
+	if f == nil {
+		return ""
+	}
+
+	name := f.Name()
+	// Method values are wrapped in functions with an -fm suffix.
+	name = strings.TrimSuffix(name, "-fm")
+	// Drop type parameters of generic functions: pkg.Map[...].
+	name = strings.ReplaceAll(name, "[...]", "")
+	name = closureSuffix.ReplaceAllString(name, "")
+	// Anonymous functions assigned to package variables.
+	name = strings.TrimSuffix(name, ".glob.")
+	if i := strings.LastIndexByte(name, '/'); i >= 0 {
+		name = name[i+1:]
+	}
+	// Pointer receivers: pkg.(*Type).Method.
+	name = strings.NewReplacer("(*", "", "(", "", ")", "").Replace(name)
+	return name
+}
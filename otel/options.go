@@ -1,6 +1,11 @@
 package otel
 
-import "time"
+import (
+	"maps"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
 
 // Option configures the Phoenix OTEL integration.
 type Option func(*Config)
@@ -10,12 +15,22 @@ type Option func(*Config)
 // Example endpoints:
 //   - http://localhost:6006 (local Phoenix)
 //   - https://app.phoenix.arize.com (Phoenix Cloud - requires SpaceID)
+//   - unix:/var/run/phoenix.sock (Unix domain socket, see WithUnixSocket)
 func WithEndpoint(endpoint string) Option {
 	return func(c *Config) {
 		c.Endpoint = endpoint
 	}
 }
 
+// WithUnixSocket exports spans over the Unix domain socket at path, for
+// example when Phoenix runs in a sidecar container. It is equivalent to
+// WithEndpoint("unix:" + path); the space ID is ignored for socket endpoints.
+func WithUnixSocket(path string) Option {
+	return func(c *Config) {
+		c.Endpoint = unixEndpointPrefix + path
+	}
+}
+
 // WithSpaceID sets the space identifier for Phoenix Cloud.
 // When using Phoenix Cloud (app.phoenix.arize.com), set this to your space ID.
 // The endpoint will be constructed as {Endpoint}/s/{SpaceID}.
@@ -32,6 +47,20 @@ func WithProjectName(name string) Option {
 	}
 }
 
+// WithProjectHeaders sets headers, such as a per-project API key, sent with
+// exports for the named project. They replace any header of the same name
+// on requests whose x-phoenix-project-name header is projectName, so a
+// single process can export to several projects with different
+// credentials. It may be called once per project.
+func WithProjectHeaders(projectName string, headers map[string]string) Option {
+	return func(c *Config) {
+		if c.ProjectHeaders == nil {
+			c.ProjectHeaders = make(map[string]map[string]string)
+		}
+		c.ProjectHeaders[projectName] = maps.Clone(headers)
+	}
+}
+
 // WithAPIKey sets the API key for authentication.
 // This is required for Arize cloud.
 func WithAPIKey(key string) Option {
@@ -71,6 +100,7 @@ func WithBatchTimeout(timeout time.Duration) Option {
 }
 
 // WithBatchSize sets the maximum number of spans to batch.
+// See BatchConfigForThroughput for recommended values.
 func WithBatchSize(size int) Option {
 	return func(c *Config) {
 		c.BatchSize = size
@@ -105,3 +135,95 @@ func WithInsecure(insecure bool) Option {
 		c.Insecure = insecure
 	}
 }
+
+// WithSchemaValidation validates the attributes of every ended span against
+// the given embedded OpenInference schema version and logs a warning for
+// each violation. Spans are exported unchanged. Register returns an error if
+// the version is not embedded.
+func WithSchemaValidation(version string) Option {
+	return func(c *Config) {
+		if version == "" {
+			version = DefaultSchemaVersion
+		}
+		c.SchemaVersion = version
+	}
+}
+
+// WithHTTPTransport sets the connection pooling limits of the HTTP
+// transport used by the OTLP exporter and optionally enables HTTP/2.
+// See NewHTTPTransport for recommended settings.
+func WithHTTPTransport(maxIdleConns, maxIdleConnsPerHost, maxConnsPerHost int, h2 bool) Option {
+	return func(c *Config) {
+		c.Transport = &TransportConfig{
+			MaxIdleConns:        maxIdleConns,
+			MaxIdleConnsPerHost: maxIdleConnsPerHost,
+			MaxConnsPerHost:     maxConnsPerHost,
+			HTTP2:               h2,
+		}
+	}
+}
+
+// WithIDGenerator sets the generator used for trace and span IDs. IDs are
+// random by default; see oteltesting.NewSequentialIDGenerator for
+// reproducible IDs in tests.
+func WithIDGenerator(gen sdktrace.IDGenerator) Option {
+	return func(c *Config) {
+		c.IDGenerator = gen
+	}
+}
+
+// WithSampler sets the sampler used by the tracer provider. Samplers created
+// with NewErrorSampler or NewCostSampler also decide, when a span ends, to
+// export spans the base sampler dropped.
+func WithSampler(sampler sdktrace.Sampler) Option {
+	return func(c *Config) {
+		c.Sampler = sampler
+	}
+}
+
+// WithAttributeNamespace renames custom span attributes to {prefix}.{key}
+// when spans end, to avoid collisions with other instrumentation.
+// OpenInference attributes are never renamed. See
+// AttributeNamespacingProcessor.
+func WithAttributeNamespace(prefix string) Option {
+	return func(c *Config) {
+		c.AttributeNamespace = prefix
+	}
+}
+
+// WithAttributeNamespaceExclude exempts attributes whose keys start with any
+// of prefixes from WithAttributeNamespace.
+func WithAttributeNamespaceExclude(prefixes ...string) Option {
+	return func(c *Config) {
+		c.AttributeNamespaceExclude = append(c.AttributeNamespaceExclude, prefixes...)
+	}
+}
+
+// WithAdaptiveBatching enables batch span processing with a batch size that
+// adapts to the observed export latency, starting from the configured batch
+// size. Every 30 seconds the batch size grows by 10% if exports are fast
+// relative to the batch timeout and shrinks by 10% if they are slow.
+func WithAdaptiveBatching() Option {
+	return func(c *Config) {
+		c.Batch = true
+		c.AdaptiveBatching = true
+	}
+}
+
+// QueueDepthExceededHandler sets a function called when the batch export
+// queue rises above 80% of its capacity, typically because Phoenix is slow
+// to accept exports. It fires once per crossing, on the goroutine ending the
+// span, so it must not block. See TracerProvider.QueueStats.
+func QueueDepthExceededHandler(fn func(depth, max int)) Option {
+	return func(c *Config) {
+		c.QueueDepthExceededHandler = fn
+	}
+}
+
+// WithAttributeEncoding sets how input, output and metadata values are
+// encoded; see AttributeEncoding. Defaults to EncodingJSON.
+func WithAttributeEncoding(enc AttributeEncoding) Option {
+	return func(c *Config) {
+		c.AttributeEncoding = enc
+	}
+}
@@ -0,0 +1,110 @@
+package otel
+
+import (
+	"context"
+	"sync/atomic"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// queueDepthAlertRatio is the fraction of the queue capacity above which
+// the QueueDepthExceededHandler fires.
+const queueDepthAlertRatio = 0.8
+
+// QueueStats is a snapshot of the batch export queue.
+type QueueStats struct {
+	// CurrentDepth is the number of spans accepted into the queue and not
+	// yet handed to the exporter. Dropped spans are not counted.
+	CurrentDepth int
+
+	// MaxDepth is the queue capacity. It is zero when spans are exported
+	// synchronously.
+	MaxDepth int
+
+	// DroppedTotal is the number of spans dropped because the queue was
+	// full.
+	DroppedTotal int64
+
+	// ExportedTotal is the number of spans handed to the exporter.
+	ExportedTotal int64
+}
+
+// QueueStats returns a snapshot of the batch export queue.
+func (tp *TracerProvider) QueueStats() QueueStats {
+	var stats QueueStats
+	if tp.stats != nil {
+		stats.ExportedTotal = tp.stats.exported.Load()
+	}
+	if q := tp.queue; q != nil {
+		stats.CurrentDepth = q.depth()
+		stats.MaxDepth = q.max
+		stats.DroppedTotal = q.dropped.Load()
+	}
+	return stats
+}
+
+// queueTracker wraps a batch span processor and tracks the depth of its
+// queue. It drops spans itself once the queue is full, so that every drop
+// is counted; the wrapped processor's queue must hold at least max spans.
+type queueTracker struct {
+	next     sdktrace.SpanProcessor
+	stats    *pipelineStats
+	max      int
+	exceeded func(depth, max int)
+
+	enqueued atomic.Int64
+	dropped  atomic.Int64
+	alerting atomic.Bool
+}
+
+func newQueueTracker(next sdktrace.SpanProcessor, stats *pipelineStats, maxDepth int, exceeded func(depth, max int)) *queueTracker {
+	return &queueTracker{
+		next:     next,
+		stats:    stats,
+		max:      maxDepth,
+		exceeded: exceeded,
+	}
+}
+
+// depth returns the number of spans enqueued but not yet exported.
+func (q *queueTracker) depth() int {
+	return int(max(q.enqueued.Load()-q.stats.exported.Load(), 0))
+}
+
+func (q *queueTracker) OnStart(ctx context.Context, s sdktrace.ReadWriteSpan) {
+	q.next.OnStart(ctx, s)
+}
+
+// OnEnd forwards a sampled span to the wrapped processor, or drops it when
+// the queue is full. The exceeded handler fires once each time the depth
+// rises above 80% of the capacity.
+func (q *queueTracker) OnEnd(s sdktrace.ReadOnlySpan) {
+	if !s.SpanContext().IsSampled() {
+		q.next.OnEnd(s)
+		return
+	}
+	depth := q.depth()
+	if depth >= q.max {
+		q.dropped.Add(1)
+		return
+	}
+	q.enqueued.Add(1)
+	depth++
+
+	if float64(depth) > queueDepthAlertRatio*float64(q.max) {
+		if q.exceeded != nil && q.alerting.CompareAndSwap(false, true) {
+			q.exceeded(depth, q.max)
+		}
+	} else {
+		q.alerting.Store(false)
+	}
+	q.next.OnEnd(s)
+}
+
+func (q *queueTracker) Shutdown(ctx context.Context) error {
+	return q.next.Shutdown(ctx)
+}
+
+func (q *queueTracker) ForceFlush(ctx context.Context) error {
+	return q.next.ForceFlush(ctx)
+}
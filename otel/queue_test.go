@@ -0,0 +1,91 @@
+package otel
+
+import (
+	"context"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// holdingProcessor keeps ended spans until they are exported with export.
+type holdingProcessor struct {
+	stats *pipelineStats
+	held  []sdktrace.ReadOnlySpan
+}
+
+func (p *holdingProcessor) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+func (p *holdingProcessor) OnEnd(s sdktrace.ReadOnlySpan) { p.held = append(p.held, s) }
+
+func (p *holdingProcessor) Shutdown(context.Context) error { return nil }
+
+func (p *holdingProcessor) ForceFlush(context.Context) error { return nil }
+
+// export hands n held spans to the exporter.
+func (p *holdingProcessor) export(n int) {
+	p.stats.exported.Add(int64(n))
+	p.held = p.held[n:]
+}
+
+func TestQueueDepth(t *testing.T) {
+	stats := &pipelineStats{}
+	held := &holdingProcessor{stats: stats}
+	var alerts []int
+	queue := newQueueTracker(held, stats, 5, func(depth, _ int) { alerts = append(alerts, depth) })
+	tp := &TracerProvider{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(queue)),
+		config:         &Config{Batch: true},
+		stats:          stats,
+		queue:          queue,
+	}
+	tracer := tp.Tracer("test")
+	endSpans := func(n int) {
+		for range n {
+			_, span := tracer.Start(context.Background(), "span")
+			span.End()
+		}
+	}
+	check := func(wantDepth int, wantDropped int64) {
+		t.Helper()
+		stats := tp.QueueStats()
+		if stats.CurrentDepth != wantDepth || stats.DroppedTotal != wantDropped {
+			t.Errorf("QueueStats = %+v, want depth %d and %d dropped", stats, wantDepth, wantDropped)
+		}
+		if got := tp.DiagnosticDump().QueueDepth; got != int64(wantDepth) {
+			t.Errorf("DiagnosticDump().QueueDepth = %d, want %d", got, wantDepth)
+		}
+	}
+
+	endSpans(3)
+	check(3, 0)
+
+	held.export(2)
+	check(1, 0)
+
+	// The queue holds five spans; the sixth and seventh are dropped.
+	endSpans(6)
+	check(5, 2)
+	if len(alerts) != 1 || alerts[0] != 5 {
+		t.Errorf("exceeded handler called with %v, want [5]", alerts)
+	}
+
+	held.export(5)
+	check(0, 2)
+	if got := tp.QueueStats().ExportedTotal; got != 7 {
+		t.Errorf("ExportedTotal = %d, want 7", got)
+	}
+}
+
+func TestQueueDepthSynchronous(t *testing.T) {
+	tp := &TracerProvider{
+		TracerProvider: sdktrace.NewTracerProvider(),
+		config:         &Config{},
+		stats:          &pipelineStats{},
+	}
+	if got := tp.QueueStats(); got != (QueueStats{}) {
+		t.Errorf("QueueStats = %+v, want zero", got)
+	}
+	if got := tp.DiagnosticDump().QueueDepth; got != 0 {
+		t.Errorf("DiagnosticDump().QueueDepth = %d, want 0", got)
+	}
+}
@@ -44,6 +44,8 @@ package otel
 import (
 	"context"
 	"fmt"
+	"net"
+	"net/http"
 	"net/url"
 	"strings"
 
@@ -59,6 +61,8 @@ import (
 type TracerProvider struct {
 	*sdktrace.TracerProvider
 	config *Config
+	stats  *pipelineStats
+	queue  *queueTracker
 }
 
 // Register creates and configures an OpenTelemetry TracerProvider for Phoenix.
@@ -89,6 +93,8 @@ func Register(opts ...Option) (*TracerProvider, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create exporter: %w", err)
 	}
+	stats := &pipelineStats{}
+	exporter = &countingExporter{SpanExporter: exporter, stats: stats}
 
 	// Create resource with Phoenix attributes
 	res, err := createResource(cfg)
@@ -96,22 +102,57 @@ func Register(opts ...Option) (*TracerProvider, error) {
 		return nil, fmt.Errorf("failed to create resource: %w", err)
 	}
 
+	var validator *AttributeSchemaValidator
+	if cfg.SchemaVersion != "" {
+		validator, err = loadSchemaValidator(cfg.SchemaVersion)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Create span processor
-	var spanProcessor sdktrace.SpanProcessor
-	if cfg.Batch {
-		spanProcessor = sdktrace.NewBatchSpanProcessor(exporter,
+	var (
+		spanProcessor sdktrace.SpanProcessor
+		queue         *queueTracker
+	)
+	switch {
+	case cfg.Batch && cfg.AdaptiveBatching:
+		queue = newQueueTracker(newAdaptiveBatchProcessor(exporter, cfg.BatchSize, cfg.BatchTimeout),
+			stats, adaptiveQueueSize, cfg.QueueDepthExceededHandler)
+		spanProcessor = queue
+	case cfg.Batch:
+		queue = newQueueTracker(sdktrace.NewBatchSpanProcessor(exporter,
 			sdktrace.WithBatchTimeout(cfg.BatchTimeout),
 			sdktrace.WithMaxExportBatchSize(cfg.BatchSize),
-		)
-	} else {
+			sdktrace.WithMaxQueueSize(sdktrace.DefaultMaxQueueSize),
+		), stats, sdktrace.DefaultMaxQueueSize, cfg.QueueDepthExceededHandler)
+		spanProcessor = queue
+	default:
 		spanProcessor = sdktrace.NewSimpleSpanProcessor(exporter)
 	}
+	if ds, ok := cfg.Sampler.(deferredSampler); ok {
+		spanProcessor = &deferredSamplingProcessor{next: spanProcessor, sampler: ds}
+	}
+	if cfg.AttributeNamespace != "" {
+		spanProcessor = NewAttributeNamespacingProcessor(cfg.AttributeNamespace, spanProcessor, cfg.AttributeNamespaceExclude...)
+	}
 
-	// Create tracer provider
-	tp := sdktrace.NewTracerProvider(
+	tpOpts := []sdktrace.TracerProviderOption{
 		sdktrace.WithSpanProcessor(spanProcessor),
 		sdktrace.WithResource(res),
-	)
+	}
+	if cfg.IDGenerator != nil {
+		tpOpts = append(tpOpts, sdktrace.WithIDGenerator(cfg.IDGenerator))
+	}
+	if cfg.Sampler != nil {
+		tpOpts = append(tpOpts, sdktrace.WithSampler(cfg.Sampler))
+	}
+	if validator != nil {
+		tpOpts = append(tpOpts, sdktrace.WithSpanProcessor(&schemaValidationProcessor{validator: validator}))
+	}
+
+	// Create tracer provider
+	tp := sdktrace.NewTracerProvider(tpOpts...)
 
 	// Set as global provider if requested
 	if cfg.SetGlobalProvider {
@@ -121,6 +162,8 @@ func Register(opts ...Option) (*TracerProvider, error) {
 	return &TracerProvider{
 		TracerProvider: tp,
 		config:         cfg,
+		stats:          stats,
+		queue:          queue,
 	}, nil
 }
 
@@ -136,48 +179,59 @@ func (tp *TracerProvider) Config() *Config {
 
 // createExporter creates an OTLP exporter based on the configuration.
 func createExporter(cfg *Config) (sdktrace.SpanExporter, error) {
-	// Use effective endpoint (includes space ID if configured)
-	endpoint := cfg.EffectiveEndpoint()
-	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
-		endpoint = "http://" + endpoint
-	}
+	// Build options
+	var exporterOpts []otlptracehttp.Option
 
-	parsedURL, err := url.Parse(endpoint)
-	if err != nil {
-		return nil, fmt.Errorf("invalid endpoint URL: %w", err)
-	}
+	socketPath, unixSocket := strings.CutPrefix(cfg.Endpoint, unixEndpointPrefix)
+	if unixSocket {
+		// The host is only used for the Host header; requests are sent
+		// over the socket.
+		exporterOpts = append(exporterOpts,
+			otlptracehttp.WithEndpoint("localhost"),
+			otlptracehttp.WithURLPath(DefaultHTTPPath),
+			otlptracehttp.WithInsecure(),
+		)
+	} else {
+		// Use effective endpoint (includes space ID if configured)
+		endpoint := cfg.EffectiveEndpoint()
+		if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+			endpoint = "http://" + endpoint
+		}
 
-	// Note: Currently only HTTP/protobuf is supported.
-	// gRPC support would require importing otlptracegrpc.
-	// Protocol inference is available via inferProtocol() for future use.
+		parsedURL, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid endpoint URL: %w", err)
+		}
 
-	// Build options
-	var exporterOpts []otlptracehttp.Option
+		// Note: Currently only HTTP/protobuf is supported.
+		// gRPC support would require importing otlptracegrpc.
+		// Protocol inference is available via inferProtocol() for future use.
 
-	// Set endpoint (host:port)
-	host := parsedURL.Hostname()
-	port := parsedURL.Port()
-	if port == "" {
-		if parsedURL.Scheme == "https" {
-			port = "443"
-		} else {
-			port = "6006"
+		// Set endpoint (host:port)
+		host := parsedURL.Hostname()
+		port := parsedURL.Port()
+		if port == "" {
+			if parsedURL.Scheme == "https" {
+				port = "443"
+			} else {
+				port = "6006"
+			}
 		}
-	}
-	exporterOpts = append(exporterOpts, otlptracehttp.WithEndpoint(host+":"+port))
+		exporterOpts = append(exporterOpts, otlptracehttp.WithEndpoint(host+":"+port))
 
-	// Set URL path - always append /v1/traces to the base path
-	basePath := parsedURL.Path
-	if basePath == "" || basePath == "/" {
-		basePath = ""
-	}
-	// Ensure we have the OTLP trace endpoint path
-	path := basePath + DefaultHTTPPath
-	exporterOpts = append(exporterOpts, otlptracehttp.WithURLPath(path))
+		// Set URL path - always append /v1/traces to the base path
+		basePath := parsedURL.Path
+		if basePath == "" || basePath == "/" {
+			basePath = ""
+		}
+		// Ensure we have the OTLP trace endpoint path
+		path := basePath + DefaultHTTPPath
+		exporterOpts = append(exporterOpts, otlptracehttp.WithURLPath(path))
 
-	// Set TLS
-	if parsedURL.Scheme == "http" {
-		exporterOpts = append(exporterOpts, otlptracehttp.WithInsecure())
+		// Set TLS
+		if parsedURL.Scheme == "http" {
+			exporterOpts = append(exporterOpts, otlptracehttp.WithInsecure())
+		}
 	}
 
 	// Set headers
@@ -193,13 +247,40 @@ func createExporter(cfg *Config) (sdktrace.SpanExporter, error) {
 
 	// Add project name header (Phoenix convention)
 	if cfg.ProjectName != "" {
-		headers["x-phoenix-project-name"] = cfg.ProjectName
+		headers[projectNameHeader] = cfg.ProjectName
 	}
 
 	if len(headers) > 0 {
 		exporterOpts = append(exporterOpts, otlptracehttp.WithHeaders(headers))
 	}
 
+	// Set transport
+	if cfg.Transport != nil || unixSocket || len(cfg.ProjectHeaders) > 0 {
+		var transport *http.Transport
+		if cfg.Transport != nil {
+			var err error
+			transport, err = cfg.Transport.build()
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			transport = http.DefaultTransport.(*http.Transport).Clone()
+		}
+		if unixSocket {
+			transport.Proxy = nil
+			transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socketPath)
+			}
+		}
+
+		var rt http.RoundTripper = transport
+		if len(cfg.ProjectHeaders) > 0 {
+			rt = &projectHeaderTransport{base: transport, headers: cfg.ProjectHeaders}
+		}
+		exporterOpts = append(exporterOpts, otlptracehttp.WithHTTPClient(&http.Client{Transport: rt}))
+	}
+
 	return otlptracehttp.New(context.Background(), exporterOpts...)
 }
 
@@ -230,8 +311,9 @@ func createResource(cfg *Config) (*resource.Resource, error) {
 }
 
 // inferProtocol infers the transport protocol from the endpoint URL.
-// Currently unused but kept for future gRPC support.
-func inferProtocol(u *url.URL) Protocol { //nolint:unused // Kept for future gRPC support
+// The SDK exporter only supports HTTP, so this is currently used only by
+// GenerateCollectorConfig.
+func inferProtocol(u *url.URL) Protocol {
 	port := u.Port()
 
 	// gRPC typically uses port 4317
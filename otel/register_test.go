@@ -0,0 +1,101 @@
+package otel
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRegisterUnixSocket(t *testing.T) {
+	// Socket paths are limited to around 100 bytes, so avoid t.TempDir.
+	dir, err := os.MkdirTemp("", "phoenix")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	socketPath := filepath.Join(dir, "phoenix.sock")
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Skipf("unix sockets unavailable: %v", err)
+	}
+
+	var requests atomic.Int32
+	var gotPath, gotProject atomic.Value
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		gotPath.Store(r.URL.Path)
+		gotProject.Store(r.Header.Get("x-phoenix-project-name"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	srv.Listener = listener
+	srv.Start()
+	t.Cleanup(srv.Close)
+
+	tp, err := Register(
+		WithUnixSocket(socketPath),
+		WithProjectName("uds-project"),
+		WithBatch(false),
+		WithGlobalProvider(false),
+	)
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	_, span := tp.Tracer("test").Start(context.Background(), "uds-span")
+	span.End()
+	if err := tp.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	if requests.Load() == 0 {
+		t.Fatal("no export request received over the socket")
+	}
+	if got := gotPath.Load(); got != DefaultHTTPPath {
+		t.Errorf("request path = %v, want %s", got, DefaultHTTPPath)
+	}
+	if got := gotProject.Load(); got != "uds-project" {
+		t.Errorf("project header = %v, want uds-project", got)
+	}
+}
+
+func TestRegisterProjectHeaders(t *testing.T) {
+	var gotAuth, gotProject atomic.Value
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth.Store(r.Header.Get("Authorization"))
+		gotProject.Store(r.Header.Get("x-phoenix-project-name"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	tp, err := Register(
+		WithEndpoint(srv.URL),
+		WithAPIKey("default-key"),
+		WithProjectName("billing"),
+		WithProjectHeaders("billing", map[string]string{"Authorization": "Bearer billing-key"}),
+		WithProjectHeaders("search", map[string]string{"Authorization": "Bearer search-key"}),
+		WithBatch(false),
+		WithGlobalProvider(false),
+	)
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	_, span := tp.Tracer("test").Start(context.Background(), "span")
+	span.End()
+	if err := tp.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	if got := gotProject.Load(); got != "billing" {
+		t.Errorf("project header = %v, want billing", got)
+	}
+	if got := gotAuth.Load(); got != "Bearer billing-key" {
+		t.Errorf("Authorization = %v, want Bearer billing-key", got)
+	}
+}
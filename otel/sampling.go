@@ -0,0 +1,161 @@
+package otel
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// deferredSampler is implemented by samplers that can only make their final
+// decision once a span has ended. Spans their base sampler drops are still
+// recorded, and keep reports whether such a span should be exported anyway.
+type deferredSampler interface {
+	sdktrace.Sampler
+	keep(s sdktrace.ReadOnlySpan) bool
+}
+
+// ErrorSampler samples every span that ends with an error status and
+// delegates all other spans to a base sampler.
+type ErrorSampler struct {
+	base sdktrace.Sampler
+}
+
+// NewErrorSampler returns a sampler that always exports spans ending with
+// codes.Error and otherwise follows base.
+//
+// A span's status is only known when it ends, so spans base drops are
+// recorded rather than discarded and exported if they end with an error.
+// Pass the sampler to Register with WithSampler; used with a tracer provider
+// created elsewhere, it behaves like base. Because the decision is made per
+// span, an exported error span's parent may itself have been dropped.
+func NewErrorSampler(base sdktrace.Sampler) sdktrace.Sampler {
+	return &ErrorSampler{base: base}
+}
+
+// ShouldSample returns the base decision, recording spans base drops.
+func (s *ErrorSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	return recordDropped(s.base.ShouldSample(p))
+}
+
+// Description returns a description of the sampler.
+func (s *ErrorSampler) Description() string {
+	return fmt.Sprintf("ErrorSampler{%s}", s.base.Description())
+}
+
+func (s *ErrorSampler) keep(span sdktrace.ReadOnlySpan) bool {
+	return span.Status().Code == codes.Error || keepDeferred(s.base, span)
+}
+
+// CostSampler samples every span whose LLM cost exceeds a threshold and
+// delegates all other spans to a base sampler.
+type CostSampler struct {
+	base      sdktrace.Sampler
+	threshold float64
+}
+
+// NewCostSampler returns a sampler that always exports spans whose
+// llm.cost_usd attribute is greater than threshold and otherwise follows
+// base.
+//
+// Spans started with the cost attribute are sampled immediately. The cost is
+// usually set later, so spans base drops are recorded rather than discarded
+// and exported if their cost exceeds threshold when they end. The same
+// caveats as for NewErrorSampler apply.
+func NewCostSampler(base sdktrace.Sampler, threshold float64) sdktrace.Sampler {
+	return &CostSampler{base: base, threshold: threshold}
+}
+
+// ShouldSample samples spans whose initial cost exceeds the threshold and
+// returns the base decision for others, recording spans base drops.
+func (s *CostSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	if s.exceeds(p.Attributes) {
+		return sdktrace.SamplingResult{
+			Decision:   sdktrace.RecordAndSample,
+			Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+		}
+	}
+	return recordDropped(s.base.ShouldSample(p))
+}
+
+// Description returns a description of the sampler.
+func (s *CostSampler) Description() string {
+	return fmt.Sprintf("CostSampler{%g,%s}", s.threshold, s.base.Description())
+}
+
+func (s *CostSampler) keep(span sdktrace.ReadOnlySpan) bool {
+	return s.exceeds(span.Attributes()) || keepDeferred(s.base, span)
+}
+
+func (s *CostSampler) exceeds(attrs []attribute.KeyValue) bool {
+	for _, kv := range attrs {
+		if kv.Key != LLMCostUSD {
+			continue
+		}
+		switch kv.Value.Type() {
+		case attribute.FLOAT64:
+			return kv.Value.AsFloat64() > s.threshold
+		case attribute.INT64:
+			return float64(kv.Value.AsInt64()) > s.threshold
+		}
+	}
+	return false
+}
+
+// recordDropped turns a drop decision into a record-only one so the span
+// is available for a decision when it ends.
+func recordDropped(res sdktrace.SamplingResult) sdktrace.SamplingResult {
+	if res.Decision == sdktrace.Drop {
+		res.Decision = sdktrace.RecordOnly
+	}
+	return res
+}
+
+// keepDeferred reports whether a deferred base sampler keeps span.
+func keepDeferred(base sdktrace.Sampler, span sdktrace.ReadOnlySpan) bool {
+	ds, ok := base.(deferredSampler)
+	return ok && ds.keep(span)
+}
+
+// deferredSamplingProcessor forwards sampled spans to the next processor,
+// along with recorded but unsampled spans its sampler decides to keep.
+type deferredSamplingProcessor struct {
+	next    sdktrace.SpanProcessor
+	sampler deferredSampler
+}
+
+func (p *deferredSamplingProcessor) OnStart(ctx context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(ctx, s)
+}
+
+func (p *deferredSamplingProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if s.SpanContext().IsSampled() {
+		p.next.OnEnd(s)
+		return
+	}
+	if p.sampler.keep(s) {
+		p.next.OnEnd(sampledSpan{ReadOnlySpan: s})
+	}
+}
+
+func (p *deferredSamplingProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+func (p *deferredSamplingProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+// sampledSpan marks a recorded span as sampled so that span processors
+// export it.
+type sampledSpan struct {
+	sdktrace.ReadOnlySpan
+}
+
+func (s sampledSpan) SpanContext() trace.SpanContext {
+	sc := s.ReadOnlySpan.SpanContext()
+	return sc.WithTraceFlags(sc.TraceFlags().WithSampled(true))
+}
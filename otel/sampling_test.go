@@ -0,0 +1,161 @@
+package otel
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newSamplingProvider returns a tracer provider wired like Register: spans
+// are exported synchronously to an in-memory exporter, behind a deferred
+// sampling processor if sampler makes deferred decisions.
+func newSamplingProvider(t *testing.T, sampler sdktrace.Sampler) (*sdktrace.TracerProvider, *tracetest.InMemoryExporter) {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	var processor sdktrace.SpanProcessor = sdktrace.NewSimpleSpanProcessor(exporter)
+	if ds, ok := sampler.(deferredSampler); ok {
+		processor = &deferredSamplingProcessor{next: processor, sampler: ds}
+	}
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(sampler), sdktrace.WithSpanProcessor(processor))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+	return tp, exporter
+}
+
+// exportedNames returns the names of the exported spans, which must all be
+// marked as sampled.
+func exportedNames(t *testing.T, exporter *tracetest.InMemoryExporter) []string {
+	t.Helper()
+	var names []string
+	for _, s := range exporter.GetSpans() {
+		if !s.SpanContext.IsSampled() {
+			t.Errorf("span %q exported without the sampled flag", s.Name)
+		}
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+func TestErrorSampler(t *testing.T) {
+	tests := []struct {
+		name string
+		base sdktrace.Sampler
+		want []string
+	}{
+		{"never", sdktrace.NeverSample(), []string{"error"}},
+		{"always", sdktrace.AlwaysSample(), []string{"ok", "error", "unset"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tp, exporter := newSamplingProvider(t, NewErrorSampler(tt.base))
+			tracer := tp.Tracer("test")
+
+			_, ok := tracer.Start(context.Background(), "ok")
+			ok.SetStatus(codes.Ok, "")
+			ok.End()
+			_, failed := tracer.Start(context.Background(), "error")
+			failed.SetStatus(codes.Error, "boom")
+			failed.End()
+			_, unset := tracer.Start(context.Background(), "unset")
+			unset.End()
+
+			got := exportedNames(t, exporter)
+			if len(got) != len(tt.want) {
+				t.Fatalf("exported %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("exported %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestCostSampler(t *testing.T) {
+	tp, exporter := newSamplingProvider(t, NewCostSampler(sdktrace.NeverSample(), 1))
+	tracer := tp.Tracer("test")
+
+	// Spans started with a cost above the threshold are sampled at once.
+	_, initial := tracer.Start(context.Background(), "initial", trace.WithAttributes(attribute.Float64(LLMCostUSD, 2)))
+	if !initial.SpanContext().IsSampled() {
+		t.Error("span started above the threshold is not sampled")
+	}
+	initial.End()
+
+	for _, s := range []struct {
+		name string
+		cost attribute.KeyValue
+	}{
+		{"later", attribute.Float64(LLMCostUSD, 1.5)},
+		{"int", attribute.Int64(LLMCostUSD, 3)},
+		{"cheap", attribute.Float64(LLMCostUSD, 0.5)},
+		{"at threshold", attribute.Float64(LLMCostUSD, 1)},
+		{"not a number", attribute.String(LLMCostUSD, "5")},
+	} {
+		_, span := tracer.Start(context.Background(), s.name)
+		if span.SpanContext().IsSampled() {
+			t.Errorf("span %q sampled before its cost was known", s.name)
+		}
+		span.SetAttributes(s.cost)
+		span.End()
+	}
+
+	got := exportedNames(t, exporter)
+	want := []string{"initial", "later", "int"}
+	if len(got) != len(want) {
+		t.Fatalf("exported %v, want %v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("exported %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestNestedDeferredSamplers(t *testing.T) {
+	tp, exporter := newSamplingProvider(t, NewCostSampler(NewErrorSampler(sdktrace.NeverSample()), 1))
+	tracer := tp.Tracer("test")
+
+	_, failed := tracer.Start(context.Background(), "error")
+	failed.SetStatus(codes.Error, "boom")
+	failed.End()
+	_, ok := tracer.Start(context.Background(), "ok")
+	ok.End()
+
+	if got := exportedNames(t, exporter); len(got) != 1 || got[0] != "error" {
+		t.Errorf("exported %v, want [error]", got)
+	}
+}
+
+func TestErrorSamplerWithoutRegister(t *testing.T) {
+	// Without the deferred sampling processor, the sampler behaves like base.
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(NewErrorSampler(sdktrace.NeverSample())),
+		sdktrace.WithSyncer(exporter),
+	)
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	_, span := tp.Tracer("test").Start(context.Background(), "error")
+	span.SetStatus(codes.Error, "boom")
+	span.End()
+	if n := len(exporter.GetSpans()); n != 0 {
+		t.Errorf("exported %d spans, want 0", n)
+	}
+}
+
+func TestSamplerDescriptions(t *testing.T) {
+	if got, want := NewErrorSampler(sdktrace.AlwaysSample()).Description(), "ErrorSampler{AlwaysOnSampler}"; got != want {
+		t.Errorf("Description = %q, want %q", got, want)
+	}
+	if got, want := NewCostSampler(sdktrace.NeverSample(), 0.5).Description(), "CostSampler{0.5,AlwaysOffSampler}"; got != want {
+		t.Errorf("Description = %q, want %q", got, want)
+	}
+}
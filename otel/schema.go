@@ -0,0 +1,239 @@
+package otel
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// DefaultSchemaVersion is the OpenInference schema version used when none is given.
+const DefaultSchemaVersion = "v1"
+
+//go:embed schemas/openinference-*.json
+var schemaFS embed.FS
+
+// schemaFile is the JSON layout of an embedded OpenInference schema.
+// Attribute names may contain {i} placeholders for list indexes.
+type schemaFile struct {
+	Version    string                     `json:"version"`
+	Namespaces []string                   `json:"namespaces"`
+	Attributes map[string]schemaAttribute `json:"attributes"`
+}
+
+type schemaAttribute struct {
+	Type string   `json:"type"`
+	Enum []string `json:"enum"`
+}
+
+type schemaPattern struct {
+	re   *regexp.Regexp
+	attr schemaAttribute
+}
+
+// ValidationError describes an attribute that does not match the schema.
+// SpanName is empty when the attribute was validated outside a span, as by
+// MetadataSchema.Validate.
+type ValidationError struct {
+	SpanName  string
+	Attribute string
+	Message   string
+}
+
+func (e ValidationError) Error() string {
+	if e.SpanName == "" {
+		return fmt.Sprintf("attribute %q: %s", e.Attribute, e.Message)
+	}
+	return fmt.Sprintf("span %q: attribute %q: %s", e.SpanName, e.Attribute, e.Message)
+}
+
+// AttributeSchemaValidator checks span attributes against an OpenInference
+// schema. Attributes outside the OpenInference namespaces (for example
+// http.* or custom keys) are not checked.
+type AttributeSchemaValidator struct {
+	version    string
+	namespaces []string
+	exact      map[string]schemaAttribute
+	patterns   []schemaPattern
+}
+
+// SchemaVersions returns the embedded OpenInference schema versions.
+func SchemaVersions() []string {
+	entries, _ := schemaFS.ReadDir("schemas")
+	versions := make([]string, 0, len(entries))
+	for _, e := range entries {
+		name := strings.TrimSuffix(strings.TrimPrefix(e.Name(), "openinference-"), ".json")
+		versions = append(versions, name)
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// NewAttributeSchemaValidator returns a validator for the given embedded
+// schema version, or DefaultSchemaVersion if version is empty.
+// It panics if the version is not embedded; see SchemaVersions.
+func NewAttributeSchemaValidator(schemaVersion string) *AttributeSchemaValidator {
+	v, err := loadSchemaValidator(schemaVersion)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func loadSchemaValidator(version string) (*AttributeSchemaValidator, error) {
+	if version == "" {
+		version = DefaultSchemaVersion
+	}
+	data, err := schemaFS.ReadFile("schemas/openinference-" + version + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("unknown OpenInference schema version %q (available: %s)", version, strings.Join(SchemaVersions(), ", "))
+	}
+
+	var f schemaFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parse OpenInference schema %q: %w", version, err)
+	}
+
+	v := &AttributeSchemaValidator{
+		version:    version,
+		namespaces: f.Namespaces,
+		exact:      make(map[string]schemaAttribute),
+	}
+	for name, attr := range f.Attributes {
+		if !strings.Contains(name, "{i}") {
+			v.exact[name] = attr
+			continue
+		}
+		expr := "^" + strings.ReplaceAll(regexp.QuoteMeta(name), `\{i\}`, `\d+`) + "$"
+		v.patterns = append(v.patterns, schemaPattern{re: regexp.MustCompile(expr), attr: attr})
+	}
+	return v, nil
+}
+
+// Version returns the schema version.
+func (v *AttributeSchemaValidator) Version() string {
+	return v.version
+}
+
+// Validate checks the attributes of span and returns one error per
+// unknown OpenInference attribute, wrong value type or disallowed value.
+func (v *AttributeSchemaValidator) Validate(span sdktrace.ReadOnlySpan) []ValidationError {
+	return v.ValidateAttributes(span.Name(), span.Attributes())
+}
+
+// ValidateAttributes checks attributes as Validate does, for spans that
+// are not available as sdktrace.ReadOnlySpan.
+func (v *AttributeSchemaValidator) ValidateAttributes(spanName string, attrs []attribute.KeyValue) []ValidationError {
+	var errs []ValidationError
+	for _, kv := range attrs {
+		key := string(kv.Key)
+		attr, ok := v.lookup(key)
+		if !ok {
+			if v.inNamespace(key) {
+				errs = append(errs, ValidationError{SpanName: spanName, Attribute: key, Message: "unknown OpenInference attribute"})
+			}
+			continue
+		}
+		if !typeMatches(attr.Type, kv.Value.Type()) {
+			errs = append(errs, ValidationError{
+				SpanName:  spanName,
+				Attribute: key,
+				Message:   fmt.Sprintf("expected %s, got %s", attr.Type, strings.ToLower(kv.Value.Type().String())),
+			})
+			continue
+		}
+		if len(attr.Enum) > 0 && !contains(attr.Enum, kv.Value.AsString()) {
+			errs = append(errs, ValidationError{
+				SpanName:  spanName,
+				Attribute: key,
+				Message:   fmt.Sprintf("value %q not in %v", kv.Value.AsString(), attr.Enum),
+			})
+		}
+	}
+	return errs
+}
+
+func (v *AttributeSchemaValidator) lookup(key string) (schemaAttribute, bool) {
+	if attr, ok := v.exact[key]; ok {
+		return attr, true
+	}
+	for _, p := range v.patterns {
+		if p.re.MatchString(key) {
+			return p.attr, true
+		}
+	}
+	return schemaAttribute{}, false
+}
+
+func (v *AttributeSchemaValidator) inNamespace(key string) bool {
+	for _, ns := range v.namespaces {
+		if strings.HasPrefix(key, ns) {
+			return true
+		}
+	}
+	return false
+}
+
+// typeMatches reports whether an attribute value type satisfies a schema type.
+// Integers are accepted where floats are expected.
+func typeMatches(schemaType string, t attribute.Type) bool {
+	switch schemaType {
+	case "string":
+		return t == attribute.STRING
+	case "int":
+		return t == attribute.INT64
+	case "float":
+		return t == attribute.FLOAT64 || t == attribute.INT64
+	case "bool":
+		return t == attribute.BOOL
+	case "string[]":
+		return t == attribute.STRINGSLICE
+	case "int[]":
+		return t == attribute.INT64SLICE
+	case "float[]":
+		return t == attribute.FLOAT64SLICE || t == attribute.INT64SLICE
+	case "bool[]":
+		return t == attribute.BOOLSLICE
+	default:
+		return true
+	}
+}
+
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// schemaValidationProcessor logs a warning for every schema violation on
+// ended spans. It never drops or modifies spans.
+type schemaValidationProcessor struct {
+	validator *AttributeSchemaValidator
+}
+
+func (p *schemaValidationProcessor) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+func (p *schemaValidationProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	for _, err := range p.validator.Validate(s) {
+		slog.Warn("phoenix: OpenInference schema violation",
+			"span", err.SpanName,
+			"attribute", err.Attribute,
+			"error", err.Message,
+			"schema", p.validator.version,
+		)
+	}
+}
+
+func (p *schemaValidationProcessor) Shutdown(context.Context) error { return nil }
+
+func (p *schemaValidationProcessor) ForceFlush(context.Context) error { return nil }
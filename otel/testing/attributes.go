@@ -0,0 +1,27 @@
+package oteltesting
+
+import (
+	"testing"
+
+	phoenixotel "github.com/agentplexus/go-phoenix/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// AttributedSpan is a span whose attributes can be read, such as
+// sdktrace.ReadOnlySpan or a span stub's Snapshot.
+type AttributedSpan interface {
+	Name() string
+	Attributes() []attribute.KeyValue
+}
+
+// AssertAttributeSize fails t for each attribute of span whose value is
+// larger than maxBytes. Values are measured as by
+// phoenixotel.AttributeValueSize.
+func AssertAttributeSize(t testing.TB, span AttributedSpan, maxBytes int) {
+	t.Helper()
+	for _, kv := range span.Attributes() {
+		if size := phoenixotel.AttributeValueSize(kv.Value); size > maxBytes {
+			t.Errorf("span %q: attribute %q is %d bytes, want at most %d", span.Name(), kv.Key, size, maxBytes)
+		}
+	}
+}
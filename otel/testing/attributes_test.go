@@ -0,0 +1,44 @@
+package oteltesting
+
+import (
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recordingTB records the errors reported to it.
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, format)
+}
+
+func TestAssertAttributeSize(t *testing.T) {
+	span := tracetest.SpanStub{
+		Name: "llm",
+		Attributes: []attribute.KeyValue{
+			attribute.String("input.value", strings.Repeat("x", 100)),
+			attribute.StringSlice("tags", []string{"a", "b"}),
+			attribute.Int("count", 12345),
+		},
+	}.Snapshot()
+
+	tb := &recordingTB{TB: t}
+	AssertAttributeSize(tb, span, 100)
+	if len(tb.errors) != 0 {
+		t.Errorf("got %d errors at the limit, want 0", len(tb.errors))
+	}
+
+	tb = &recordingTB{TB: t}
+	AssertAttributeSize(tb, span, 4)
+	if len(tb.errors) != 2 {
+		t.Errorf("got %d errors over the limit, want 2", len(tb.errors))
+	}
+}
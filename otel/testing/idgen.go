@@ -0,0 +1,55 @@
+// Package oteltesting provides helpers for deterministic tests of code
+// instrumented with the Phoenix OTEL integration.
+package oteltesting
+
+import (
+	"context"
+	"encoding/binary"
+	"sync"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// sequentialIDGenerator issues trace and span IDs from two counters.
+type sequentialIDGenerator struct {
+	mu       sync.Mutex
+	traceSeq uint64
+	spanSeq  uint64
+}
+
+// NewSequentialIDGenerator returns an ID generator that issues incrementing
+// IDs starting at 1: span IDs 0000000000000001, 0000000000000002, ... and
+// trace IDs 00000000000000000000000000000001, ... Use it with
+// otel.WithIDGenerator for golden-file tests of span output. It is safe for
+// concurrent use, but IDs are only reproducible when spans start in a
+// deterministic order.
+func NewSequentialIDGenerator() sdktrace.IDGenerator {
+	return &sequentialIDGenerator{}
+}
+
+// NewIDs returns the next trace ID and span ID.
+func (g *sequentialIDGenerator) NewIDs(context.Context) (trace.TraceID, trace.SpanID) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.traceSeq++
+	g.spanSeq++
+
+	var tid trace.TraceID
+	binary.BigEndian.PutUint64(tid[8:], g.traceSeq)
+	return tid, g.spanID()
+}
+
+// NewSpanID returns the next span ID.
+func (g *sequentialIDGenerator) NewSpanID(context.Context, trace.TraceID) trace.SpanID {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.spanSeq++
+	return g.spanID()
+}
+
+func (g *sequentialIDGenerator) spanID() trace.SpanID {
+	var sid trace.SpanID
+	binary.BigEndian.PutUint64(sid[:], g.spanSeq)
+	return sid
+}
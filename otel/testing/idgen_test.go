@@ -0,0 +1,63 @@
+package oteltesting
+
+import (
+	"context"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSequentialIDGenerator(t *testing.T) {
+	gen := NewSequentialIDGenerator()
+	ctx := context.Background()
+
+	tid, sid := gen.NewIDs(ctx)
+	if got, want := tid.String(), "00000000000000000000000000000001"; got != want {
+		t.Errorf("trace ID = %s, want %s", got, want)
+	}
+	if got, want := sid.String(), "0000000000000001"; got != want {
+		t.Errorf("span ID = %s, want %s", got, want)
+	}
+
+	if got, want := gen.NewSpanID(ctx, tid).String(), "0000000000000002"; got != want {
+		t.Errorf("span ID = %s, want %s", got, want)
+	}
+
+	tid, sid = gen.NewIDs(ctx)
+	if got, want := tid.String(), "00000000000000000000000000000002"; got != want {
+		t.Errorf("trace ID = %s, want %s", got, want)
+	}
+	if got, want := sid.String(), "0000000000000003"; got != want {
+		t.Errorf("span ID = %s, want %s", got, want)
+	}
+}
+
+func TestSequentialIDGenerator_TracerProvider(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(exporter),
+		sdktrace.WithIDGenerator(NewSequentialIDGenerator()),
+	)
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	tracer := tp.Tracer("test")
+	ctx, parent := tracer.Start(context.Background(), "parent")
+	_, child := tracer.Start(ctx, "child")
+	child.End()
+	parent.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	want := map[string]string{
+		"parent": "0000000000000001",
+		"child":  "0000000000000002",
+	}
+	for _, s := range spans {
+		if got := s.SpanContext.SpanID().String(); got != want[s.Name] {
+			t.Errorf("span %q ID = %s, want %s", s.Name, got, want[s.Name])
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package otel
+
+import (
+	"fmt"
+	"net/http"
+
+	"golang.org/x/net/http2"
+)
+
+// TransportConfig holds connection pooling settings for the HTTP transport
+// used to export spans. See WithHTTPTransport.
+type TransportConfig struct {
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	MaxConnsPerHost     int
+	HTTP2               bool
+}
+
+// NewHTTPTransport builds an http.Transport from the default transport with
+// the given connection limits. A limit of zero means no limit, as in
+// http.Transport. When h2 is true, HTTP/2 is configured explicitly with
+// golang.org/x/net/http2.
+//
+// Recommended settings:
+//   - Low traffic (a few spans per second): the defaults are sufficient.
+//   - Moderate traffic (hundreds of spans per second): 100, 20, 0, false.
+//   - High traffic (thousands of spans per second): 500, 100, 100, true.
+func NewHTTPTransport(maxIdleConns, maxIdleConnsPerHost, maxConnsPerHost int, h2 bool) (*http.Transport, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.MaxIdleConns = maxIdleConns
+	t.MaxIdleConnsPerHost = maxIdleConnsPerHost
+	t.MaxConnsPerHost = maxConnsPerHost
+	if h2 {
+		if err := http2.ConfigureTransport(t); err != nil {
+			return nil, fmt.Errorf("failed to configure HTTP/2: %w", err)
+		}
+	}
+	return t, nil
+}
+
+func (c *TransportConfig) build() (*http.Transport, error) {
+	return NewHTTPTransport(c.MaxIdleConns, c.MaxIdleConnsPerHost, c.MaxConnsPerHost, c.HTTP2)
+}
+
+// projectNameHeader carries the project name of an export request.
+const projectNameHeader = "x-phoenix-project-name"
+
+// projectHeaderTransport replaces the headers of export requests with those
+// configured for the request's project. See WithProjectHeaders.
+type projectHeaderTransport struct {
+	base    *http.Transport
+	headers map[string]map[string]string
+}
+
+func (t *projectHeaderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	headers, ok := t.headers[req.Header.Get(projectNameHeader)]
+	if !ok {
+		return t.base.RoundTrip(req)
+	}
+
+	// A RoundTripper must not modify the request it is given.
+	req = req.Clone(req.Context())
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// CloseIdleConnections closes the idle connections of the base transport.
+func (t *projectHeaderTransport) CloseIdleConnections() {
+	t.base.CloseIdleConnections()
+}
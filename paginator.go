@@ -0,0 +1,116 @@
+package phoenix
+
+import "context"
+
+// Paginator steps through the pages of a list operation one call at a
+// time, for callers that want control over when each page is fetched:
+//
+//	p := phoenix.NewProjectPaginator(client)
+//	for p.Next(ctx) {
+//		for _, project := range p.Items() {
+//			...
+//		}
+//	}
+//	if err := p.Err(); err != nil {
+//		...
+//	}
+type Paginator[T any] struct {
+	fetch  func(ctx context.Context, cursor string) ([]T, string, error)
+	items  []T
+	cursor string
+	done   bool
+	err    error
+}
+
+// NewPaginator returns a Paginator over the pages returned by fetch, which
+// is called with the cursor of the page to fetch (empty for the first page)
+// and returns the page and the cursor of the next one (empty on the last
+// page).
+func NewPaginator[T any](fetch func(cursor string) ([]T, string, error)) *Paginator[T] {
+	return newPaginator(func(_ context.Context, cursor string) ([]T, string, error) {
+		return fetch(cursor)
+	})
+}
+
+func newPaginator[T any](fetch func(ctx context.Context, cursor string) ([]T, string, error)) *Paginator[T] {
+	return &Paginator[T]{fetch: fetch}
+}
+
+// Next fetches the next page and reports whether there was one. It returns
+// false once the last page has been fetched, or when fetching fails; check
+// Err to tell the two apart.
+func (p *Paginator[T]) Next(ctx context.Context) bool {
+	if p.done || p.err != nil {
+		return false
+	}
+	if err := ctx.Err(); err != nil {
+		p.err = err
+		return false
+	}
+
+	items, next, err := p.fetch(ctx, p.cursor)
+	if err != nil {
+		p.err = err
+		p.items = nil
+		return false
+	}
+	p.items = items
+	p.cursor = next
+	p.done = next == ""
+	return true
+}
+
+// Items returns the page fetched by the last call to Next.
+func (p *Paginator[T]) Items() []T {
+	return p.items
+}
+
+// Err returns the error that stopped Next, if any.
+func (p *Paginator[T]) Err() error {
+	return p.err
+}
+
+// Cursor returns the cursor of the page the next call to Next fetches. It
+// is empty before the first page and after the last one, and can be passed
+// to WithCursor to resume listing later.
+func (p *Paginator[T]) Cursor() string {
+	return p.cursor
+}
+
+// NewProjectPaginator returns a Paginator over ListProjects.
+func NewProjectPaginator(client *Client, opts ...ListOption) *Paginator[*Project] {
+	return newPaginator(func(ctx context.Context, cursor string) ([]*Project, string, error) {
+		return client.ListProjects(ctx, withPageCursor(opts, cursor)...)
+	})
+}
+
+// NewDatasetPaginator returns a Paginator over ListDatasets.
+func NewDatasetPaginator(client *Client, opts ...ListOption) *Paginator[*Dataset] {
+	return newPaginator(func(ctx context.Context, cursor string) ([]*Dataset, string, error) {
+		return client.ListDatasets(ctx, withPageCursor(opts, cursor)...)
+	})
+}
+
+// NewPromptPaginator returns a Paginator over ListPrompts.
+func NewPromptPaginator(client *Client, opts ...ListOption) *Paginator[*Prompt] {
+	return newPaginator(func(ctx context.Context, cursor string) ([]*Prompt, string, error) {
+		return client.ListPrompts(ctx, withPageCursor(opts, cursor)...)
+	})
+}
+
+// NewAnnotationPaginator returns a Paginator over ListSpanAnnotations for
+// the given spans.
+func NewAnnotationPaginator(client *Client, spanIDs []string, opts ...ListOption) *Paginator[*Annotation] {
+	return newPaginator(func(ctx context.Context, cursor string) ([]*Annotation, string, error) {
+		return client.ListSpanAnnotations(ctx, spanIDs, withPageCursor(opts, cursor)...)
+	})
+}
+
+// withPageCursor returns opts followed by the cursor of the page to fetch.
+// A cursor passed in opts is used for the first page only.
+func withPageCursor(opts []ListOption, cursor string) []ListOption {
+	if cursor == "" {
+		return opts
+	}
+	return append(append([]ListOption{}, opts...), WithCursor(cursor))
+}
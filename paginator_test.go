@@ -0,0 +1,105 @@
+package phoenix
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// pagedFetch returns a fetch function serving pages, where the cursor of
+// page i is its index as a string.
+func pagedFetch(pages [][]int) func(cursor string) ([]int, string, error) {
+	return func(cursor string) ([]int, string, error) {
+		i := 0
+		if cursor != "" {
+			i = int(cursor[0] - '0')
+		}
+		next := ""
+		if i+1 < len(pages) {
+			next = string(rune('0' + i + 1))
+		}
+		return pages[i], next, nil
+	}
+}
+
+func TestPaginatorSinglePage(t *testing.T) {
+	p := NewPaginator(pagedFetch([][]int{{1, 2, 3}}))
+
+	if !p.Next(t.Context()) {
+		t.Fatalf("Next() = false, want true (err %v)", p.Err())
+	}
+	if got := p.Items(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Items() = %v, want [1 2 3]", got)
+	}
+	if got := p.Cursor(); got != "" {
+		t.Errorf("Cursor() = %q, want empty", got)
+	}
+	if p.Next(t.Context()) {
+		t.Error("Next() = true after the last page")
+	}
+	if err := p.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+}
+
+func TestPaginatorMultiPage(t *testing.T) {
+	p := NewPaginator(pagedFetch([][]int{{1, 2}, {3, 4}, {5}}))
+
+	var all []int
+	pages := 0
+	for p.Next(t.Context()) {
+		pages++
+		all = append(all, p.Items()...)
+		if pages == 1 && p.Cursor() != "1" {
+			t.Errorf("Cursor() after first page = %q, want %q", p.Cursor(), "1")
+		}
+	}
+	if err := p.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+	if pages != 3 {
+		t.Errorf("got %d pages, want 3", pages)
+	}
+	if !slices.Equal(all, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("items = %v, want [1 2 3 4 5]", all)
+	}
+}
+
+func TestPaginatorError(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+	calls := 0
+	p := NewPaginator(func(cursor string) ([]int, string, error) {
+		calls++
+		if cursor == "" {
+			return []int{1}, "next", nil
+		}
+		return nil, "", errFetch
+	})
+
+	if !p.Next(t.Context()) {
+		t.Fatalf("first Next() = false (err %v)", p.Err())
+	}
+	if p.Next(t.Context()) {
+		t.Fatal("second Next() = true, want false")
+	}
+	if !errors.Is(p.Err(), errFetch) {
+		t.Errorf("Err() = %v, want %v", p.Err(), errFetch)
+	}
+	if p.Next(t.Context()) || calls != 2 {
+		t.Errorf("Next() fetched again after an error (%d calls)", calls)
+	}
+}
+
+func TestPaginatorCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	p := NewPaginator(pagedFetch([][]int{{1}}))
+	if p.Next(ctx) {
+		t.Fatal("Next() = true with a canceled context")
+	}
+	if !errors.Is(p.Err(), context.Canceled) {
+		t.Errorf("Err() = %v, want context.Canceled", p.Err())
+	}
+}
@@ -2,6 +2,10 @@ package phoenix
 
 import (
 	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/agentplexus/go-phoenix/internal/api"
@@ -18,6 +22,7 @@ type Project struct {
 
 // ListProjects lists all projects.
 func (c *Client) ListProjects(ctx context.Context, opts ...ListOption) ([]*Project, string, error) { //nolint:dupl // Type-safe pattern differs only in types
+	ctx = withRequestIDRecorder(ctx)
 	options := defaultListOptions()
 	for _, opt := range opts {
 		opt(options)
@@ -38,12 +43,16 @@ func (c *Client) ListProjects(ctx context.Context, opts ...ListOption) ([]*Proje
 
 	resp, ok := res.(*api.GetProjectsResponseBody)
 	if !ok {
-		return nil, "", &APIError{Message: "unexpected response type"}
+		return nil, "", c.unexpectedResponseError(ctx)
 	}
 
 	projects := make([]*Project, 0, len(resp.Data))
 	for i := range resp.Data {
-		projects = append(projects, convertProject(&resp.Data[i]))
+		project := convertProject(&resp.Data[i])
+		if !strings.HasPrefix(project.Name, options.namePrefix) || !strings.Contains(project.Name, options.nameContains) {
+			continue
+		}
+		projects = append(projects, project)
 	}
 
 	var nextCursor string
@@ -56,6 +65,7 @@ func (c *Client) ListProjects(ctx context.Context, opts ...ListOption) ([]*Proje
 
 // GetProject retrieves a project by identifier (ID or name).
 func (c *Client) GetProject(ctx context.Context, identifier string) (*Project, error) {
+	ctx = withRequestIDRecorder(ctx)
 	res, err := c.apiClient.GetProject(ctx, api.GetProjectParams{
 		ProjectIdentifier: identifier,
 	})
@@ -63,19 +73,22 @@ func (c *Client) GetProject(ctx context.Context, identifier string) (*Project, e
 		return nil, err
 	}
 
-	resp, ok := res.(*api.GetProjectResponseBody)
-	if !ok {
-		return nil, &APIError{Message: "unexpected response type"}
+	switch resp := res.(type) {
+	case *api.GetProjectResponseBody:
+		return &Project{
+			ID:   resp.Data.ID,
+			Name: resp.Data.Name,
+		}, nil
+	case *api.GetProjectNotFound:
+		return nil, ErrProjectNotFound
+	default:
+		return nil, c.unexpectedResponseError(ctx)
 	}
-
-	return &Project{
-		ID:   resp.Data.ID,
-		Name: resp.Data.Name,
-	}, nil
 }
 
 // CreateProject creates a new project.
 func (c *Client) CreateProject(ctx context.Context, name string, opts ...ProjectOption) (*Project, error) {
+	ctx = withRequestIDRecorder(ctx)
 	options := &projectOptions{}
 	for _, opt := range opts {
 		opt(options)
@@ -95,8 +108,9 @@ func (c *Client) CreateProject(ctx context.Context, name string, opts ...Project
 
 	resp, ok := res.(*api.CreateProjectResponseBody)
 	if !ok {
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, c.unexpectedResponseError(ctx)
 	}
+	c.projects.invalidate()
 
 	return &Project{
 		ID:   resp.Data.ID,
@@ -109,9 +123,77 @@ func (c *Client) DeleteProject(ctx context.Context, identifier string) error {
 	_, err := c.apiClient.DeleteProject(ctx, api.DeleteProjectParams{
 		ProjectIdentifier: identifier,
 	})
+	c.projects.invalidate()
 	return err
 }
 
+// projectListCacheTTL is how long SearchProjects caches the project list.
+const projectListCacheTTL = 60 * time.Second
+
+// projectListCache holds the full project list fetched by SearchProjects.
+type projectListCache struct {
+	mu       sync.Mutex
+	projects []*Project
+	expires  time.Time
+}
+
+func (pc *projectListCache) invalidate() {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	pc.projects = nil
+}
+
+// SearchProjects returns the projects whose name matches the regular
+// expression namePattern.
+//
+// Phoenix has no project search, so this pages through every project and
+// matches names on the client. The full project list is cached for 60
+// seconds, or until the client creates or deletes a project, so repeated
+// searches do not list the projects again.
+func (c *Client) SearchProjects(ctx context.Context, namePattern string) ([]*Project, error) {
+	re, err := regexp.Compile(namePattern)
+	if err != nil {
+		return nil, fmt.Errorf("%w: name pattern: %w", ErrInvalidInput, err)
+	}
+
+	all, err := c.allProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*Project
+	for _, p := range all {
+		if re.MatchString(p.Name) {
+			project := *p
+			matches = append(matches, &project)
+		}
+	}
+	return matches, nil
+}
+
+// allProjects returns every project, from the cache when it is fresh.
+func (c *Client) allProjects(ctx context.Context) ([]*Project, error) {
+	c.projects.mu.Lock()
+	defer c.projects.mu.Unlock()
+
+	if c.projects.projects != nil && time.Now().Before(c.projects.expires) {
+		return c.projects.projects, nil
+	}
+
+	all, err := collectPages(func(cursor string) ([]*Project, string, error) {
+		return c.ListProjects(ctx, WithCursor(cursor))
+	})
+	if err != nil {
+		return nil, err
+	}
+	if all == nil {
+		all = []*Project{}
+	}
+	c.projects.projects = all
+	c.projects.expires = time.Now().Add(projectListCacheTTL)
+	return all, nil
+}
+
 // ProjectOption is a functional option for project operations.
 type ProjectOption func(*projectOptions)
 
@@ -0,0 +1,125 @@
+package phoenix
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+)
+
+// projectsMux serves two pages of projects and counts list requests.
+func projectsMux(t *testing.T, lists *int) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/projects", func(w http.ResponseWriter, r *http.Request) {
+		*lists++
+		if r.URL.Query().Get("cursor") == "" {
+			writeJSON(t, w, pageJSON([]map[string]any{
+				{"id": "p1", "name": "search-prod"},
+				{"id": "p2", "name": "search-staging"},
+			}, "page2"))
+			return
+		}
+		writeJSON(t, w, pageJSON([]map[string]any{
+			{"id": "p3", "name": "billing-prod"},
+			{"id": "p4", "name": "default"},
+		}, ""))
+	})
+	mux.HandleFunc("POST /v1/projects", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, map[string]any{"data": map[string]any{"id": "p5", "name": "new"}})
+	})
+	return mux
+}
+
+// projectNames returns the names of projects.
+func projectNames(projects []*Project) []string {
+	names := make([]string, 0, len(projects))
+	for _, p := range projects {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+func TestListProjectsNameFilters(t *testing.T) {
+	var lists int
+	c := newTestClient(t, projectsMux(t, &lists))
+
+	tests := []struct {
+		name string
+		opts []ListOption
+		want []string
+	}{
+		{"prefix", []ListOption{WithProjectNamePrefix("search-")}, []string{"search-prod", "search-staging"}},
+		{"contains", []ListOption{WithProjectNameContains("prod")}, []string{"search-prod"}},
+		{"both", []ListOption{WithProjectNamePrefix("search"), WithProjectNameContains("staging")}, []string{"search-staging"}},
+		{"no match", []ListOption{WithProjectNamePrefix("billing")}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projects, next, err := c.ListProjects(t.Context(), tt.opts...)
+			if err != nil {
+				t.Fatalf("ListProjects: %v", err)
+			}
+			if got := projectNames(projects); !slices.Equal(got, tt.want) {
+				t.Errorf("ListProjects = %v, want %v", got, tt.want)
+			}
+			// Filtering is per page, so the next cursor is kept.
+			if next != "page2" {
+				t.Errorf("next cursor = %q, want page2", next)
+			}
+		})
+	}
+}
+
+func TestSearchProjects(t *testing.T) {
+	var lists int
+	c := newTestClient(t, projectsMux(t, &lists))
+
+	projects, err := c.SearchProjects(t.Context(), "-prod$")
+	if err != nil {
+		t.Fatalf("SearchProjects: %v", err)
+	}
+	if got := projectNames(projects); !slices.Equal(got, []string{"search-prod", "billing-prod"}) {
+		t.Errorf("SearchProjects = %v, want [search-prod billing-prod]", got)
+	}
+	if lists != 2 {
+		t.Errorf("listed %d pages, want 2", lists)
+	}
+
+	// Later searches use the cached list; results are copies.
+	projects[0].Name = "changed"
+	projects, err = c.SearchProjects(t.Context(), "^search")
+	if err != nil {
+		t.Fatalf("SearchProjects: %v", err)
+	}
+	if got := projectNames(projects); !slices.Equal(got, []string{"search-prod", "search-staging"}) {
+		t.Errorf("SearchProjects = %v, want [search-prod search-staging]", got)
+	}
+	if lists != 2 {
+		t.Errorf("listed %d pages with a fresh cache, want 2", lists)
+	}
+
+	// Creating a project invalidates the cache.
+	if _, err := c.CreateProject(t.Context(), "new"); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	if _, err := c.SearchProjects(t.Context(), "."); err != nil {
+		t.Fatalf("SearchProjects: %v", err)
+	}
+	if lists != 4 {
+		t.Errorf("listed %d pages after CreateProject, want 4", lists)
+	}
+
+	// So does expiry.
+	c.projects.expires = time.Now().Add(-time.Second)
+	if _, err := c.SearchProjects(t.Context(), "."); err != nil {
+		t.Fatalf("SearchProjects: %v", err)
+	}
+	if lists != 6 {
+		t.Errorf("listed %d pages after the cache expired, want 6", lists)
+	}
+
+	if _, err := c.SearchProjects(t.Context(), "("); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("SearchProjects(invalid pattern) = %v, want %v", err, ErrInvalidInput)
+	}
+}
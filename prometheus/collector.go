@@ -0,0 +1,92 @@
+// Package prometheus exposes the operational statistics of a Phoenix
+// client and tracer provider as Prometheus metrics.
+//
+//	reg := prometheus.NewRegistry()
+//	if err := phoenixprom.RegisterCollector(reg, client, tp); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// Metrics are read from Client.DiagnosticDump and
+// TracerProvider.DiagnosticDump each time the registry is scraped.
+package prometheus
+
+import (
+	"github.com/agentplexus/go-phoenix"
+	phoenixotel "github.com/agentplexus/go-phoenix/otel"
+	promclient "github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	spansQueuedDesc = promclient.NewDesc(
+		"phoenix_sdk_spans_queued",
+		"Number of ended spans not yet handed to the exporter.",
+		nil, nil,
+	)
+	spansExportedDesc = promclient.NewDesc(
+		"phoenix_sdk_spans_exported_total",
+		"Number of spans handed to the exporter.",
+		nil, nil,
+	)
+	exportErrorsDesc = promclient.NewDesc(
+		"phoenix_sdk_export_errors_total",
+		"Number of span export calls that failed.",
+		nil, nil,
+	)
+	exportLatencyDesc = promclient.NewDesc(
+		"phoenix_sdk_export_latency_seconds",
+		"Duration of the most recent span export call.",
+		nil, nil,
+	)
+	apiCallsDesc = promclient.NewDesc(
+		"phoenix_sdk_api_calls_total",
+		"Number of Phoenix API calls made by the client, not counting retries.",
+		nil, nil,
+	)
+)
+
+// collector implements promclient.Collector over a client and a tracer
+// provider.
+type collector struct {
+	client *phoenix.Client
+	tp     *phoenixotel.TracerProvider
+}
+
+// NewCollector returns a collector reporting span export statistics from
+// tp and API call statistics from client. Either may be nil, in which case
+// its metrics are not reported.
+func NewCollector(client *phoenix.Client, tp *phoenixotel.TracerProvider) promclient.Collector {
+	return &collector{client: client, tp: tp}
+}
+
+// RegisterCollector registers a collector created by NewCollector with reg.
+func RegisterCollector(reg promclient.Registerer, client *phoenix.Client, tp *phoenixotel.TracerProvider) error {
+	return reg.Register(NewCollector(client, tp))
+}
+
+// Describe implements promclient.Collector.
+func (c *collector) Describe(ch chan<- *promclient.Desc) {
+	if c.tp != nil {
+		ch <- spansQueuedDesc
+		ch <- spansExportedDesc
+		ch <- exportErrorsDesc
+		ch <- exportLatencyDesc
+	}
+	if c.client != nil {
+		ch <- apiCallsDesc
+	}
+}
+
+// Collect implements promclient.Collector.
+func (c *collector) Collect(ch chan<- promclient.Metric) {
+	if c.tp != nil {
+		info := c.tp.DiagnosticDump()
+		ch <- promclient.MustNewConstMetric(spansQueuedDesc, promclient.GaugeValue, float64(info.QueueDepth))
+		ch <- promclient.MustNewConstMetric(spansExportedDesc, promclient.CounterValue, float64(info.ExportedSpans))
+		ch <- promclient.MustNewConstMetric(exportErrorsDesc, promclient.CounterValue, float64(info.ExportErrors))
+		ch <- promclient.MustNewConstMetric(exportLatencyDesc, promclient.GaugeValue, info.LastExportLatency.Seconds())
+	}
+	if c.client != nil {
+		info := c.client.DiagnosticDump()
+		ch <- promclient.MustNewConstMetric(apiCallsDesc, promclient.CounterValue, float64(info.TotalCallsMade))
+	}
+}
@@ -0,0 +1,129 @@
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/agentplexus/go-phoenix"
+	phoenixotel "github.com/agentplexus/go-phoenix/otel"
+	promclient "github.com/prometheus/client_golang/prometheus"
+)
+
+// gather returns the value of each metric gathered from reg by name.
+func gather(t *testing.T, reg *promclient.Registry) map[string]float64 {
+	t.Helper()
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+	values := make(map[string]float64)
+	for _, mf := range families {
+		for _, m := range mf.GetMetric() {
+			switch {
+			case m.GetCounter() != nil:
+				values[mf.GetName()] = m.GetCounter().GetValue()
+			case m.GetGauge() != nil:
+				values[mf.GetName()] = m.GetGauge().GetValue()
+			}
+		}
+	}
+	return values
+}
+
+func TestCollector(t *testing.T) {
+	var failExports atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/projects" {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": []any{}, "next_cursor": nil})
+			return
+		}
+		if failExports.Load() {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := phoenix.NewClient(phoenix.WithURL(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	tp, err := phoenixotel.Register(
+		phoenixotel.WithEndpoint(srv.URL),
+		phoenixotel.WithBatch(false),
+		phoenixotel.WithGlobalProvider(false),
+	)
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	reg := promclient.NewRegistry()
+	if err := RegisterCollector(reg, client, tp); err != nil {
+		t.Fatalf("RegisterCollector: %v", err)
+	}
+
+	tracer := tp.Tracer("test")
+	for range 2 {
+		_, span := tracer.Start(context.Background(), "span")
+		span.End()
+	}
+	failExports.Store(true)
+	_, span := tracer.Start(context.Background(), "failing")
+	span.End()
+	if _, _, err := client.ListProjects(t.Context()); err != nil {
+		t.Fatalf("ListProjects: %v", err)
+	}
+
+	got := gather(t, reg)
+	want := map[string]float64{
+		"phoenix_sdk_spans_queued":         0,
+		"phoenix_sdk_spans_exported_total": 3,
+		"phoenix_sdk_export_errors_total":  1,
+		"phoenix_sdk_api_calls_total":      1,
+	}
+	for name, v := range want {
+		if got[name] != v {
+			t.Errorf("%s = %v, want %v", name, got[name], v)
+		}
+	}
+	if latency, ok := got["phoenix_sdk_export_latency_seconds"]; !ok || latency <= 0 {
+		t.Errorf("phoenix_sdk_export_latency_seconds = %v, want a positive duration", latency)
+	}
+
+	var already promclient.AlreadyRegisteredError
+	if err := RegisterCollector(reg, client, tp); !errors.As(err, &already) {
+		t.Errorf("registering twice = %v, want AlreadyRegisteredError", err)
+	}
+}
+
+func TestCollectorPartial(t *testing.T) {
+	client, err := phoenix.NewClient(phoenix.WithURL("http://localhost:1"))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	reg := promclient.NewRegistry()
+	if err := RegisterCollector(reg, client, nil); err != nil {
+		t.Fatalf("RegisterCollector: %v", err)
+	}
+	got := gather(t, reg)
+	if len(got) != 1 || got["phoenix_sdk_api_calls_total"] != 0 {
+		t.Errorf("metrics = %v, want only phoenix_sdk_api_calls_total", got)
+	}
+
+	empty := promclient.NewRegistry()
+	if err := RegisterCollector(empty, nil, nil); err != nil {
+		t.Fatalf("RegisterCollector: %v", err)
+	}
+	if got := gather(t, empty); len(got) != 0 {
+		t.Errorf("metrics = %v, want none", got)
+	}
+}
@@ -2,8 +2,16 @@ package phoenix
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/agentplexus/go-phoenix/internal/api"
+	"github.com/go-faster/jx"
 )
 
 // Prompt represents a Phoenix prompt.
@@ -12,6 +20,8 @@ type Prompt struct {
 	Name           string
 	Description    string
 	SourcePromptID string
+	Metadata       map[string]any
+	Archived       bool
 }
 
 // PromptVersion represents a version of a prompt.
@@ -19,6 +29,7 @@ type PromptVersion struct {
 	ID            string
 	Description   string
 	Template      string // The prompt template content
+	TemplateSHA   string // Hex SHA-256 of the template content
 	TemplateType  PromptTemplateType
 	ModelName     string
 	ModelProvider PromptModelProvider
@@ -57,6 +68,8 @@ type PromptOption func(*promptOptions)
 
 type promptOptions struct {
 	description string
+	tag         string
+	forceTag    bool
 }
 
 // WithPromptDescription sets the prompt description.
@@ -66,8 +79,37 @@ func WithPromptDescription(desc string) PromptOption {
 	}
 }
 
+// WithPromptTag tags the created prompt version with tagName. If the tag
+// already points at another version of the prompt, nothing is created and
+// an error wrapping ErrTagAlreadyExists is returned, unless WithForceTag is
+// set.
+//
+// Phoenix cannot create a version and tag it atomically, so the tag is
+// checked again just before it is applied. If another client creates the
+// tag in between, or tagging fails for another reason, the version is left
+// untagged and returned in a *PromptTagError. A tag created after that
+// second check may still be overwritten.
+func WithPromptTag(tagName string) PromptOption {
+	return func(o *promptOptions) {
+		o.tag = tagName
+	}
+}
+
+// WithForceTag moves the tag set with WithPromptTag to the created version
+// even if it already points at another version.
+func WithForceTag(force bool) PromptOption {
+	return func(o *promptOptions) {
+		o.forceTag = force
+	}
+}
+
 // ListPrompts lists all prompts.
+//
+// Archived prompts are filtered out of each page on the client unless
+// WithIncludeArchived(true) is passed, so a page may hold fewer prompts than
+// the requested limit, or none, while the next cursor is still set.
 func (c *Client) ListPrompts(ctx context.Context, opts ...ListOption) ([]*Prompt, string, error) { //nolint:dupl // Type-safe pattern differs only in types
+	ctx = withRequestIDRecorder(ctx)
 	options := defaultListOptions()
 	for _, opt := range opts {
 		opt(options)
@@ -88,12 +130,16 @@ func (c *Client) ListPrompts(ctx context.Context, opts ...ListOption) ([]*Prompt
 
 	resp, ok := res.(*api.GetPromptsResponseBody)
 	if !ok {
-		return nil, "", &APIError{Message: "unexpected response type"}
+		return nil, "", c.unexpectedResponseError(ctx)
 	}
 
 	prompts := make([]*Prompt, 0, len(resp.Data))
 	for i := range resp.Data {
-		prompts = append(prompts, convertPrompt(&resp.Data[i]))
+		prompt := convertPrompt(&resp.Data[i])
+		if prompt.Archived && !options.includeArchived {
+			continue
+		}
+		prompts = append(prompts, prompt)
 	}
 
 	var nextCursor string
@@ -118,15 +164,72 @@ func convertPrompt(p *api.Prompt) *Prompt {
 	if p.SourcePromptID.Set && !p.SourcePromptID.Null {
 		prompt.SourcePromptID = p.SourcePromptID.Value
 	}
+	if p.Metadata.Set && !p.Metadata.Null {
+		prompt.Metadata = convertRawMap(p.Metadata.Value)
+	}
+	prompt.Archived = isArchived(prompt.Metadata)
 	return prompt
 }
 
-// CreatePrompt creates a new prompt with the given template.
+// ArchivePrompt marks a prompt as archived instead of deleting it.
+//
+// Phoenix does not currently expose an endpoint for updating prompt
+// metadata, so this returns ErrNotSupported. Prompts whose metadata already
+// carries the archived flag are still hidden from ListPrompts unless
+// WithIncludeArchived(true) is passed.
+func (c *Client) ArchivePrompt(ctx context.Context, promptName string) error {
+	return c.setPromptArchived(ctx, promptName, true)
+}
+
+// RestorePrompt clears the archived flag on a prompt.
+// See ArchivePrompt for current API limitations.
+func (c *Client) RestorePrompt(ctx context.Context, promptName string) error {
+	return c.setPromptArchived(ctx, promptName, false)
+}
+
+func (c *Client) setPromptArchived(ctx context.Context, promptName string, archived bool) error {
+	prompt, err := c.findPrompt(ctx, promptName)
+	if err != nil {
+		return err
+	}
+	if prompt.Archived == archived {
+		return nil
+	}
+	return fmt.Errorf("%w: updating metadata for prompt %q", ErrNotSupported, promptName)
+}
+
+// findPrompt pages through all prompts (including archived ones) to find one by name.
+func (c *Client) findPrompt(ctx context.Context, name string) (*Prompt, error) {
+	cursor := ""
+	for {
+		prompts, next, err := c.ListPrompts(ctx, WithCursor(cursor), WithIncludeArchived(true))
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range prompts {
+			if p.Name == name {
+				return p, nil
+			}
+		}
+		if next == "" {
+			return nil, ErrPromptNotFound
+		}
+		cursor = next
+	}
+}
+
+// CreatePrompt creates a new prompt with the given template. If the new
+// version cannot be tagged with WithPromptTag, it returns a *PromptTagError
+// holding the version.
 func (c *Client) CreatePrompt(ctx context.Context, name string, template string, modelName string, modelProvider PromptModelProvider, opts ...PromptOption) (*PromptVersion, error) {
+	ctx = withRequestIDRecorder(ctx)
 	options := &promptOptions{}
 	for _, opt := range opts {
 		opt(options)
 	}
+	if err := c.checkPromptTag(ctx, name, options); err != nil {
+		return nil, err
+	}
 
 	// Build prompt data
 	promptData := api.PromptData{
@@ -163,18 +266,27 @@ func (c *Client) CreatePrompt(ctx context.Context, name string, template string,
 
 	resp, ok := res.(*api.CreatePromptResponseBody)
 	if !ok {
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, c.unexpectedResponseError(ctx)
 	}
 
-	return convertPromptVersion(&resp.Data), nil
+	version := convertPromptVersion(&resp.Data)
+	if err := c.tagPromptVersion(ctx, name, version, options); err != nil {
+		return nil, err
+	}
+	return version, nil
 }
 
-// CreateChatPrompt creates a new chat-style prompt with messages.
+// CreateChatPrompt creates a new chat-style prompt with messages. Tags set
+// with WithPromptTag are applied as in CreatePrompt.
 func (c *Client) CreateChatPrompt(ctx context.Context, name string, messages []PromptMessage, modelName string, modelProvider PromptModelProvider, opts ...PromptOption) (*PromptVersion, error) {
+	ctx = withRequestIDRecorder(ctx)
 	options := &promptOptions{}
 	for _, opt := range opts {
 		opt(options)
 	}
+	if err := c.checkPromptTag(ctx, name, options); err != nil {
+		return nil, err
+	}
 
 	// Build prompt data
 	promptData := api.PromptData{
@@ -221,14 +333,52 @@ func (c *Client) CreateChatPrompt(ctx context.Context, name string, messages []P
 
 	resp, ok := res.(*api.CreatePromptResponseBody)
 	if !ok {
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, c.unexpectedResponseError(ctx)
 	}
 
-	return convertPromptVersion(&resp.Data), nil
+	version := convertPromptVersion(&resp.Data)
+	if err := c.tagPromptVersion(ctx, name, version, options); err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// checkPromptTag returns an error wrapping ErrTagAlreadyExists if the tag
+// set with WithPromptTag already exists and WithForceTag is not set.
+func (c *Client) checkPromptTag(ctx context.Context, promptName string, options *promptOptions) error {
+	if options.tag == "" || options.forceTag {
+		return nil
+	}
+	current, err := c.GetPromptVersionByTag(ctx, promptName, options.tag)
+	switch {
+	case err == nil:
+		return fmt.Errorf("%w: tag %q of prompt %q points at version %q", ErrTagAlreadyExists, options.tag, promptName, current.ID)
+	case IsNotFound(err):
+		return nil
+	default:
+		return err
+	}
+}
+
+// tagPromptVersion applies the tag set with WithPromptTag to a newly created
+// version, returning a *PromptTagError if it cannot.
+func (c *Client) tagPromptVersion(ctx context.Context, promptName string, version *PromptVersion, options *promptOptions) error {
+	if options.tag == "" {
+		return nil
+	}
+	err := c.checkPromptTag(ctx, promptName, options)
+	if err == nil {
+		err = c.createPromptTag(ctx, promptName, options.tag, version.ID, &promptTagOptions{})
+	}
+	if err != nil {
+		return &PromptTagError{Version: version, Tag: options.tag, Err: err}
+	}
+	return nil
 }
 
 // GetPromptLatest retrieves the latest version of a prompt by name.
 func (c *Client) GetPromptLatest(ctx context.Context, name string) (*PromptVersion, error) {
+	ctx = withRequestIDRecorder(ctx)
 	res, err := c.apiClient.GetPromptVersionLatest(ctx, api.GetPromptVersionLatestParams{
 		PromptIdentifier: name,
 	})
@@ -238,7 +388,7 @@ func (c *Client) GetPromptLatest(ctx context.Context, name string) (*PromptVersi
 
 	resp, ok := res.(*api.GetPromptResponseBody)
 	if !ok {
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, c.unexpectedResponseError(ctx)
 	}
 
 	return convertPromptVersion(&resp.Data), nil
@@ -246,6 +396,7 @@ func (c *Client) GetPromptLatest(ctx context.Context, name string) (*PromptVersi
 
 // GetPromptVersion retrieves a specific prompt version by its ID.
 func (c *Client) GetPromptVersion(ctx context.Context, versionID string) (*PromptVersion, error) {
+	ctx = withRequestIDRecorder(ctx)
 	res, err := c.apiClient.GetPromptVersionByPromptVersionId(ctx, api.GetPromptVersionByPromptVersionIdParams{
 		PromptVersionID: versionID,
 	})
@@ -255,7 +406,7 @@ func (c *Client) GetPromptVersion(ctx context.Context, versionID string) (*Promp
 
 	resp, ok := res.(*api.GetPromptResponseBody)
 	if !ok {
-		return nil, &APIError{Message: "unexpected response type"}
+		return nil, c.unexpectedResponseError(ctx)
 	}
 
 	return convertPromptVersion(&resp.Data), nil
@@ -263,6 +414,7 @@ func (c *Client) GetPromptVersion(ctx context.Context, versionID string) (*Promp
 
 // GetPromptVersionByTag retrieves a prompt version by its tag name.
 func (c *Client) GetPromptVersionByTag(ctx context.Context, promptName, tagName string) (*PromptVersion, error) {
+	ctx = withRequestIDRecorder(ctx)
 	res, err := c.apiClient.GetPromptVersionByTagName(ctx, api.GetPromptVersionByTagNameParams{
 		PromptIdentifier: promptName,
 		TagName:          tagName,
@@ -271,20 +423,67 @@ func (c *Client) GetPromptVersionByTag(ctx context.Context, promptName, tagName
 		return nil, err
 	}
 
-	resp, ok := res.(*api.GetPromptResponseBody)
-	if !ok {
-		return nil, &APIError{Message: "unexpected response type"}
+	switch resp := res.(type) {
+	case *api.GetPromptResponseBody:
+		return convertPromptVersion(&resp.Data), nil
+	case *api.GetPromptVersionByTagNameNotFound:
+		return nil, fmt.Errorf("%w: tag %q of prompt %q", ErrPromptTagNotFound, tagName, promptName)
+	default:
+		return nil, c.unexpectedResponseError(ctx)
 	}
+}
 
-	return convertPromptVersion(&resp.Data), nil
+// GetPromptVersionByMultipleTags retrieves the versions of a prompt that the
+// given tags point at, keyed by tag name. Phoenix has no batch endpoint, so
+// the tags are fetched concurrently. Tags that do not exist are left out of
+// the result; any other error fails the whole call.
+func (c *Client) GetPromptVersionByMultipleTags(ctx context.Context, promptName string, tags []string) (map[string]*PromptVersion, error) {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		versions = make(map[string]*PromptVersion, len(tags))
+		firstErr error
+	)
+	for _, tag := range slices.Compact(slices.Sorted(slices.Values(tags))) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := c.GetPromptVersionByTag(ctx, promptName, tag)
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				versions[tag] = v
+			case errors.Is(err, ErrPromptTagNotFound):
+			case firstErr == nil:
+				firstErr = err
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return versions, nil
 }
 
-// ListPromptVersions lists all versions of a prompt.
+// ListPromptVersions lists all versions of a prompt, newest first.
+//
+// With WithVersionFilter, the tags of every version on the page are fetched
+// to build its VersionSummary, so a page may hold fewer versions than the
+// limit even when more remain. WithVersionCreatedBefore and
+// WithVersionCreatedAfter are not supported yet and return ErrNotSupported.
 func (c *Client) ListPromptVersions(ctx context.Context, promptName string, opts ...ListOption) ([]*PromptVersion, string, error) { //nolint:dupl // Type-safe pattern differs only in types
+	ctx = withRequestIDRecorder(ctx)
 	options := defaultListOptions()
 	for _, opt := range opts {
 		opt(options)
 	}
+	if !options.versionCreatedBefore.IsZero() || !options.versionCreatedAfter.IsZero() {
+		return nil, "", fmt.Errorf("%w: filtering versions of prompt %q by creation time", ErrNotSupported, promptName)
+	}
 
 	params := api.ListPromptVersionsParams{
 		PromptIdentifier: promptName,
@@ -301,14 +500,29 @@ func (c *Client) ListPromptVersions(ctx context.Context, promptName string, opts
 		return nil, "", err
 	}
 
-	resp, ok := res.(*api.GetPromptVersionsResponseBody)
-	if !ok {
-		return nil, "", &APIError{Message: "unexpected response type"}
+	var resp *api.GetPromptVersionsResponseBody
+	switch r := res.(type) {
+	case *api.GetPromptVersionsResponseBody:
+		resp = r
+	case *api.ListPromptVersionsNotFound:
+		return nil, "", fmt.Errorf("%w: %q", ErrPromptNotFound, promptName)
+	default:
+		return nil, "", c.unexpectedResponseError(ctx)
 	}
 
 	versions := make([]*PromptVersion, 0, len(resp.Data))
 	for i := range resp.Data {
-		versions = append(versions, convertPromptVersion(&resp.Data[i]))
+		v := convertPromptVersion(&resp.Data[i])
+		if options.versionFilter != nil {
+			summary, err := c.summarizePromptVersion(ctx, v)
+			if err != nil {
+				return nil, "", err
+			}
+			if !options.versionFilter(summary) {
+				continue
+			}
+		}
+		versions = append(versions, v)
 	}
 
 	var nextCursor string
@@ -328,6 +542,7 @@ func convertPromptVersion(v *api.PromptVersion) *PromptVersion {
 		ModelName:     v.ModelName,
 		ModelProvider: PromptModelProvider(v.ModelProvider),
 		TemplateType:  PromptTemplateType(v.TemplateType),
+		TemplateSHA:   templateSHA(v.Template),
 	}
 	if !v.Description.Null {
 		pv.Description = v.Description.Value
@@ -339,3 +554,277 @@ func convertPromptVersion(v *api.PromptVersion) *PromptVersion {
 	// For chat templates, we would need to serialize the messages
 	return pv
 }
+
+// templateSHA hashes the template content: the raw text of a string
+// template, or the JSON encoding of a chat template's messages.
+func templateSHA(t api.PromptVersionTemplate) string {
+	var content []byte
+	if t.IsPromptStringTemplate() {
+		content = []byte(t.PromptStringTemplate.Template)
+	} else {
+		e := &jx.Encoder{}
+		t.Encode(e)
+		content = e.Bytes()
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// VersionSummary describes a prompt version for filtering with
+// WithVersionFilter.
+type VersionSummary struct {
+	ID            string
+	TemplateSHA   string
+	ModelName     string
+	ModelProvider PromptModelProvider
+	Tags          []string
+}
+
+// summarizePromptVersion builds the VersionSummary of v, fetching its tags.
+func (c *Client) summarizePromptVersion(ctx context.Context, v *PromptVersion) (*VersionSummary, error) {
+	tags, err := c.listPromptVersionTags(ctx, v.ID)
+	if err != nil {
+		return nil, err
+	}
+	summary := &VersionSummary{
+		ID:            v.ID,
+		TemplateSHA:   v.TemplateSHA,
+		ModelName:     v.ModelName,
+		ModelProvider: v.ModelProvider,
+		Tags:          make([]string, 0, len(tags)),
+	}
+	for _, t := range tags {
+		summary.Tags = append(summary.Tags, t.Name)
+	}
+	return summary, nil
+}
+
+// GetPromptLatestN returns up to the n most recent versions of a prompt,
+// newest first.
+func (c *Client) GetPromptLatestN(ctx context.Context, promptName string, n int) ([]*PromptVersion, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("%w: n must be positive", ErrInvalidInput)
+	}
+
+	versions := make([]*PromptVersion, 0, n)
+	cursor := ""
+	for {
+		page, next, err := c.ListPromptVersions(ctx, promptName, WithCursor(cursor), WithLimit(n-len(versions)))
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, page...)
+		if len(versions) >= n || next == "" {
+			return versions[:min(n, len(versions))], nil
+		}
+		cursor = next
+	}
+}
+
+// LatestVersionBefore returns the most recent version of a prompt created
+// before the given time, for example to reproduce the prompt used at the
+// time of an incident.
+//
+// Phoenix does not currently report when prompt versions were created, so
+// this returns ErrNotSupported.
+func (c *Client) LatestVersionBefore(ctx context.Context, promptName string, before time.Time) (*PromptVersion, error) {
+	if promptName == "" || before.IsZero() {
+		return nil, fmt.Errorf("%w: prompt name and time are required", ErrInvalidInput)
+	}
+	return nil, fmt.Errorf("%w: finding the version of prompt %q created before %s", ErrNotSupported, promptName, before.Format(time.RFC3339))
+}
+
+// PromptTag represents a named tag pointing at a prompt version.
+type PromptTag struct {
+	ID          string
+	Name        string
+	Description string
+	VersionID   string
+}
+
+// PromptTagOption is a functional option for prompt tag operations.
+type PromptTagOption func(*promptTagOptions)
+
+type promptTagOptions struct {
+	description string
+}
+
+// WithPromptTagDescription sets the description for a prompt tag.
+func WithPromptTagDescription(desc string) PromptTagOption {
+	return func(o *promptTagOptions) {
+		o.description = desc
+	}
+}
+
+// CreatePromptTag tags a prompt version with the given name.
+//
+// Tag names are unique per prompt in Phoenix: tagging a version with a name
+// that already exists on another version of the same prompt moves the tag.
+// Phoenix tags versions by ID alone, so the versions of the prompt are
+// listed first to check that versionID belongs to it; ErrPromptNotFound is
+// returned if it does not.
+func (c *Client) CreatePromptTag(ctx context.Context, promptName, tagName, versionID string, opts ...PromptTagOption) error {
+	if promptName == "" || tagName == "" || versionID == "" {
+		return ErrInvalidInput
+	}
+
+	options := &promptTagOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	owned, err := c.promptHasVersion(ctx, promptName, versionID)
+	if err != nil {
+		return err
+	}
+	if !owned {
+		return fmt.Errorf("%w: version %q of prompt %q", ErrPromptNotFound, versionID, promptName)
+	}
+	return c.createPromptTag(ctx, promptName, tagName, versionID, options)
+}
+
+// createPromptTag is CreatePromptTag for a version known to belong to the
+// prompt.
+func (c *Client) createPromptTag(ctx context.Context, promptName, tagName, versionID string, options *promptTagOptions) error {
+	ctx = withRequestIDRecorder(ctx)
+	req := &api.PromptVersionTagData{
+		Name: api.Identifier(tagName),
+	}
+	if options.description != "" {
+		req.Description.SetTo(options.description)
+	}
+
+	res, err := c.apiClient.CreatePromptVersionTag(ctx, req, api.CreatePromptVersionTagParams{
+		PromptVersionID: versionID,
+	})
+	if err != nil {
+		return err
+	}
+
+	switch res.(type) {
+	case *api.CreatePromptVersionTagNoContent:
+		return nil
+	case *api.CreatePromptVersionTagNotFound:
+		return fmt.Errorf("%w: version %q of prompt %q", ErrPromptNotFound, versionID, promptName)
+	default:
+		return c.unexpectedResponseError(ctx)
+	}
+}
+
+// promptHasVersion reports whether versionID is a version of the prompt.
+func (c *Client) promptHasVersion(ctx context.Context, promptName, versionID string) (bool, error) {
+	cursor := ""
+	for {
+		versions, next, err := c.ListPromptVersions(ctx, promptName, WithCursor(cursor))
+		if err != nil {
+			return false, err
+		}
+		for _, v := range versions {
+			if v.ID == versionID {
+				return true, nil
+			}
+		}
+		if next == "" {
+			return false, nil
+		}
+		cursor = next
+	}
+}
+
+// MovePromptTag points an existing tag at a different version of the prompt.
+//
+// Phoenix has no endpoint for updating or deleting tags, but re-tagging is an
+// upsert on the server, so the move happens in a single request and readers
+// never observe the tag missing.
+func (c *Client) MovePromptTag(ctx context.Context, promptName, tagName, newVersionID string) error {
+	current, err := c.GetPromptVersionByTag(ctx, promptName, tagName)
+	if err != nil {
+		return err
+	}
+	if current.ID == newVersionID {
+		return nil
+	}
+	return c.CreatePromptTag(ctx, promptName, tagName, newVersionID)
+}
+
+// PromotePromptTag points toTag at the version fromTag points at, for
+// example promoting "staging" to "production". It returns
+// ErrPromptTagNotFound if fromTag does not exist. toTag is created if it
+// does not exist; since re-tagging is an upsert on the server, an existing
+// toTag moves in a single request.
+func (c *Client) PromotePromptTag(ctx context.Context, promptName, fromTag, toTag string) error {
+	if fromTag == "" || toTag == "" {
+		return fmt.Errorf("%w: fromTag and toTag are required", ErrInvalidInput)
+	}
+	from, err := c.GetPromptVersionByTag(ctx, promptName, fromTag)
+	if err != nil {
+		return err
+	}
+	return c.createPromptTag(ctx, promptName, toTag, from.ID, &promptTagOptions{})
+}
+
+// ListPromptTags lists the tags across all versions of a prompt.
+func (c *Client) ListPromptTags(ctx context.Context, promptName string) ([]*PromptTag, error) {
+	var tags []*PromptTag
+
+	cursor := ""
+	for {
+		versions, next, err := c.ListPromptVersions(ctx, promptName, WithCursor(cursor))
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range versions {
+			versionTags, err := c.listPromptVersionTags(ctx, v.ID)
+			if err != nil {
+				return nil, err
+			}
+			tags = append(tags, versionTags...)
+		}
+		if next == "" {
+			return tags, nil
+		}
+		cursor = next
+	}
+}
+
+// listPromptVersionTags pages through the tags of a single prompt version.
+func (c *Client) listPromptVersionTags(ctx context.Context, versionID string) ([]*PromptTag, error) {
+	ctx = withRequestIDRecorder(ctx)
+	params := api.GetPromptVersionTagsParams{
+		PromptVersionID: versionID,
+	}
+
+	var tags []*PromptTag
+	for {
+		res, err := c.apiClient.GetPromptVersionTags(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, ok := res.(*api.GetPromptVersionTagsResponseBody)
+		if !ok {
+			return nil, c.unexpectedResponseError(ctx)
+		}
+
+		for i := range resp.Data {
+			tags = append(tags, convertPromptTag(&resp.Data[i], versionID))
+		}
+
+		if resp.NextCursor.Null || resp.NextCursor.Value == "" {
+			return tags, nil
+		}
+		params.Cursor.SetTo(resp.NextCursor.Value)
+	}
+}
+
+func convertPromptTag(t *api.PromptVersionTag, versionID string) *PromptTag {
+	tag := &PromptTag{
+		ID:        t.ID,
+		Name:      string(t.Name),
+		VersionID: versionID,
+	}
+	if t.Description.Set && !t.Description.Null {
+		tag.Description = t.Description.Value
+	}
+	return tag
+}
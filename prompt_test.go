@@ -0,0 +1,270 @@
+package phoenix
+
+import (
+	"errors"
+	"maps"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestLatestVersionBefore(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	c := newTestClient(t, mux)
+
+	tests := []struct {
+		name   string
+		prompt string
+		before time.Time
+		want   error
+	}{
+		{"supported input", "greeting", time.Now(), ErrNotSupported},
+		{"missing time", "greeting", time.Time{}, ErrInvalidInput},
+		{"missing prompt", "", time.Now(), ErrInvalidInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := c.LatestVersionBefore(t.Context(), tt.prompt, tt.before)
+			if !errors.Is(err, tt.want) || v != nil {
+				t.Errorf("LatestVersionBefore = %v, %v, want nil, %v", v, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreatePromptTag(t *testing.T) {
+	var tagged []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/prompts/{prompt}/versions", func(w http.ResponseWriter, r *http.Request) {
+		switch r.PathValue("prompt") {
+		case "greeting":
+			if r.URL.Query().Get("cursor") == "" {
+				writeJSON(t, w, pageJSON([]map[string]any{promptVersionJSON("v2", "Hi")}, "next"))
+				return
+			}
+			writeJSON(t, w, pageJSON([]map[string]any{promptVersionJSON("v1", "Hello")}, ""))
+		case "farewell":
+			writeJSON(t, w, pageJSON([]map[string]any{promptVersionJSON("v3", "Bye")}, ""))
+		default:
+			notFound(w)
+		}
+	})
+	mux.HandleFunc("POST /v1/prompt_versions/{id}/tags", func(w http.ResponseWriter, r *http.Request) {
+		tagged = append(tagged, r.PathValue("id"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	c := newTestClient(t, mux)
+
+	tests := []struct {
+		name    string
+		prompt  string
+		version string
+		wantErr error
+	}{
+		{"own version", "greeting", "v1", nil},
+		{"other prompt's version", "greeting", "v3", ErrPromptNotFound},
+		{"missing prompt", "missing", "v1", ErrPromptNotFound},
+		{"missing version ID", "greeting", "", ErrInvalidInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tagged = nil
+			err := c.CreatePromptTag(t.Context(), tt.prompt, "production", tt.version)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreatePromptTag = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (len(tagged) != 1 || tagged[0] != tt.version) {
+				t.Errorf("tagged versions %v, want [%s]", tagged, tt.version)
+			}
+			if tt.wantErr != nil && len(tagged) != 0 {
+				t.Errorf("tagged versions %v, want none", tagged)
+			}
+		})
+	}
+}
+
+func TestCreatePromptWithTag(t *testing.T) {
+	// existing maps tag names to the version they point at. Tags listed in
+	// racing are created by another client right after a version is created.
+	var (
+		existing map[string]string
+		racing   map[string]string
+		created  int
+		tagged   []string
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/prompts", func(w http.ResponseWriter, r *http.Request) {
+		created++
+		maps.Copy(existing, racing)
+		writeJSON(t, w, map[string]any{"data": promptVersionJSON("new", "Hi")})
+	})
+	mux.HandleFunc("GET /v1/prompts/{prompt}/tags/{tag}", func(w http.ResponseWriter, r *http.Request) {
+		id, ok := existing[r.PathValue("tag")]
+		if !ok {
+			notFound(w)
+			return
+		}
+		writeJSON(t, w, map[string]any{"data": promptVersionJSON(id, "Hello")})
+	})
+	mux.HandleFunc("POST /v1/prompt_versions/{id}/tags", func(w http.ResponseWriter, r *http.Request) {
+		tagged = append(tagged, r.PathValue("id"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	c := newTestClient(t, mux)
+
+	tests := []struct {
+		name        string
+		existing    map[string]string
+		racing      map[string]string
+		opts        []PromptOption
+		wantErr     error
+		wantCreated int
+		wantTagged  []string
+	}{
+		{"new tag", nil, nil, []PromptOption{WithPromptTag("production")}, nil, 1, []string{"new"}},
+		{"existing tag", map[string]string{"production": "old"}, nil, []PromptOption{WithPromptTag("production")}, ErrTagAlreadyExists, 0, nil},
+		{"forced tag", map[string]string{"production": "old"}, nil, []PromptOption{WithPromptTag("production"), WithForceTag(true)}, nil, 1, []string{"new"}},
+		{"racing tag", nil, map[string]string{"production": "other"}, []PromptOption{WithPromptTag("production")}, ErrTagAlreadyExists, 1, nil},
+		{"no tag", map[string]string{"production": "old"}, nil, nil, nil, 1, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existing, racing = maps.Clone(tt.existing), tt.racing
+			if existing == nil {
+				existing = map[string]string{}
+			}
+			created, tagged = 0, nil
+
+			v, err := c.CreatePrompt(t.Context(), "greeting", "Hi", "gpt-4o", PromptModelProviderOpenAI, tt.opts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreatePrompt = %v, want %v", err, tt.wantErr)
+			}
+			if created != tt.wantCreated {
+				t.Errorf("created %d versions, want %d", created, tt.wantCreated)
+			}
+			if !slices.Equal(tagged, tt.wantTagged) {
+				t.Errorf("tagged versions %v, want %v", tagged, tt.wantTagged)
+			}
+			if err != nil && v != nil {
+				t.Errorf("CreatePrompt returned version %+v with error", v)
+			}
+
+			var tagErr *PromptTagError
+			switch {
+			case tt.wantCreated == 1 && err != nil:
+				if !errors.As(err, &tagErr) || tagErr.Version == nil || tagErr.Version.ID != "new" {
+					t.Errorf("CreatePrompt = %v, want *PromptTagError holding version new", err)
+				}
+			case errors.As(err, &tagErr):
+				t.Errorf("CreatePrompt = %v, want no *PromptTagError when nothing was created", err)
+			}
+		})
+	}
+}
+
+func TestArchivePrompt(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/prompts", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, pageJSON([]map[string]any{
+			{"id": "p1", "name": "active", "metadata": map[string]any{}},
+			{"id": "p2", "name": "old", "metadata": map[string]any{MetadataKeyArchived: true}},
+		}, "next"))
+	})
+	mux.HandleFunc("POST /", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	c := newTestClient(t, mux)
+
+	if err := c.ArchivePrompt(t.Context(), "active"); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("ArchivePrompt = %v, want %v", err, ErrNotSupported)
+	}
+	if err := c.RestorePrompt(t.Context(), "old"); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("RestorePrompt = %v, want %v", err, ErrNotSupported)
+	}
+	// Prompts already in the requested state need no update.
+	if err := c.ArchivePrompt(t.Context(), "old"); err != nil {
+		t.Errorf("ArchivePrompt(archived) = %v", err)
+	}
+	if err := c.RestorePrompt(t.Context(), "active"); err != nil {
+		t.Errorf("RestorePrompt(active) = %v", err)
+	}
+
+	prompts, next, err := c.ListPrompts(t.Context())
+	if err != nil {
+		t.Fatalf("ListPrompts: %v", err)
+	}
+	if len(prompts) != 1 || prompts[0].Name != "active" || next != "next" {
+		t.Errorf("ListPrompts = %v, %q, want only the active prompt and the next cursor", prompts, next)
+	}
+	prompts, _, err = c.ListPrompts(t.Context(), WithIncludeArchived(true))
+	if err != nil {
+		t.Fatalf("ListPrompts: %v", err)
+	}
+	if len(prompts) != 2 || !prompts[1].Archived {
+		t.Errorf("ListPrompts(WithIncludeArchived) = %v, want both prompts", prompts)
+	}
+}
+
+func TestListPromptVersionsCreatedFilters(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	c := newTestClient(t, mux)
+
+	now := time.Now()
+	for name, opt := range map[string]ListOption{
+		"before": WithVersionCreatedBefore(now),
+		"after":  WithVersionCreatedAfter(now),
+	} {
+		t.Run(name, func(t *testing.T) {
+			versions, _, err := c.ListPromptVersions(t.Context(), "greeting", opt)
+			if versions != nil || !errors.Is(err, ErrNotSupported) {
+				t.Errorf("ListPromptVersions = %v, %v, want %v", versions, err, ErrNotSupported)
+			}
+		})
+	}
+}
+
+func TestListPromptVersionsFilter(t *testing.T) {
+	var tagLookups []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/prompts/{prompt}/versions", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, pageJSON([]map[string]any{
+			promptVersionJSON("v3", "Hi"),
+			promptVersionJSON("v2", "Hello"),
+			promptVersionJSON("v1", "Hey"),
+		}, ""))
+	})
+	mux.HandleFunc("GET /v1/prompt_versions/{id}/tags", func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		tagLookups = append(tagLookups, id)
+		var tags []map[string]any
+		if id == "v2" {
+			tags = append(tags, map[string]any{"id": "tag1", "name": "production", "description": nil})
+		}
+		writeJSON(t, w, pageJSON(tags, ""))
+	})
+	c := newTestClient(t, mux)
+
+	versions, _, err := c.ListPromptVersions(t.Context(), "greeting", WithVersionFilter(func(s *VersionSummary) bool {
+		return slices.Contains(s.Tags, "production")
+	}))
+	if err != nil {
+		t.Fatalf("ListPromptVersions: %v", err)
+	}
+	if len(versions) != 1 || versions[0].ID != "v2" {
+		t.Errorf("ListPromptVersions = %v, want [v2]", versions)
+	}
+	// The tags of every version on the page are fetched.
+	if !slices.Equal(tagLookups, []string{"v3", "v2", "v1"}) {
+		t.Errorf("looked up tags of %v, want every version", tagLookups)
+	}
+}
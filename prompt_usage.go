@@ -0,0 +1,118 @@
+package phoenix
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	phoenixotel "github.com/agentplexus/go-phoenix/otel"
+)
+
+// PromptUsageStats summarizes how often a prompt was used.
+type PromptUsageStats struct {
+	// VersionCallCounts maps prompt version IDs to the number of spans
+	// that used them.
+	VersionCallCounts map[string]int64
+
+	// TagCallCounts maps prompt tags to the number of spans that used the
+	// prompt through them.
+	TagCallCounts map[string]int64
+
+	// ErrorRate is the fraction of spans using the prompt that ended with
+	// an error status.
+	ErrorRate float64
+
+	// MedianRenderLatencyMs is the median duration of the spans using the
+	// prompt, in milliseconds.
+	MedianRenderLatencyMs float64
+}
+
+// PromptUsageOption is a functional option for GetPromptUsageStats.
+type PromptUsageOption func(*promptUsageOptions)
+
+type promptUsageOptions struct {
+	project    string
+	start, end time.Time
+}
+
+// WithUsageTimeRange only counts spans that started at or after start and
+// before end. A zero start or end leaves that side of the range open.
+func WithUsageTimeRange(start, end time.Time) PromptUsageOption {
+	return func(o *promptUsageOptions) {
+		o.start = start
+		o.end = end
+	}
+}
+
+// WithUsageProject counts spans in the given project instead of the
+// client's default project.
+func WithUsageProject(projectIdentifier string) PromptUsageOption {
+	return func(o *promptUsageOptions) {
+		o.project = projectIdentifier
+	}
+}
+
+// GetPromptUsageStats reports how the named prompt was used, based on the
+// spans carrying its name in the llm.prompt.name attribute (see
+// otel.WithPromptReference). Versions and tags are taken from the
+// llm.prompt.version_id and llm.prompt.tag attributes of those spans.
+//
+// Phoenix does not track prompt usage itself, so this pages through every
+// span in the time range and filters them client-side.
+func (c *Client) GetPromptUsageStats(ctx context.Context, promptName string, opts ...PromptUsageOption) (*PromptUsageStats, error) {
+	options := &promptUsageOptions{project: c.config.ProjectName}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	spans, err := collectPages(func(cursor string) ([]*Span, string, error) {
+		return c.GetSpans(ctx, options.project,
+			WithSpanCursor(cursor),
+			WithSpanTimeRange(options.start, options.end),
+			WithAttributeFilter(phoenixotel.LLMPromptName, promptName),
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &PromptUsageStats{
+		VersionCallCounts: make(map[string]int64),
+		TagCallCounts:     make(map[string]int64),
+	}
+	if len(spans) == 0 {
+		return stats, nil
+	}
+
+	var errorCount int
+	latencies := make([]float64, 0, len(spans))
+	for _, s := range spans {
+		if v, ok := lookupAttribute(s.Attributes, phoenixotel.LLMPromptVersion); ok {
+			stats.VersionCallCounts[attributeString(v)]++
+		}
+		if v, ok := lookupAttribute(s.Attributes, phoenixotel.LLMPromptTag); ok {
+			stats.TagCallCounts[attributeString(v)]++
+		}
+		if s.StatusCode == SpanStatusError {
+			errorCount++
+		}
+		latencies = append(latencies, float64(s.EndTime.Sub(s.StartTime))/float64(time.Millisecond))
+	}
+
+	stats.ErrorRate = float64(errorCount) / float64(len(spans))
+	stats.MedianRenderLatencyMs = median(latencies)
+	return stats, nil
+}
+
+// median returns the median of values, sorting them in place.
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	slices.Sort(values)
+	mid := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[mid-1] + values[mid]) / 2
+	}
+	return values[mid]
+}
@@ -0,0 +1,121 @@
+package phoenix
+
+import (
+	"maps"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	phoenixotel "github.com/agentplexus/go-phoenix/otel"
+)
+
+func TestGetPromptUsageStats(t *testing.T) {
+	span := func(id, prompt, version, tag, status, end string) map[string]any {
+		attributes := map[string]any{}
+		if prompt != "" {
+			attributes[phoenixotel.LLMPromptName] = prompt
+			attributes[phoenixotel.LLMPromptVersion] = version
+		}
+		if tag != "" {
+			attributes[phoenixotel.LLMPromptTag] = tag
+		}
+		s := spanJSON("t-"+id, id, attributes)
+		s["status_code"] = status
+		s["end_time"] = end
+		return s
+	}
+	var projects []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/projects/{project}/spans", func(w http.ResponseWriter, r *http.Request) {
+		projects = append(projects, r.PathValue("project"))
+		// All spans start at 2025-01-01T00:00:00Z.
+		if r.URL.Query().Get("cursor") == "" {
+			writeJSON(t, w, pageJSON([]map[string]any{
+				span("s1", "greeting", "v1", "production", "OK", "2025-01-01T00:00:01Z"),
+				span("s2", "greeting", "v1", "production", "ERROR", "2025-01-01T00:00:03Z"),
+				span("s3", "farewell", "v9", "production", "OK", "2025-01-01T00:00:01Z"),
+			}, "page2"))
+			return
+		}
+		writeJSON(t, w, pageJSON([]map[string]any{
+			span("s4", "greeting", "v2", "", "OK", "2025-01-01T00:00:02Z"),
+			span("s5", "", "", "", "OK", "2025-01-01T00:00:01Z"),
+		}, ""))
+	})
+	c := newTestClient(t, mux)
+
+	stats, err := c.GetPromptUsageStats(t.Context(), "greeting")
+	if err != nil {
+		t.Fatalf("GetPromptUsageStats: %v", err)
+	}
+	if want := map[string]int64{"v1": 2, "v2": 1}; !maps.Equal(stats.VersionCallCounts, want) {
+		t.Errorf("VersionCallCounts = %v, want %v", stats.VersionCallCounts, want)
+	}
+	if want := map[string]int64{"production": 2}; !maps.Equal(stats.TagCallCounts, want) {
+		t.Errorf("TagCallCounts = %v, want %v", stats.TagCallCounts, want)
+	}
+	if math.Abs(stats.ErrorRate-1.0/3) > 1e-9 {
+		t.Errorf("ErrorRate = %v, want 1/3", stats.ErrorRate)
+	}
+	if stats.MedianRenderLatencyMs != 2000 {
+		t.Errorf("MedianRenderLatencyMs = %v, want 2000", stats.MedianRenderLatencyMs)
+	}
+	if len(projects) != 2 || projects[0] != "test" {
+		t.Errorf("listed spans of %v, want two pages of the client's project", projects)
+	}
+
+	// A prompt without spans has empty stats.
+	projects = nil
+	stats, err = c.GetPromptUsageStats(t.Context(), "unused", WithUsageProject("other"))
+	if err != nil {
+		t.Fatalf("GetPromptUsageStats: %v", err)
+	}
+	if len(stats.VersionCallCounts) != 0 || len(stats.TagCallCounts) != 0 || stats.ErrorRate != 0 || stats.MedianRenderLatencyMs != 0 {
+		t.Errorf("stats for an unused prompt = %+v, want zero", stats)
+	}
+	if stats.VersionCallCounts == nil || stats.TagCallCounts == nil {
+		t.Errorf("stats for an unused prompt have nil maps")
+	}
+	if len(projects) == 0 || projects[0] != "other" {
+		t.Errorf("listed spans of %v, want project other", projects)
+	}
+}
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		values []float64
+		want   float64
+	}{
+		{nil, 0},
+		{[]float64{5}, 5},
+		{[]float64{3, 1, 2}, 2},
+		{[]float64{4, 1, 3, 2}, 2.5},
+	}
+	for _, tt := range tests {
+		if got := median(tt.values); got != tt.want {
+			t.Errorf("median(%v) = %v, want %v", tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestGetPromptUsageStatsTimeRange(t *testing.T) {
+	var start, end string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/projects/{project}/spans", func(w http.ResponseWriter, r *http.Request) {
+		start, end = r.URL.Query().Get("start_time"), r.URL.Query().Get("end_time")
+		writeJSON(t, w, pageJSON([]map[string]any{}, ""))
+	})
+	c := newTestClient(t, mux)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := c.GetPromptUsageStats(t.Context(), "greeting", WithUsageTimeRange(from, from.Add(time.Hour))); err != nil {
+		t.Fatalf("GetPromptUsageStats: %v", err)
+	}
+	if got, err := time.Parse(time.RFC3339, start); err != nil || !got.Equal(from) {
+		t.Errorf("start_time = %q, want %v", start, from)
+	}
+	if got, err := time.Parse(time.RFC3339, end); err != nil || !got.Equal(from.Add(time.Hour)) {
+		t.Errorf("end_time = %q, want %v", end, from.Add(time.Hour))
+	}
+}
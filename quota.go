@@ -0,0 +1,125 @@
+package phoenix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Rate limit response headers. The generic X-RateLimit-* headers describe
+// the limit that applied to the request; the per-resource headers are only
+// sent by deployments that meter spans and annotations separately.
+const (
+	headerRateLimitLimit     = "X-RateLimit-Limit"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+
+	headerSpansPerMinuteLimit    = "X-RateLimit-Limit-Spans-Per-Minute"
+	headerSpansPerMinuteUsed     = "X-RateLimit-Used-Spans-Per-Minute"
+	headerAnnotationsPerDayLimit = "X-RateLimit-Limit-Annotations-Per-Day"
+	headerAnnotationsPerDayUsed  = "X-RateLimit-Used-Annotations-Per-Day"
+)
+
+// QuotaStatus is the rate limit state last reported by Phoenix.
+// Fields the server did not report are zero.
+type QuotaStatus struct {
+	// Limit and Remaining describe the request rate limit that applied to
+	// the most recent response.
+	Limit     int
+	Remaining int
+
+	SpansPerMinuteLimit    int
+	SpansPerMinuteUsed     int
+	AnnotationsPerDayLimit int
+	AnnotationsPerDayUsed  int
+
+	ResetAt time.Time
+	// ObservedAt is when the headers were received.
+	ObservedAt time.Time
+}
+
+// GetQuotaStatus returns the current rate limit status.
+//
+// Phoenix has no quota endpoint, so this sends a minimal request and reads
+// the rate limit headers of its response. It returns an error wrapping
+// ErrNotSupported if the server does not send rate limit headers, which is
+// the case for most self-hosted deployments.
+func (c *Client) GetQuotaStatus(ctx context.Context) (*QuotaStatus, error) {
+	before := c.LastKnownQuota()
+	if _, _, err := c.ListProjects(ctx, WithLimit(1)); err != nil {
+		return nil, err
+	}
+
+	q := c.LastKnownQuota()
+	if q == nil || (before != nil && !q.ObservedAt.After(before.ObservedAt)) {
+		return nil, fmt.Errorf("%w: server did not report rate limit headers", ErrNotSupported)
+	}
+	return q, nil
+}
+
+// LastKnownQuota returns the rate limit status from the most recent response
+// that carried rate limit headers, or nil if none has been seen.
+func (c *Client) LastKnownQuota() *QuotaStatus {
+	return c.quota.get()
+}
+
+// quotaTracker caches the rate limit headers of API responses.
+type quotaTracker struct {
+	mu   sync.RWMutex
+	last *QuotaStatus
+}
+
+func (t *quotaTracker) get() *QuotaStatus {
+	if t == nil {
+		return nil
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.last == nil {
+		return nil
+	}
+	q := *t.last
+	return &q
+}
+
+// observe records the rate limit headers of resp, if it has any.
+func (t *quotaTracker) observe(resp *http.Response) {
+	if t == nil || resp == nil {
+		return
+	}
+	h := resp.Header
+	if h.Get(headerRateLimitLimit) == "" && h.Get(headerSpansPerMinuteLimit) == "" && h.Get(headerAnnotationsPerDayLimit) == "" {
+		return
+	}
+
+	now := time.Now()
+	q := &QuotaStatus{
+		Limit:                  headerInt(h, headerRateLimitLimit),
+		Remaining:              headerInt(h, headerRateLimitRemaining),
+		SpansPerMinuteLimit:    headerInt(h, headerSpansPerMinuteLimit),
+		SpansPerMinuteUsed:     headerInt(h, headerSpansPerMinuteUsed),
+		AnnotationsPerDayLimit: headerInt(h, headerAnnotationsPerDayLimit),
+		AnnotationsPerDayUsed:  headerInt(h, headerAnnotationsPerDayUsed),
+		ObservedAt:             now,
+	}
+	if reset := int64(headerInt(h, headerRateLimitReset)); reset > 0 {
+		// Servers send either a Unix timestamp or seconds until reset.
+		if reset > 1_000_000_000 {
+			q.ResetAt = time.Unix(reset, 0)
+		} else {
+			q.ResetAt = now.Add(time.Duration(reset) * time.Second)
+		}
+	}
+
+	t.mu.Lock()
+	t.last = q
+	t.mu.Unlock()
+}
+
+func headerInt(h http.Header, key string) int {
+	n, _ := strconv.Atoi(h.Get(key))
+	return n
+}
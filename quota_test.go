@@ -0,0 +1,97 @@
+package phoenix
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestGetQuotaStatus(t *testing.T) {
+	headers := map[string]string{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/projects", func(w http.ResponseWriter, r *http.Request) {
+		for k, v := range headers {
+			w.Header().Set(k, v)
+		}
+		writeJSON(t, w, pageJSON([]map[string]any{{"id": "p1", "name": "test"}}, ""))
+	})
+	c := newTestClient(t, mux)
+
+	if q := c.LastKnownQuota(); q != nil {
+		t.Fatalf("LastKnownQuota before any response = %+v, want nil", q)
+	}
+	if _, err := c.GetQuotaStatus(t.Context()); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("GetQuotaStatus without headers = %v, want %v", err, ErrNotSupported)
+	}
+
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+	headers = map[string]string{
+		"X-RateLimit-Limit":                     "100",
+		"X-RateLimit-Remaining":                 "42",
+		"X-RateLimit-Reset":                     strconv.FormatInt(reset.Unix(), 10),
+		"X-RateLimit-Limit-Spans-Per-Minute":    "1000",
+		"X-RateLimit-Used-Spans-Per-Minute":     "250",
+		"X-RateLimit-Limit-Annotations-Per-Day": "500",
+		"X-RateLimit-Used-Annotations-Per-Day":  "7",
+	}
+	q, err := c.GetQuotaStatus(t.Context())
+	if err != nil {
+		t.Fatalf("GetQuotaStatus: %v", err)
+	}
+	want := QuotaStatus{
+		Limit:                  100,
+		Remaining:              42,
+		SpansPerMinuteLimit:    1000,
+		SpansPerMinuteUsed:     250,
+		AnnotationsPerDayLimit: 500,
+		AnnotationsPerDayUsed:  7,
+		ResetAt:                reset,
+		ObservedAt:             q.ObservedAt,
+	}
+	if !q.ResetAt.Equal(reset) || q.ObservedAt.IsZero() {
+		t.Errorf("GetQuotaStatus times = %v, %v", q.ResetAt, q.ObservedAt)
+	}
+	q.ResetAt = reset
+	if *q != want {
+		t.Errorf("GetQuotaStatus = %+v, want %+v", *q, want)
+	}
+
+	// LastKnownQuota returns a copy of the cached status.
+	q.Remaining = 0
+	if last := c.LastKnownQuota(); last == nil || last.Remaining != 42 {
+		t.Errorf("LastKnownQuota = %+v, want the cached status", last)
+	}
+
+	// Once headers stop arriving, the cached status is not reported as current.
+	headers = map[string]string{}
+	if _, err := c.GetQuotaStatus(t.Context()); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("GetQuotaStatus without new headers = %v, want %v", err, ErrNotSupported)
+	}
+	if last := c.LastKnownQuota(); last == nil || last.Limit != 100 {
+		t.Errorf("LastKnownQuota = %+v, want the last seen status", last)
+	}
+}
+
+func TestQuotaResetSeconds(t *testing.T) {
+	var tracker quotaTracker
+	before := time.Now()
+	tracker.observe(&http.Response{Header: http.Header{
+		"X-Ratelimit-Limit": {"10"},
+		"X-Ratelimit-Reset": {"30"},
+	}})
+	q := tracker.get()
+	if q == nil || q.Limit != 10 {
+		t.Fatalf("observed quota = %+v, want limit 10", q)
+	}
+	if q.ResetAt.Before(before.Add(30*time.Second)) || q.ResetAt.After(time.Now().Add(30*time.Second)) {
+		t.Errorf("ResetAt = %v, want 30s after the response", q.ResetAt)
+	}
+
+	// Only the per-resource headers are enough.
+	tracker.observe(&http.Response{Header: http.Header{"X-Ratelimit-Limit-Spans-Per-Minute": {"5"}}})
+	if q := tracker.get(); q.SpansPerMinuteLimit != 5 || q.Limit != 0 || !q.ResetAt.IsZero() {
+		t.Errorf("observed quota = %+v, want only the span limit", q)
+	}
+}
@@ -0,0 +1,81 @@
+package phoenix
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+)
+
+// requestIDHeaders are the response headers checked, in order, for a
+// server-assigned request ID.
+var requestIDHeaders = []string{"X-Request-ID", "X-Trace-ID"}
+
+// requestIDFromHeader returns the request ID carried by h, or "" if none.
+func requestIDFromHeader(h http.Header) string {
+	for _, name := range requestIDHeaders {
+		if id := h.Get(name); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// requestIDTracker remembers the request ID of the most recent response.
+type requestIDTracker struct {
+	last atomic.Value // string
+}
+
+func (t *requestIDTracker) observe(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	t.last.Store(requestIDFromHeader(resp.Header))
+}
+
+func (t *requestIDTracker) load() string {
+	id, _ := t.last.Load().(string)
+	return id
+}
+
+// requestIDRecorder records the request ID of the latest response to a
+// request made with the context it is attached to.
+type requestIDRecorder struct {
+	requestIDTracker
+}
+
+type requestIDRecorderKey struct{}
+
+// withRequestIDRecorder attaches a requestIDRecorder to ctx, so that errors
+// built from it carry the ID of the call's own response rather than that of
+// whichever request finished last. A recorder already attached to ctx is
+// reused.
+func withRequestIDRecorder(ctx context.Context) context.Context {
+	if requestIDRecorderFrom(ctx) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, requestIDRecorderKey{}, &requestIDRecorder{})
+}
+
+// requestIDRecorderFrom returns the recorder attached to ctx, or nil.
+func requestIDRecorderFrom(ctx context.Context) *requestIDRecorder {
+	rec, _ := ctx.Value(requestIDRecorderKey{}).(*requestIDRecorder)
+	return rec
+}
+
+// LastRequestID returns the request ID of the most recent API response, or
+// "" if the server did not send one. When the client is used concurrently it
+// may belong to a different call than the one being inspected.
+func (c *Client) LastRequestID() string {
+	return c.requestIDs.load()
+}
+
+// unexpectedResponseError returns the error for an API response variant the
+// client does not handle, tagged with the request ID of the response recorded
+// for ctx.
+func (c *Client) unexpectedResponseError(ctx context.Context) *APIError {
+	err := &APIError{Message: "unexpected response type"}
+	if rec := requestIDRecorderFrom(ctx); rec != nil {
+		err.RequestID = rec.load()
+	}
+	return err
+}
@@ -0,0 +1,46 @@
+package phoenix
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestAPIErrorRequestID(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/projects/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-ID", "req-"+r.PathValue("id"))
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("forbidden"))
+	})
+	c := newTestClient(t, mux)
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id := fmt.Sprintf("p%d", i)
+			_, err := c.GetProject(t.Context(), id)
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Errorf("GetProject(%s) = %v, want *APIError", id, err)
+				return
+			}
+			if want := "req-" + id; apiErr.RequestID != want {
+				t.Errorf("GetProject(%s) request ID = %q, want %q", id, apiErr.RequestID, want)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if _, err := c.GetProject(t.Context(), "last"); err == nil {
+		t.Fatal("GetProject succeeded, want error")
+	}
+	if got := c.LastRequestID(); got != "req-last" {
+		t.Errorf("LastRequestID = %q, want req-last", got)
+	}
+}
@@ -0,0 +1,164 @@
+package phoenix
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"sync/atomic"
+	"time"
+)
+
+// BackoffFunc returns the delay before retry number attempt, starting at 1.
+type BackoffFunc func(attempt int) time.Duration
+
+// RetryPolicy controls how failed requests are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Values below 2 disable retries.
+	MaxAttempts int
+
+	// RetryOn lists the HTTP status codes that trigger a retry, e.g.
+	// 429, 502, 503 and 504.
+	RetryOn []int
+
+	// RetryOnNetworkError retries requests that fail without a response.
+	// Context cancellation is never retried.
+	RetryOnNetworkError bool
+
+	// Backoff computes the delay between attempts.
+	// Defaults to ExponentialBackoff(100*time.Millisecond, 5*time.Second).
+	Backoff BackoffFunc
+}
+
+// ExponentialBackoff returns a BackoffFunc that doubles the delay on each
+// attempt, starting at base and never exceeding limit.
+func ExponentialBackoff(base, limit time.Duration) BackoffFunc {
+	return func(attempt int) time.Duration {
+		d := base
+		for i := 1; i < attempt; i++ {
+			d *= 2
+			if d >= limit {
+				return limit
+			}
+		}
+		return min(d, limit)
+	}
+}
+
+// LinearBackoff returns a BackoffFunc that waits base times the attempt number.
+func LinearBackoff(base time.Duration) BackoffFunc {
+	return func(attempt int) time.Duration {
+		return base * time.Duration(attempt)
+	}
+}
+
+// RetryStats reports retry activity since the client was created.
+// All counts are zero unless a retry policy is configured.
+type RetryStats struct {
+	// Requests is the number of logical requests sent.
+	Requests int64
+	// Retries is the number of additional attempts made.
+	Retries int64
+	// Exhausted is the number of requests that still failed after the last
+	// allowed attempt.
+	Exhausted int64
+}
+
+// WithRetryPolicy retries failed requests according to p.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(o *clientOptions) {
+		o.retryPolicy = &p
+	}
+}
+
+// RetryStats returns retry counters for this client.
+func (c *Client) RetryStats() RetryStats {
+	if c.retrier == nil {
+		return RetryStats{}
+	}
+	return RetryStats{
+		Requests:  c.retrier.requests.Load(),
+		Retries:   c.retrier.retries.Load(),
+		Exhausted: c.retrier.exhausted.Load(),
+	}
+}
+
+// retrier sends requests according to a RetryPolicy and counts attempts.
+type retrier struct {
+	policy RetryPolicy
+
+	requests  atomic.Int64
+	retries   atomic.Int64
+	exhausted atomic.Int64
+}
+
+func newRetrier(p RetryPolicy) *retrier {
+	if p.Backoff == nil {
+		p.Backoff = ExponentialBackoff(100*time.Millisecond, 5*time.Second)
+	}
+	return &retrier{policy: p}
+}
+
+func (r *retrier) do(client *http.Client, req *http.Request) (*http.Response, error) {
+	r.requests.Add(1)
+
+	// Make the body replayable so it can be resent on retry.
+	if req.Body != nil && req.GetBody == nil {
+		body, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+		req.Body, _ = req.GetBody()
+	}
+
+	maxAttempts := max(r.policy.MaxAttempts, 1)
+	for attempt := 1; ; attempt++ {
+		resp, err := client.Do(req)
+		if !r.shouldRetry(req.Context(), resp, err) {
+			return resp, err
+		}
+		if attempt >= maxAttempts {
+			r.exhausted.Add(1)
+			return resp, err
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		timer := time.NewTimer(r.policy.Backoff(attempt))
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+		r.retries.Add(1)
+	}
+}
+
+func (r *retrier) shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		if ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false
+		}
+		return r.policy.RetryOnNetworkError
+	}
+	return slices.Contains(r.policy.RetryOn, resp.StatusCode)
+}
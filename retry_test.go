@@ -0,0 +1,158 @@
+package phoenix
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	exp := ExponentialBackoff(100*time.Millisecond, time.Second)
+	for attempt, want := range map[int]time.Duration{
+		1:  100 * time.Millisecond,
+		2:  200 * time.Millisecond,
+		4:  800 * time.Millisecond,
+		5:  time.Second,
+		60: time.Second,
+	} {
+		if got := exp(attempt); got != want {
+			t.Errorf("ExponentialBackoff(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+	if got := ExponentialBackoff(2*time.Second, time.Second)(1); got != time.Second {
+		t.Errorf("ExponentialBackoff with base above the limit = %v, want %v", got, time.Second)
+	}
+
+	linear := LinearBackoff(50 * time.Millisecond)
+	if got := linear(3); got != 150*time.Millisecond {
+		t.Errorf("LinearBackoff(3) = %v, want %v", got, 150*time.Millisecond)
+	}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	var statuses []int
+	var bodies []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/span_annotations", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		status := statuses[0]
+		statuses = statuses[1:]
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		writeJSON(t, w, map[string]any{"data": []map[string]any{{"id": "a1"}}})
+	})
+	c := newTestClient(t, mux, WithRetryPolicy(RetryPolicy{
+		MaxAttempts: 3,
+		RetryOn:     []int{http.StatusTooManyRequests, http.StatusServiceUnavailable},
+		Backoff:     LinearBackoff(0),
+	}))
+	annotate := func() error {
+		return c.CreateSpanAnnotations(t.Context(), []*Annotation{{SpanID: "s1", Name: "quality", Score: 1}})
+	}
+
+	// Listed status codes are retried, resending the request body.
+	statuses = []int{http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusOK}
+	if err := annotate(); err != nil {
+		t.Fatalf("CreateSpanAnnotations: %v", err)
+	}
+	if len(bodies) != 3 || bodies[0] == "" || bodies[1] != bodies[0] || bodies[2] != bodies[0] {
+		t.Errorf("request bodies = %q, want the same body three times", bodies)
+	}
+	if got, want := c.RetryStats(), (RetryStats{Requests: 1, Retries: 2}); got != want {
+		t.Errorf("RetryStats = %+v, want %+v", got, want)
+	}
+
+	// Other status codes are not.
+	bodies = nil
+	statuses = []int{http.StatusInternalServerError}
+	if err := annotate(); err == nil {
+		t.Error("CreateSpanAnnotations succeeded after a 500")
+	}
+	if len(bodies) != 1 {
+		t.Errorf("sent %d requests for a 500, want 1", len(bodies))
+	}
+
+	// Requests failing on every attempt are counted as exhausted.
+	bodies = nil
+	statuses = []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable}
+	if err := annotate(); err == nil {
+		t.Error("CreateSpanAnnotations succeeded after exhausting retries")
+	}
+	if len(bodies) != 3 {
+		t.Errorf("sent %d requests, want 3", len(bodies))
+	}
+	if got, want := c.RetryStats(), (RetryStats{Requests: 3, Retries: 4, Exhausted: 1}); got != want {
+		t.Errorf("RetryStats = %+v, want %+v", got, want)
+	}
+}
+
+// roundTripFunc is an http.RoundTripper backed by a function.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestRetryNetworkErrors(t *testing.T) {
+	errNetwork := errors.New("connection reset")
+	attempts := 0
+	client := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, errNetwork
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+	})}
+	newRequest := func(ctx context.Context) *http.Request {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://phoenix.test/v1/projects", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return req
+	}
+
+	r := newRetrier(RetryPolicy{MaxAttempts: 5, RetryOnNetworkError: true, Backoff: LinearBackoff(0)})
+	resp, err := r.do(client, newRequest(t.Context()))
+	if err != nil {
+		t.Fatalf("do: %v", err)
+	}
+	_ = resp.Body.Close()
+	if attempts != 3 {
+		t.Errorf("made %d attempts, want 3", attempts)
+	}
+
+	// Network errors are returned as is unless RetryOnNetworkError is set.
+	attempts = 0
+	r = newRetrier(RetryPolicy{MaxAttempts: 5, Backoff: LinearBackoff(0)})
+	if _, err := r.do(client, newRequest(t.Context())); !errors.Is(err, errNetwork) || attempts != 1 {
+		t.Errorf("do = %v after %d attempts, want %v after 1", err, attempts, errNetwork)
+	}
+
+	// Cancellation is never retried.
+	attempts = 0
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	r = newRetrier(RetryPolicy{MaxAttempts: 5, RetryOnNetworkError: true, Backoff: LinearBackoff(0)})
+	if _, err := r.do(client, newRequest(ctx)); err == nil || attempts > 1 {
+		t.Errorf("do = %v after %d attempts, want a cancellation error without retries", err, attempts)
+	}
+}
+
+func TestRetryStatsWithoutPolicy(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/projects", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_ = json.NewEncoder(w).Encode(map[string]any{})
+	})
+	c := newTestClient(t, mux)
+	_, _, _ = c.ListProjects(t.Context())
+	if got := c.RetryStats(); got != (RetryStats{}) {
+		t.Errorf("RetryStats = %+v, want zero", got)
+	}
+}
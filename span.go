@@ -2,9 +2,11 @@ package phoenix
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/agentplexus/go-phoenix/internal/api"
+	phoenixotel "github.com/agentplexus/go-phoenix/otel"
 )
 
 // Span represents a Phoenix span.
@@ -19,10 +21,139 @@ type Span struct {
 	SpanID        string
 	StartTime     time.Time
 	EndTime       time.Time
+	// Attributes holds the span's OpenInference attributes.
+	Attributes map[string]any
+	// Annotations is nil unless loaded with Client.AttachSpanAnnotations.
+	Annotations []*Annotation
+	// Events holds the span's OTEL events in the order they were recorded,
+	// such as feedback scores and agent steps.
+	Events []SpanEvent
+}
+
+// Span status codes, as reported in Span.StatusCode.
+const (
+	SpanStatusUnset = "UNSET"
+	SpanStatusOK    = "OK"
+	SpanStatusError = "ERROR"
+)
+
+// SpanEvent is an event recorded on a span.
+type SpanEvent struct {
+	Name       string
+	Timestamp  time.Time
+	Attributes map[string]any
+}
+
+// EventsByName returns the span's events with the given name.
+func (s *Span) EventsByName(name string) []SpanEvent {
+	var events []SpanEvent
+	for _, e := range s.Events {
+		if e.Name == name {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// TokenUsage holds LLM token counts.
+type TokenUsage struct {
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+}
+
+// ModelName returns the span's llm.model_name attribute, or "" if unset.
+func (s *Span) ModelName() string {
+	return s.stringAttribute(phoenixotel.LLMModelName)
+}
+
+// LLMProvider returns the span's llm.provider attribute, or "" if unset.
+func (s *Span) LLMProvider() string {
+	return s.stringAttribute(phoenixotel.LLMProvider)
+}
+
+// InputValue returns the span's input.value attribute, or "" if unset.
+func (s *Span) InputValue() string {
+	return s.stringAttribute(phoenixotel.InputValue)
+}
+
+// OutputValue returns the span's output.value attribute, or "" if unset.
+func (s *Span) OutputValue() string {
+	return s.stringAttribute(phoenixotel.OutputValue)
+}
+
+// SessionID returns the span's session.id attribute, or "" if unset.
+func (s *Span) SessionID() string {
+	return s.stringAttribute(phoenixotel.SessionID)
+}
+
+// UserID returns the span's user.id attribute, or "" if unset.
+func (s *Span) UserID() string {
+	return s.stringAttribute(phoenixotel.UserID)
+}
+
+// ToolName returns the span's tool.name attribute, or "" if unset.
+func (s *Span) ToolName() string {
+	return s.stringAttribute(phoenixotel.ToolName)
+}
+
+// Metadata returns the span's metadata attribute, decoding it if it was
+// recorded as a JSON string. It returns nil if the attribute is unset or is
+// not a JSON object.
+func (s *Span) Metadata() map[string]any {
+	return s.objectAttribute(phoenixotel.MetadataKey)
+}
+
+// InvocationParameters returns the span's llm.invocation_parameters
+// attribute, such as temperature and max_tokens, decoded like Metadata.
+func (s *Span) InvocationParameters() map[string]any {
+	return s.objectAttribute(phoenixotel.LLMInvocationParams)
+}
+
+// TokenUsage returns the span's llm.token_count.* attributes. Missing
+// counts are zero.
+func (s *Span) TokenUsage() TokenUsage {
+	return TokenUsage{
+		PromptTokens:     attributeInt(s.Attributes, phoenixotel.LLMTokenCountPrompt),
+		CompletionTokens: attributeInt(s.Attributes, phoenixotel.LLMTokenCountCompletion),
+		TotalTokens:      attributeInt(s.Attributes, phoenixotel.LLMTokenCountTotal),
+	}
+}
+
+// stringAttribute returns the attribute with the given key as a string,
+// or "" if it is absent.
+func (s *Span) stringAttribute(key string) string {
+	v, ok := lookupAttribute(s.Attributes, key)
+	if !ok {
+		return ""
+	}
+	return attributeString(v)
+}
+
+// objectAttribute returns the attribute with the given key as a map,
+// decoding JSON strings.
+func (s *Span) objectAttribute(key string) map[string]any {
+	v, ok := lookupAttribute(s.Attributes, key)
+	if !ok {
+		return nil
+	}
+	switch val := v.(type) {
+	case map[string]any:
+		return val
+	case string:
+		var m map[string]any
+		if err := json.Unmarshal([]byte(val), &m); err != nil {
+			return nil
+		}
+		return m
+	default:
+		return nil
+	}
 }
 
 // GetSpans retrieves spans for a project.
 func (c *Client) GetSpans(ctx context.Context, projectIdentifier string, opts ...SpanOption) ([]*Span, string, error) {
+	ctx = withRequestIDRecorder(ctx)
 	options := &spanOptions{
 		limit: 100,
 	}
@@ -39,6 +170,12 @@ func (c *Client) GetSpans(ctx context.Context, projectIdentifier string, opts ..
 	if options.limit > 0 {
 		params.Limit.SetTo(options.limit)
 	}
+	if !options.start.IsZero() {
+		params.StartTime.SetTo(options.start)
+	}
+	if !options.end.IsZero() {
+		params.EndTime.SetTo(options.end)
+	}
 
 	res, err := c.apiClient.GetSpans(ctx, params)
 	if err != nil {
@@ -47,11 +184,14 @@ func (c *Client) GetSpans(ctx context.Context, projectIdentifier string, opts ..
 
 	resp, ok := res.(*api.SpansResponseBody)
 	if !ok {
-		return nil, "", &APIError{Message: "unexpected response type"}
+		return nil, "", c.unexpectedResponseError(ctx)
 	}
 
 	spans := make([]*Span, 0, len(resp.Data))
 	for i := range resp.Data {
+		if !options.matches(&resp.Data[i]) {
+			continue
+		}
 		spans = append(spans, convertSpan(&resp.Data[i]))
 	}
 
@@ -63,6 +203,81 @@ func (c *Client) GetSpans(ctx context.Context, projectIdentifier string, opts ..
 	return spans, nextCursor, nil
 }
 
+// GetSpan retrieves a span by its OpenTelemetry span ID or Phoenix global ID.
+//
+// Phoenix does not expose an endpoint for fetching a single span, so this
+// pages through the spans of the client's default project, 1000 at a time,
+// until a match is found. A lookup can therefore list the whole project; to
+// look up many spans, list them once with GetSpans instead. Returns
+// ErrSpanNotFound if no span matches, or ErrProjectNotFound if the project
+// does not exist.
+func (c *Client) GetSpan(ctx context.Context, spanID string) (*Span, error) {
+	s, err := c.findSpan(ctx, spanID)
+	if err != nil {
+		return nil, err
+	}
+	return convertSpan(s), nil
+}
+
+// GetSpanAttributes returns all attributes recorded on a span, including
+// OpenInference attributes such as llm.model_name and input.value.
+// See GetSpan for how the span is located.
+func (c *Client) GetSpanAttributes(ctx context.Context, spanID string) (map[string]any, error) {
+	s, err := c.findSpan(ctx, spanID)
+	if err != nil {
+		return nil, err
+	}
+	attrs := map[string]any{}
+	if s.Attributes.Set {
+		for k, v := range convertRawMap(s.Attributes.Value) {
+			attrs[k] = v
+		}
+	}
+	return attrs, nil
+}
+
+// findSpan pages through the default project's spans looking for spanID.
+func (c *Client) findSpan(ctx context.Context, spanID string) (*api.Span, error) {
+	ctx = withRequestIDRecorder(ctx)
+	if spanID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	params := api.GetSpansParams{
+		ProjectIdentifier: c.config.ProjectName,
+	}
+	params.Limit.SetTo(1000)
+
+	for {
+		res, err := c.apiClient.GetSpans(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp *api.SpansResponseBody
+		switch r := res.(type) {
+		case *api.SpansResponseBody:
+			resp = r
+		case *api.GetSpansNotFound:
+			return nil, ErrProjectNotFound
+		default:
+			return nil, c.unexpectedResponseError(ctx)
+		}
+
+		for i := range resp.Data {
+			s := &resp.Data[i]
+			if s.Context.SpanID == spanID || (s.ID.Set && s.ID.Value == spanID) {
+				return s, nil
+			}
+		}
+
+		if resp.NextCursor.Null || resp.NextCursor.Value == "" {
+			return nil, ErrSpanNotFound
+		}
+		params.Cursor.SetTo(resp.NextCursor.Value)
+	}
+}
+
 // DeleteSpan deletes a span.
 func (c *Client) DeleteSpan(ctx context.Context, spanIdentifier string) error {
 	_, err := c.apiClient.DeleteSpan(ctx, api.DeleteSpanParams{
@@ -83,8 +298,27 @@ func (c *Client) DeleteTrace(ctx context.Context, traceIdentifier string) error
 type SpanOption func(*spanOptions)
 
 type spanOptions struct {
-	cursor string
-	limit  int
+	cursor     string
+	limit      int
+	filters    []SpanFilter
+	start, end time.Time
+}
+
+// matches reports whether a span satisfies all configured filters.
+func (o *spanOptions) matches(s *api.Span) bool {
+	if len(o.filters) == 0 {
+		return true
+	}
+	var attrs map[string]any
+	if s.Attributes.Set {
+		attrs = convertRawMap(s.Attributes.Value)
+	}
+	for _, f := range o.filters {
+		if !f.Match(attrs) {
+			return false
+		}
+	}
+	return true
 }
 
 // WithSpanCursor sets the pagination cursor for spans.
@@ -101,6 +335,35 @@ func WithSpanLimit(limit int) SpanOption {
 	}
 }
 
+// WithSpanTimeRange only returns spans that started at or after start and
+// before end. A zero start or end leaves that side of the range open.
+func WithSpanTimeRange(start, end time.Time) SpanOption {
+	return func(o *spanOptions) {
+		o.start = start
+		o.end = end
+	}
+}
+
+// WithAttributeFilter only returns spans whose attribute key equals value.
+// Filtering is applied client-side; see SpanFilter.
+func WithAttributeFilter(key, value string) SpanOption {
+	return WithSpanFilter(AttributeEquals(key, value))
+}
+
+// WithAttributeContains only returns spans whose attribute key contains substring.
+// Filtering is applied client-side; see SpanFilter.
+func WithAttributeContains(key, substring string) SpanOption {
+	return WithSpanFilter(AttributeContains(key, substring))
+}
+
+// WithSpanFilter only returns spans matching the given filter.
+// Multiple filters are combined with AND.
+func WithSpanFilter(filter SpanFilter) SpanOption {
+	return func(o *spanOptions) {
+		o.filters = append(o.filters, filter)
+	}
+}
+
 func convertSpan(s *api.Span) *Span {
 	if s == nil {
 		return nil
@@ -114,6 +377,9 @@ func convertSpan(s *api.Span) *Span {
 		TraceID:    s.Context.TraceID,
 		SpanID:     s.Context.SpanID,
 	}
+	if s.Attributes.Set {
+		span.Attributes = convertRawMap(s.Attributes.Value)
+	}
 	if s.ID.Set {
 		span.ID = s.ID.Value
 	}
@@ -123,5 +389,18 @@ func convertSpan(s *api.Span) *Span {
 	if s.ParentID.Set && !s.ParentID.Null {
 		span.ParentID = s.ParentID.Value
 	}
+	if len(s.Events) > 0 {
+		span.Events = make([]SpanEvent, 0, len(s.Events))
+		for _, e := range s.Events {
+			event := SpanEvent{
+				Name:      e.Name,
+				Timestamp: e.Timestamp,
+			}
+			if e.Attributes.Set {
+				event.Attributes = convertRawMap(e.Attributes.Value)
+			}
+			span.Events = append(span.Events, event)
+		}
+	}
 	return span
 }
@@ -0,0 +1,111 @@
+package phoenix
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SpanFilter is a composable predicate over span attributes.
+//
+// A leaf filter matches a single attribute by Key, either by exact Value or,
+// when Contains is set, by substring. Group filters combine child filters
+// with And or Or. A filter with both a leaf condition and groups requires all
+// of them to match.
+//
+// The Phoenix spans endpoint does not support attribute queries, so filters
+// are evaluated client-side after each page is fetched. Filtering therefore
+// does not reduce the amount of data transferred, and a page may contain
+// fewer spans than the requested limit (or none) while a next cursor is
+// still returned.
+type SpanFilter struct {
+	Key      string
+	Value    string
+	Contains bool
+
+	And []SpanFilter
+	Or  []SpanFilter
+}
+
+// AttributeEquals returns a filter matching spans whose attribute equals value.
+func AttributeEquals(key, value string) SpanFilter {
+	return SpanFilter{Key: key, Value: value}
+}
+
+// AttributeContains returns a filter matching spans whose attribute contains substring.
+func AttributeContains(key, substring string) SpanFilter {
+	return SpanFilter{Key: key, Value: substring, Contains: true}
+}
+
+// And returns a filter that matches only when all filters match.
+func And(filters ...SpanFilter) SpanFilter {
+	return SpanFilter{And: filters}
+}
+
+// Or returns a filter that matches when any of the filters match.
+func Or(filters ...SpanFilter) SpanFilter {
+	return SpanFilter{Or: filters}
+}
+
+// Match reports whether the given span attributes satisfy the filter.
+func (f SpanFilter) Match(attrs map[string]any) bool {
+	if f.Key != "" {
+		v, ok := lookupAttribute(attrs, f.Key)
+		if !ok {
+			return false
+		}
+		str := attributeString(v)
+		if f.Contains {
+			if !strings.Contains(str, f.Value) {
+				return false
+			}
+		} else if str != f.Value {
+			return false
+		}
+	}
+	for _, child := range f.And {
+		if !child.Match(attrs) {
+			return false
+		}
+	}
+	if len(f.Or) > 0 {
+		for _, child := range f.Or {
+			if child.Match(attrs) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// lookupAttribute finds an attribute by its dotted key. Phoenix may return
+// attributes either flattened ("llm.model_name") or nested
+// ({"llm": {"model_name": ...}}), so both forms are checked.
+func lookupAttribute(attrs map[string]any, key string) (any, bool) {
+	if v, ok := attrs[key]; ok {
+		return v, true
+	}
+	var current any = attrs
+	for _, part := range strings.Split(key, ".") {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = m[part]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// attributeString renders an attribute value for comparison.
+func attributeString(v any) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(val)
+	}
+}
@@ -0,0 +1,346 @@
+package phoenix
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/agentplexus/go-phoenix/internal/api"
+	phoenixotel "github.com/agentplexus/go-phoenix/otel"
+)
+
+// defaultSpanImportBatchSize is the number of spans sent per API call.
+const defaultSpanImportBatchSize = 100
+
+// ImportSpansFromOTLPJSON reads spans from an OTLP JSON export, as written by
+// the OpenTelemetry Collector's file exporter, and creates them in the named
+// project. The file holds one JSON-encoded TracesData object per line.
+//
+// Lines that are not valid OTLP JSON, and spans with missing or malformed
+// IDs, are skipped and reported in a *BatchImportError, where Row is the
+// line number; the remaining spans are still imported. The returned count
+// is the number of spans successfully created.
+//
+// Spans are sent with the REST span endpoint rather than the OpenTelemetry
+// exporter of the otel package. A tracer cannot start spans with the IDs and
+// timestamps recorded in the file, and the batching exporter reports
+// failures asynchronously, whereas the REST call returns errors such as
+// ErrProjectNotFound for each batch.
+func ImportSpansFromOTLPJSON(ctx context.Context, client *Client, projectName, filePath string) (int, error) {
+	if client == nil {
+		return 0, fmt.Errorf("%w: client is required", ErrInvalidInput)
+	}
+	if projectName == "" {
+		return 0, fmt.Errorf("%w: project name is required", ErrInvalidInput)
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	var (
+		imported  int
+		batch     []api.Span
+		importErr BatchImportError
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := client.createSpans(ctx, projectName, batch); err != nil {
+			return err
+		}
+		imported += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	r := bufio.NewReader(f)
+	for row := 1; ; row++ {
+		line, err := r.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return imported, err
+		}
+		eof := err != nil
+
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			spans, err := parseOTLPTraces(line)
+			if err != nil {
+				importErr.Errors = append(importErr.Errors, &RowError{Row: row, Err: err})
+			}
+			for _, s := range spans {
+				batch = append(batch, toAPISpan(s))
+				if len(batch) >= defaultSpanImportBatchSize {
+					if err := flush(); err != nil {
+						return imported, err
+					}
+				}
+			}
+		}
+		if eof {
+			break
+		}
+	}
+	if err := flush(); err != nil {
+		return imported, err
+	}
+
+	if len(importErr.Errors) > 0 {
+		return imported, &importErr
+	}
+	return imported, nil
+}
+
+// createSpans creates spans in a project.
+func (c *Client) createSpans(ctx context.Context, projectIdentifier string, spans []api.Span) error {
+	ctx = withRequestIDRecorder(ctx)
+	res, err := c.apiClient.CreateSpans(ctx, &api.CreateSpansRequestBody{
+		Data: spans,
+	}, api.CreateSpansParams{
+		ProjectIdentifier: projectIdentifier,
+	})
+	if err != nil {
+		return err
+	}
+
+	switch res.(type) {
+	case *api.CreateSpansResponseBody:
+		return nil
+	case *api.CreateSpansNotFound:
+		return ErrProjectNotFound
+	default:
+		return c.unexpectedResponseError(ctx)
+	}
+}
+
+// toAPISpan converts a span to its API representation.
+func toAPISpan(s *Span) api.Span {
+	span := api.Span{
+		Context: api.SpanContext{
+			TraceID: s.TraceID,
+			SpanID:  s.SpanID,
+		},
+		Name:       s.Name,
+		SpanKind:   s.SpanKind,
+		StatusCode: s.StatusCode,
+		StartTime:  s.StartTime,
+		EndTime:    s.EndTime,
+		Events:     make([]api.SpanEvent, 0, len(s.Events)),
+	}
+	if attrs, err := encodeRawMap[api.SpanAttributes](s.Attributes); err == nil && len(attrs) > 0 {
+		span.Attributes.SetTo(attrs)
+	}
+	if s.StatusMessage != "" {
+		span.StatusMessage.SetTo(s.StatusMessage)
+	}
+	if s.ParentID != "" {
+		span.ParentID.SetTo(s.ParentID)
+	}
+	for _, e := range s.Events {
+		event := api.SpanEvent{
+			Name:      e.Name,
+			Timestamp: e.Timestamp,
+		}
+		if attrs, err := encodeRawMap[api.SpanEventAttributes](e.Attributes); err == nil && len(attrs) > 0 {
+			event.Attributes.SetTo(attrs)
+		}
+		span.Events = append(span.Events, event)
+	}
+	return span
+}
+
+// OTLP JSON encoding of TracesData. IDs are hex strings, and 64-bit
+// integers may be encoded as JSON strings or numbers.
+type (
+	otlpTracesData struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []otlpSpan `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+
+	otlpSpan struct {
+		TraceID           string          `json:"traceId"`
+		SpanID            string          `json:"spanId"`
+		ParentSpanID      string          `json:"parentSpanId"`
+		Name              string          `json:"name"`
+		StartTimeUnixNano otlpInt         `json:"startTimeUnixNano"`
+		EndTimeUnixNano   otlpInt         `json:"endTimeUnixNano"`
+		Attributes        []otlpKeyValue  `json:"attributes"`
+		Events            []otlpSpanEvent `json:"events"`
+		Status            struct {
+			Code    json.RawMessage `json:"code"`
+			Message string          `json:"message"`
+		} `json:"status"`
+	}
+
+	otlpSpanEvent struct {
+		TimeUnixNano otlpInt        `json:"timeUnixNano"`
+		Name         string         `json:"name"`
+		Attributes   []otlpKeyValue `json:"attributes"`
+	}
+
+	otlpKeyValue struct {
+		Key   string       `json:"key"`
+		Value otlpAnyValue `json:"value"`
+	}
+
+	otlpAnyValue struct {
+		StringValue *string  `json:"stringValue"`
+		BoolValue   *bool    `json:"boolValue"`
+		IntValue    *otlpInt `json:"intValue"`
+		DoubleValue *float64 `json:"doubleValue"`
+		BytesValue  *string  `json:"bytesValue"`
+		ArrayValue  *struct {
+			Values []otlpAnyValue `json:"values"`
+		} `json:"arrayValue"`
+		KvlistValue *struct {
+			Values []otlpKeyValue `json:"values"`
+		} `json:"kvlistValue"`
+	}
+)
+
+// otlpInt is a 64-bit integer encoded as a JSON string or number.
+type otlpInt int64
+
+func (i *otlpInt) UnmarshalJSON(data []byte) error {
+	n, err := strconv.ParseInt(string(bytes.Trim(data, `"`)), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid integer %s", data)
+	}
+	*i = otlpInt(n)
+	return nil
+}
+
+// parseOTLPTraces decodes one TracesData object. Spans that cannot be
+// converted are skipped, and the valid spans are returned along with the
+// errors for the others.
+func parseOTLPTraces(data []byte) ([]*Span, error) {
+	var traces otlpTracesData
+	if err := json.Unmarshal(data, &traces); err != nil {
+		return nil, fmt.Errorf("%w: invalid OTLP JSON: %w", ErrInvalidInput, err)
+	}
+
+	var (
+		spans []*Span
+		errs  []error
+	)
+	for _, rs := range traces.ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			for i := range ss.Spans {
+				span, err := convertOTLPSpan(&ss.Spans[i])
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				spans = append(spans, span)
+			}
+		}
+	}
+	return spans, errors.Join(errs...)
+}
+
+func convertOTLPSpan(s *otlpSpan) (*Span, error) {
+	if !isHexID(s.TraceID, 16) {
+		return nil, fmt.Errorf("%w: span %q: invalid trace ID %q", ErrInvalidInput, s.Name, s.TraceID)
+	}
+	if !isHexID(s.SpanID, 8) {
+		return nil, fmt.Errorf("%w: span %q: invalid span ID %q", ErrInvalidInput, s.Name, s.SpanID)
+	}
+	if s.ParentSpanID != "" && !isHexID(s.ParentSpanID, 8) {
+		return nil, fmt.Errorf("%w: span %q: invalid parent span ID %q", ErrInvalidInput, s.Name, s.ParentSpanID)
+	}
+
+	span := &Span{
+		Name:          s.Name,
+		TraceID:       s.TraceID,
+		SpanID:        s.SpanID,
+		ParentID:      s.ParentSpanID,
+		StatusCode:    otlpStatusCode(s.Status.Code),
+		StatusMessage: s.Status.Message,
+		StartTime:     time.Unix(0, int64(s.StartTimeUnixNano)).UTC(),
+		EndTime:       time.Unix(0, int64(s.EndTimeUnixNano)).UTC(),
+		Attributes:    otlpAttributes(s.Attributes),
+	}
+	span.SpanKind = "UNKNOWN"
+	if kind, ok := span.Attributes[phoenixotel.OpenInferenceSpanKind].(string); ok && kind != "" {
+		span.SpanKind = kind
+	}
+	for _, e := range s.Events {
+		span.Events = append(span.Events, SpanEvent{
+			Name:       e.Name,
+			Timestamp:  time.Unix(0, int64(e.TimeUnixNano)).UTC(),
+			Attributes: otlpAttributes(e.Attributes),
+		})
+	}
+	return span, nil
+}
+
+// isHexID reports whether id is the hex encoding of a non-zero n-byte ID.
+func isHexID(id string, n int) bool {
+	b, err := hex.DecodeString(id)
+	if err != nil || len(b) != n {
+		return false
+	}
+	return !bytes.Equal(b, make([]byte, n))
+}
+
+// otlpStatusCode converts an OTLP status code, encoded as either its enum
+// number or name, to a span status code.
+func otlpStatusCode(code json.RawMessage) string {
+	switch string(bytes.Trim(code, `"`)) {
+	case "1", "STATUS_CODE_OK":
+		return SpanStatusOK
+	case "2", "STATUS_CODE_ERROR":
+		return SpanStatusError
+	default:
+		return SpanStatusUnset
+	}
+}
+
+func otlpAttributes(kvs []otlpKeyValue) map[string]any {
+	if len(kvs) == 0 {
+		return nil
+	}
+	attrs := make(map[string]any, len(kvs))
+	for _, kv := range kvs {
+		attrs[kv.Key] = kv.Value.value()
+	}
+	return attrs
+}
+
+func (v otlpAnyValue) value() any {
+	switch {
+	case v.StringValue != nil:
+		return *v.StringValue
+	case v.BoolValue != nil:
+		return *v.BoolValue
+	case v.IntValue != nil:
+		return int64(*v.IntValue)
+	case v.DoubleValue != nil:
+		return *v.DoubleValue
+	case v.BytesValue != nil:
+		return *v.BytesValue
+	case v.ArrayValue != nil:
+		values := make([]any, len(v.ArrayValue.Values))
+		for i, elem := range v.ArrayValue.Values {
+			values[i] = elem.value()
+		}
+		return values
+	case v.KvlistValue != nil:
+		return otlpAttributes(v.KvlistValue.Values)
+	default:
+		return nil
+	}
+}
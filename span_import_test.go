@@ -0,0 +1,292 @@
+package phoenix
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testTraceID = "5b8efff798038103d269b633813fc60c"
+	testSpanID  = "eee19b7ec3c1b174"
+)
+
+func TestOTLPInt(t *testing.T) {
+	tests := []struct {
+		data    string
+		want    otlpInt
+		wantErr bool
+	}{
+		{`"1700000000000000000"`, 1700000000000000000, false},
+		{`1700000000`, 1700000000, false},
+		{`"-5"`, -5, false},
+		{`"1.5"`, 0, true},
+		{`"abc"`, 0, true},
+		{`""`, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			var got otlpInt
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal(%s) error = %v, wantErr %v", tt.data, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Unmarshal(%s) = %d, want %d", tt.data, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsHexID(t *testing.T) {
+	tests := []struct {
+		id   string
+		n    int
+		want bool
+	}{
+		{testTraceID, 16, true},
+		{testSpanID, 8, true},
+		{testSpanID, 16, false},
+		{strings.ToUpper(testSpanID), 8, true},
+		{"0000000000000000", 8, false},
+		{"eee19b7ec3c1b17", 8, false},
+		{"zzz19b7ec3c1b174", 8, false},
+		{"", 8, false},
+	}
+	for _, tt := range tests {
+		if got := isHexID(tt.id, tt.n); got != tt.want {
+			t.Errorf("isHexID(%q, %d) = %v, want %v", tt.id, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestConvertOTLPSpan(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	valid := func() otlpSpan {
+		return otlpSpan{
+			TraceID:           testTraceID,
+			SpanID:            testSpanID,
+			Name:              "llm",
+			StartTimeUnixNano: otlpInt(start.UnixNano()),
+			EndTimeUnixNano:   otlpInt(start.Add(time.Second).UnixNano()),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*otlpSpan)
+		check   func(*testing.T, *Span)
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			check: func(t *testing.T, s *Span) {
+				if s.SpanKind != "UNKNOWN" || s.StatusCode != SpanStatusUnset || s.ParentID != "" {
+					t.Errorf("span = %+v, want UNKNOWN kind and UNSET status", s)
+				}
+				if !s.StartTime.Equal(start) || !s.EndTime.Equal(start.Add(time.Second)) {
+					t.Errorf("times = %v, %v", s.StartTime, s.EndTime)
+				}
+			},
+		},
+		{
+			name: "kind, status and parent",
+			modify: func(s *otlpSpan) {
+				str := "LLM"
+				s.Attributes = []otlpKeyValue{{Key: "openinference.span.kind", Value: otlpAnyValue{StringValue: &str}}}
+				s.Status.Code = json.RawMessage(`"STATUS_CODE_ERROR"`)
+				s.Status.Message = "boom"
+				s.ParentSpanID = "aaa19b7ec3c1b174"
+			},
+			check: func(t *testing.T, s *Span) {
+				if s.SpanKind != "LLM" || s.StatusCode != SpanStatusError || s.StatusMessage != "boom" || s.ParentID != "aaa19b7ec3c1b174" {
+					t.Errorf("span = %+v", s)
+				}
+			},
+		},
+		{
+			name: "numeric status",
+			modify: func(s *otlpSpan) {
+				s.Status.Code = json.RawMessage(`1`)
+			},
+			check: func(t *testing.T, s *Span) {
+				if s.StatusCode != SpanStatusOK {
+					t.Errorf("StatusCode = %q, want OK", s.StatusCode)
+				}
+			},
+		},
+		{
+			name: "events",
+			modify: func(s *otlpSpan) {
+				b := true
+				s.Events = []otlpSpanEvent{{
+					Name:         "exception",
+					TimeUnixNano: otlpInt(start.UnixNano()),
+					Attributes:   []otlpKeyValue{{Key: "handled", Value: otlpAnyValue{BoolValue: &b}}},
+				}}
+			},
+			check: func(t *testing.T, s *Span) {
+				want := []SpanEvent{{Name: "exception", Timestamp: start, Attributes: map[string]any{"handled": true}}}
+				if !reflect.DeepEqual(s.Events, want) {
+					t.Errorf("Events = %+v, want %+v", s.Events, want)
+				}
+			},
+		},
+		{name: "bad trace ID", modify: func(s *otlpSpan) { s.TraceID = testSpanID }, wantErr: true},
+		{name: "zero span ID", modify: func(s *otlpSpan) { s.SpanID = "0000000000000000" }, wantErr: true},
+		{name: "bad parent ID", modify: func(s *otlpSpan) { s.ParentSpanID = "xyz" }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := valid()
+			if tt.modify != nil {
+				tt.modify(&s)
+			}
+			span, err := convertOTLPSpan(&s)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidInput) || span != nil {
+					t.Errorf("convertOTLPSpan = %+v, %v, want ErrInvalidInput", span, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("convertOTLPSpan: %v", err)
+			}
+			tt.check(t, span)
+		})
+	}
+}
+
+func TestOTLPAttributes(t *testing.T) {
+	const data = `[
+		{"key": "s", "value": {"stringValue": "v"}},
+		{"key": "i", "value": {"intValue": "42"}},
+		{"key": "d", "value": {"doubleValue": 0.5}},
+		{"key": "b", "value": {"boolValue": false}},
+		{"key": "a", "value": {"arrayValue": {"values": [{"stringValue": "x"}, {"intValue": 1}]}}},
+		{"key": "kv", "value": {"kvlistValue": {"values": [{"key": "k", "value": {"stringValue": "v"}}]}}},
+		{"key": "empty", "value": {}}
+	]`
+	var kvs []otlpKeyValue
+	if err := json.Unmarshal([]byte(data), &kvs); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	want := map[string]any{
+		"s":     "v",
+		"i":     int64(42),
+		"d":     0.5,
+		"b":     false,
+		"a":     []any{"x", int64(1)},
+		"kv":    map[string]any{"k": "v"},
+		"empty": nil,
+	}
+	if got := otlpAttributes(kvs); !reflect.DeepEqual(got, want) {
+		t.Errorf("otlpAttributes = %#v, want %#v", got, want)
+	}
+}
+
+// otlpLine is a TracesData object holding spans with the given span IDs.
+func otlpLine(spanIDs ...string) string {
+	spans := make([]map[string]any, len(spanIDs))
+	for i, id := range spanIDs {
+		spans[i] = map[string]any{
+			"traceId":           testTraceID,
+			"spanId":            id,
+			"name":              "span-" + id,
+			"startTimeUnixNano": "1735689600000000000",
+			"endTimeUnixNano":   "1735689601000000000",
+		}
+	}
+	data, _ := json.Marshal(map[string]any{
+		"resourceSpans": []any{map[string]any{
+			"scopeSpans": []any{map[string]any{"spans": spans}},
+		}},
+	})
+	return string(data)
+}
+
+func TestParseOTLPTraces(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		wantSpans int
+		wantErr   bool
+	}{
+		{"valid", otlpLine(testSpanID, "aaa19b7ec3c1b174"), 2, false},
+		{"empty object", `{}`, 0, false},
+		{"partly invalid", otlpLine(testSpanID, "bad"), 1, true},
+		{"not JSON", `{"resourceSpans":`, 0, true},
+		{"bad timestamp", strings.Replace(otlpLine(testSpanID), "1735689600000000000", "soon", 1), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spans, err := parseOTLPTraces([]byte(tt.data))
+			if len(spans) != tt.wantSpans {
+				t.Errorf("parsed %d spans, want %d", len(spans), tt.wantSpans)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseOTLPTraces error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("parseOTLPTraces error = %v, want ErrInvalidInput", err)
+			}
+		})
+	}
+}
+
+func TestImportSpansFromOTLPJSON(t *testing.T) {
+	var received []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/projects/{project}/spans", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("project") != "test" {
+			notFound(w)
+			return
+		}
+		var req struct {
+			Data []struct {
+				Context struct {
+					SpanID string `json:"span_id"`
+				} `json:"context"`
+			} `json:"data"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		for _, s := range req.Data {
+			received = append(received, s.Context.SpanID)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		writeJSON(t, w, map[string]any{"total_received": len(req.Data), "total_queued": len(req.Data)})
+	})
+	c := newTestClient(t, mux)
+
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	lines := []string{otlpLine(testSpanID), "", "not json", otlpLine("aaa19b7ec3c1b174", "bad")}
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := ImportSpansFromOTLPJSON(t.Context(), c, "test", path)
+	if n != 2 {
+		t.Errorf("imported %d spans, want 2", n)
+	}
+	want := []string{testSpanID, "aaa19b7ec3c1b174"}
+	if !reflect.DeepEqual(received, want) {
+		t.Errorf("received spans %v, want %v", received, want)
+	}
+	var batchErr *BatchImportError
+	if !errors.As(err, &batchErr) || len(batchErr.Errors) != 2 || batchErr.Errors[0].Row != 3 || batchErr.Errors[1].Row != 4 {
+		t.Errorf("ImportSpansFromOTLPJSON error = %v, want rows 3 and 4", err)
+	}
+
+	if _, err := ImportSpansFromOTLPJSON(t.Context(), c, "missing", path); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("ImportSpansFromOTLPJSON(missing) = %v, want %v", err, ErrProjectNotFound)
+	}
+}
@@ -0,0 +1,90 @@
+package phoenix
+
+import (
+	"context"
+	"time"
+)
+
+// TimeRange is a range of time from Start, inclusive, to End, exclusive. A
+// zero Start or End leaves that side of the range open.
+type TimeRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// AttributeFilter is a predicate over span attributes; see SpanFilter.
+type AttributeFilter = SpanFilter
+
+// SpanQuery selects spans for QuerySpans. Nil and zero fields do not
+// restrict the query.
+type SpanQuery struct {
+	// TimeRange only selects spans that started within the range.
+	TimeRange *TimeRange
+
+	// SpanKind only selects spans of the given OpenInference kind, such
+	// as "LLM".
+	SpanKind *string
+
+	// MinDuration and MaxDuration only select spans whose duration is
+	// within the given bounds, inclusive.
+	MinDuration *time.Duration
+	MaxDuration *time.Duration
+
+	// AttributeFilters only selects spans matching every filter.
+	AttributeFilters []AttributeFilter
+
+	// Cursor is the pagination cursor returned by a previous query.
+	Cursor string
+
+	// Limit is the maximum number of spans fetched per page. Defaults to 100.
+	Limit int
+}
+
+// QuerySpans returns a page of the spans of a project that match query,
+// and the cursor of the next page, or an empty string on the last page.
+//
+// Only the time range is applied by Phoenix; the other conditions are
+// evaluated client-side after the page is fetched, so, as with SpanFilter,
+// a page may contain fewer spans than Limit, or none, while a next cursor
+// is still returned.
+func (c *Client) QuerySpans(ctx context.Context, projectIdentifier string, query SpanQuery) ([]*Span, string, error) {
+	opts := []SpanOption{WithSpanCursor(query.Cursor)}
+	if query.Limit > 0 {
+		opts = append(opts, WithSpanLimit(query.Limit))
+	}
+	if query.TimeRange != nil {
+		opts = append(opts, WithSpanTimeRange(query.TimeRange.Start, query.TimeRange.End))
+	}
+	for _, f := range query.AttributeFilters {
+		opts = append(opts, WithSpanFilter(f))
+	}
+
+	spans, next, err := c.GetSpans(ctx, projectIdentifier, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+
+	matches := spans[:0]
+	for _, s := range spans {
+		if query.matches(s) {
+			matches = append(matches, s)
+		}
+	}
+	return matches, next, nil
+}
+
+// matches reports whether s satisfies the conditions of the query that
+// Phoenix does not apply.
+func (q *SpanQuery) matches(s *Span) bool {
+	if q.SpanKind != nil && s.SpanKind != *q.SpanKind {
+		return false
+	}
+	d := s.EndTime.Sub(s.StartTime)
+	if q.MinDuration != nil && d < *q.MinDuration {
+		return false
+	}
+	if q.MaxDuration != nil && d > *q.MaxDuration {
+		return false
+	}
+	return true
+}
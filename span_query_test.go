@@ -0,0 +1,81 @@
+package phoenix
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestQuerySpans(t *testing.T) {
+	span := func(id, kind, end, model string) map[string]any {
+		s := spanJSON("t1", id, map[string]any{"llm.model_name": model})
+		s["span_kind"] = kind
+		s["end_time"] = end
+		return s
+	}
+	var query url.Values
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/projects/{project}/spans", func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		// All spans start at 2025-01-01T00:00:00Z.
+		writeJSON(t, w, pageJSON([]map[string]any{
+			span("match", "LLM", "2025-01-01T00:00:02Z", "gpt-4o"),
+			span("chain", "CHAIN", "2025-01-01T00:00:02Z", "gpt-4o"),
+			span("fast", "LLM", "2025-01-01T00:00:00.5Z", "gpt-4o"),
+			span("slow", "LLM", "2025-01-01T00:00:10Z", "gpt-4o"),
+			span("bounds", "LLM", "2025-01-01T00:00:05Z", "gpt-4o"),
+			span("other model", "LLM", "2025-01-01T00:00:02Z", "claude"),
+		}, "c2"))
+	})
+	c := newTestClient(t, mux)
+
+	kind := "LLM"
+	minDuration, maxDuration := time.Second, 5*time.Second
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	spans, next, err := c.QuerySpans(t.Context(), "test", SpanQuery{
+		TimeRange:        &TimeRange{Start: start, End: start.Add(time.Hour)},
+		SpanKind:         &kind,
+		MinDuration:      &minDuration,
+		MaxDuration:      &maxDuration,
+		AttributeFilters: []AttributeFilter{AttributeEquals("llm.model_name", "gpt-4o")},
+		Cursor:           "c1",
+		Limit:            50,
+	})
+	if err != nil {
+		t.Fatalf("QuerySpans: %v", err)
+	}
+	if len(spans) != 2 || spans[0].SpanID != "match" || spans[1].SpanID != "bounds" {
+		var ids []string
+		for _, s := range spans {
+			ids = append(ids, s.SpanID)
+		}
+		t.Errorf("QuerySpans = %v, want [match bounds]", ids)
+	}
+	if next != "c2" {
+		t.Errorf("next cursor = %q, want c2", next)
+	}
+
+	// The time range and pagination are sent to Phoenix.
+	if query.Get("cursor") != "c1" || query.Get("limit") != "50" {
+		t.Errorf("pagination params = %v", query)
+	}
+	if got, err := time.Parse(time.RFC3339, query.Get("start_time")); err != nil || !got.Equal(start) {
+		t.Errorf("start_time = %q, want %v", query.Get("start_time"), start)
+	}
+	if got, err := time.Parse(time.RFC3339, query.Get("end_time")); err != nil || !got.Equal(start.Add(time.Hour)) {
+		t.Errorf("end_time = %q, want %v", query.Get("end_time"), start.Add(time.Hour))
+	}
+
+	// An empty query returns the whole page.
+	spans, _, err = c.QuerySpans(t.Context(), "test", SpanQuery{})
+	if err != nil {
+		t.Fatalf("QuerySpans: %v", err)
+	}
+	if len(spans) != 6 {
+		t.Errorf("QuerySpans with an empty query returned %d spans, want 6", len(spans))
+	}
+	if query.Has("start_time") || query.Has("end_time") || query.Has("cursor") {
+		t.Errorf("empty query sent params %v", query)
+	}
+}
@@ -0,0 +1,173 @@
+package phoenix
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultSpanStatusCacheTTL is how long GetSpanStatusCounts caches counts
+// by default.
+const DefaultSpanStatusCacheTTL = time.Minute
+
+// spanStatusPageSize is the number of spans fetched per page when counting.
+const spanStatusPageSize = 1000
+
+// spanStatusCacheSize is the maximum number of results GetSpanStatusCounts
+// caches. Once full, the oldest result is evicted.
+const spanStatusCacheSize = 64
+
+// SpanStatusCounts is the number of spans with each status code.
+type SpanStatusCounts struct {
+	OK    int64
+	Error int64
+	Unset int64
+}
+
+// Total returns the number of spans counted.
+func (c *SpanStatusCounts) Total() int64 {
+	return c.OK + c.Error + c.Unset
+}
+
+// SpanStatusOption is a functional option for GetSpanStatusCounts.
+type SpanStatusOption func(*spanStatusOptions)
+
+type spanStatusOptions struct {
+	start, end time.Time
+	cacheTTL   time.Duration
+}
+
+// WithStatusTimeRange only counts spans that started at or after start and
+// before end. A zero start or end leaves that side of the range open.
+func WithStatusTimeRange(start, end time.Time) SpanStatusOption {
+	return func(o *spanStatusOptions) {
+		o.start = start
+		o.end = end
+	}
+}
+
+// WithStatusCacheTTL sets how old cached counts may be before they are
+// computed again. Zero or less disables the cache for the call: cached
+// counts are not used and the result is not cached. Defaults to
+// DefaultSpanStatusCacheTTL.
+func WithStatusCacheTTL(d time.Duration) SpanStatusOption {
+	return func(o *spanStatusOptions) {
+		o.cacheTTL = d
+	}
+}
+
+// spanStatusCacheKey identifies the counts cached by GetSpanStatusCounts.
+type spanStatusCacheKey struct {
+	project    string
+	start, end time.Time
+}
+
+// spanStatusCacheEntry is a result cached by GetSpanStatusCounts.
+type spanStatusCacheEntry struct {
+	counts    SpanStatusCounts
+	fetchedAt time.Time
+}
+
+// spanStatusCache holds the results cached by GetSpanStatusCounts, at most
+// spanStatusCacheSize of them. Ranges relative to the current time, such as
+// the last hour, differ on every call, so the cache must be bounded.
+type spanStatusCache struct {
+	mu      sync.Mutex
+	entries map[spanStatusCacheKey]spanStatusCacheEntry
+}
+
+// load returns the counts cached for key if they are younger than ttl.
+func (sc *spanStatusCache) load(key spanStatusCacheKey, ttl time.Duration) (SpanStatusCounts, bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	entry, ok := sc.entries[key]
+	if !ok || time.Since(entry.fetchedAt) >= ttl {
+		return SpanStatusCounts{}, false
+	}
+	return entry.counts, true
+}
+
+// store caches counts for key, first dropping results older than ttl and,
+// if the cache is still full, the oldest result.
+func (sc *spanStatusCache) store(key spanStatusCacheKey, counts SpanStatusCounts, ttl time.Duration) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.entries == nil {
+		sc.entries = make(map[spanStatusCacheKey]spanStatusCacheEntry)
+	}
+
+	now := time.Now()
+	for k, entry := range sc.entries {
+		if now.Sub(entry.fetchedAt) >= ttl {
+			delete(sc.entries, k)
+		}
+	}
+	if _, ok := sc.entries[key]; !ok && len(sc.entries) >= spanStatusCacheSize {
+		var oldest spanStatusCacheKey
+		var oldestAt time.Time
+		for k, entry := range sc.entries {
+			if oldestAt.IsZero() || entry.fetchedAt.Before(oldestAt) {
+				oldest, oldestAt = k, entry.fetchedAt
+			}
+		}
+		delete(sc.entries, oldest)
+	}
+	sc.entries[key] = spanStatusCacheEntry{counts: counts, fetchedAt: now}
+}
+
+// GetSpanStatusCounts returns the number of spans in a project with each
+// status code.
+//
+// Phoenix has no aggregation endpoint for span statuses, so this pages
+// through every span in the time range and counts them on the client, which
+// can be slow for large projects. Results are cached per project and time
+// range; see WithStatusCacheTTL. Spans with an unrecognized status code are
+// counted as Unset.
+func (c *Client) GetSpanStatusCounts(ctx context.Context, projectIdentifier string, opts ...SpanStatusOption) (*SpanStatusCounts, error) {
+	if projectIdentifier == "" {
+		return nil, fmt.Errorf("%w: project identifier is required", ErrInvalidInput)
+	}
+	options := &spanStatusOptions{cacheTTL: DefaultSpanStatusCacheTTL}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	key := spanStatusCacheKey{project: projectIdentifier, start: options.start, end: options.end}
+	if options.cacheTTL > 0 {
+		if counts, ok := c.spanStatusCounts.load(key, options.cacheTTL); ok {
+			return &counts, nil
+		}
+	}
+
+	counts := SpanStatusCounts{}
+	cursor := ""
+	for {
+		spans, next, err := c.GetSpans(ctx, projectIdentifier,
+			WithSpanCursor(cursor),
+			WithSpanLimit(spanStatusPageSize),
+			WithSpanTimeRange(options.start, options.end))
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range spans {
+			switch s.StatusCode {
+			case SpanStatusOK:
+				counts.OK++
+			case SpanStatusError:
+				counts.Error++
+			default:
+				counts.Unset++
+			}
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	if options.cacheTTL > 0 {
+		c.spanStatusCounts.store(key, counts, options.cacheTTL)
+	}
+	return &counts, nil
+}
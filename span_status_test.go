@@ -0,0 +1,74 @@
+package phoenix
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetSpanStatusCounts(t *testing.T) {
+	requests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/projects/test/spans", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		ok, failed, unset := spanJSON("t1", "s1", nil), spanJSON("t1", "s2", nil), spanJSON("t1", "s3", nil)
+		failed["status_code"] = "ERROR"
+		unset["status_code"] = "UNSET"
+		writeJSON(t, w, pageJSON([]map[string]any{ok, failed, unset, spanJSON("t2", "s4", nil)}, ""))
+	})
+	c := newTestClient(t, mux)
+
+	counts, err := c.GetSpanStatusCounts(t.Context(), "test")
+	if err != nil {
+		t.Fatalf("GetSpanStatusCounts: %v", err)
+	}
+	if want := (SpanStatusCounts{OK: 2, Error: 1, Unset: 1}); *counts != want {
+		t.Errorf("counts = %+v, want %+v", *counts, want)
+	}
+	if counts.Total() != 4 {
+		t.Errorf("Total() = %d, want 4", counts.Total())
+	}
+
+	if _, err := c.GetSpanStatusCounts(t.Context(), "test"); err != nil {
+		t.Fatalf("GetSpanStatusCounts: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("made %d requests for a cached range, want 1", requests)
+	}
+
+	c.spanStatusCounts = spanStatusCache{}
+	requests = 0
+	for range 2 {
+		if _, err := c.GetSpanStatusCounts(t.Context(), "test", WithStatusCacheTTL(0)); err != nil {
+			t.Fatalf("GetSpanStatusCounts: %v", err)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("made %d requests with caching disabled, want 2", requests)
+	}
+	if n := len(c.spanStatusCounts.entries); n != 0 {
+		t.Errorf("cached %d results with caching disabled, want 0", n)
+	}
+}
+
+func TestSpanStatusCacheBounded(t *testing.T) {
+	var sc spanStatusCache
+	start := time.Now()
+	for i := range spanStatusCacheSize * 2 {
+		key := spanStatusCacheKey{project: "p", start: start.Add(time.Duration(i) * time.Second)}
+		sc.store(key, SpanStatusCounts{OK: int64(i)}, time.Hour)
+	}
+	if n := len(sc.entries); n != spanStatusCacheSize {
+		t.Errorf("cache holds %d results, want %d", n, spanStatusCacheSize)
+	}
+	last := spanStatusCacheKey{project: "p", start: start.Add(time.Duration(spanStatusCacheSize*2-1) * time.Second)}
+	if counts, ok := sc.load(last, time.Hour); !ok || counts.OK != spanStatusCacheSize*2-1 {
+		t.Errorf("latest result = %+v, %v, want it cached", counts, ok)
+	}
+
+	// Expired results are dropped on the next store.
+	sc.store(spanStatusCacheKey{project: "q"}, SpanStatusCounts{}, time.Nanosecond)
+	if n := len(sc.entries); n != 1 {
+		t.Errorf("cache holds %d results after expiry, want 1", n)
+	}
+}
@@ -0,0 +1,155 @@
+package phoenix
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestGetSpan(t *testing.T) {
+	pages := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/projects/{project}/spans", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("project") != "test" {
+			notFound(w)
+			return
+		}
+		pages++
+		if r.URL.Query().Get("cursor") == "" {
+			writeJSON(t, w, pageJSON([]map[string]any{spanJSON("t1", "s1", nil)}, "page2"))
+			return
+		}
+		writeJSON(t, w, pageJSON([]map[string]any{spanJSON("t1", "s2", map[string]any{"llm.model_name": "gpt-4o"})}, ""))
+	})
+
+	c := newTestClient(t, mux)
+	span, err := c.GetSpan(t.Context(), "s2")
+	if err != nil {
+		t.Fatalf("GetSpan: %v", err)
+	}
+	if span.SpanID != "s2" || span.TraceID != "t1" || span.Attributes["llm.model_name"] != "gpt-4o" {
+		t.Errorf("GetSpan = %+v", span)
+	}
+	if pages != 2 {
+		t.Errorf("fetched %d pages, want 2", pages)
+	}
+
+	attrs, err := c.GetSpanAttributes(t.Context(), "s2")
+	if err != nil {
+		t.Fatalf("GetSpanAttributes: %v", err)
+	}
+	if attrs["llm.model_name"] != "gpt-4o" {
+		t.Errorf("GetSpanAttributes = %v", attrs)
+	}
+
+	tests := []struct {
+		name    string
+		client  *Client
+		spanID  string
+		wantErr error
+	}{
+		{"missing span", c, "s3", ErrSpanNotFound},
+		{"missing project", newTestClient(t, mux, WithProjectName("other")), "s1", ErrProjectNotFound},
+		{"empty ID", c, "", ErrInvalidInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.client.GetSpan(t.Context(), tt.spanID); !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetSpan = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSpanAttributeAccessors(t *testing.T) {
+	flat := &Span{Attributes: map[string]any{
+		"session.id":                "sess1",
+		"user.id":                   "user1",
+		"tool.name":                 "search",
+		"metadata":                  `{"team":"search"}`,
+		"llm.invocation_parameters": `{"temperature":0.5}`,
+	}}
+	nested := &Span{Attributes: map[string]any{
+		"session":  map[string]any{"id": "sess1"},
+		"user":     map[string]any{"id": "user1"},
+		"tool":     map[string]any{"name": "search"},
+		"metadata": map[string]any{"team": "search"},
+		"llm":      map[string]any{"invocation_parameters": map[string]any{"temperature": 0.5}},
+	}}
+	for name, span := range map[string]*Span{"flat": flat, "nested": nested} {
+		t.Run(name, func(t *testing.T) {
+			if got := span.SessionID(); got != "sess1" {
+				t.Errorf("SessionID = %q", got)
+			}
+			if got := span.UserID(); got != "user1" {
+				t.Errorf("UserID = %q", got)
+			}
+			if got := span.ToolName(); got != "search" {
+				t.Errorf("ToolName = %q", got)
+			}
+			if got := span.Metadata(); got["team"] != "search" {
+				t.Errorf("Metadata = %v", got)
+			}
+			if got := span.InvocationParameters(); got["temperature"] != 0.5 {
+				t.Errorf("InvocationParameters = %v", got)
+			}
+		})
+	}
+
+	empty := &Span{Attributes: map[string]any{"metadata": "not json", "llm.invocation_parameters": 3}}
+	if got := empty.SessionID(); got != "" {
+		t.Errorf("SessionID = %q, want empty", got)
+	}
+	if got := empty.Metadata(); got != nil {
+		t.Errorf("Metadata = %v, want nil for a non-JSON string", got)
+	}
+	if got := empty.InvocationParameters(); got != nil {
+		t.Errorf("InvocationParameters = %v, want nil for a non-object", got)
+	}
+}
+
+func TestGetSpanEvents(t *testing.T) {
+	span := spanJSON("t1", "s1", nil)
+	span["events"] = []any{
+		map[string]any{"name": "feedback", "timestamp": "2025-01-01T00:00:00.5Z", "attributes": map[string]any{"score": 0.8, "feedback.name": "helpful"}},
+		map[string]any{"name": "step", "timestamp": "2025-01-01T00:00:00.6Z"},
+		map[string]any{"name": "feedback", "timestamp": "2025-01-01T00:00:00.7Z", "attributes": map[string]any{"score": 0.1}},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/projects/{project}/spans", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, pageJSON([]map[string]any{span, spanJSON("t1", "s2", nil)}, ""))
+	})
+	c := newTestClient(t, mux)
+
+	got, err := c.GetSpan(t.Context(), "s1")
+	if err != nil {
+		t.Fatalf("GetSpan: %v", err)
+	}
+	if len(got.Events) != 3 {
+		t.Fatalf("Events = %+v, want 3 events", got.Events)
+	}
+	first := got.Events[0]
+	if first.Name != "feedback" || first.Timestamp.UnixMilli() != 1735689600500 ||
+		first.Attributes["score"] != 0.8 || first.Attributes["feedback.name"] != "helpful" {
+		t.Errorf("Events[0] = %+v", first)
+	}
+	if step := got.Events[1]; step.Name != "step" || step.Attributes != nil {
+		t.Errorf("Events[1] = %+v, want step without attributes", step)
+	}
+
+	feedback := got.EventsByName("feedback")
+	if len(feedback) != 2 || feedback[1].Attributes["score"] != 0.1 {
+		t.Errorf("EventsByName(feedback) = %+v, want both feedback events in order", feedback)
+	}
+	if events := got.EventsByName("missing"); events != nil {
+		t.Errorf("EventsByName(missing) = %+v, want nil", events)
+	}
+
+	empty, err := c.GetSpan(t.Context(), "s2")
+	if err != nil {
+		t.Fatalf("GetSpan: %v", err)
+	}
+	if empty.Events != nil {
+		t.Errorf("Events = %+v for a span without events, want nil", empty.Events)
+	}
+}
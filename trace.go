@@ -0,0 +1,151 @@
+package phoenix
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/agentplexus/go-phoenix/internal/api"
+)
+
+// DeleteOption is a functional option for bulk delete operations.
+type DeleteOption func(*deleteOptions)
+
+type deleteOptions struct {
+	batchSize   int
+	concurrency int
+	dryRun      bool
+}
+
+func defaultDeleteOptions() *deleteOptions {
+	return &deleteOptions{
+		batchSize:   1000,
+		concurrency: 4,
+	}
+}
+
+// WithDeleteBatchSize sets the page size used when listing items to delete.
+func WithDeleteBatchSize(n int) DeleteOption {
+	return func(o *deleteOptions) {
+		o.batchSize = n
+	}
+}
+
+// WithDeleteConcurrency sets the number of deletes issued in parallel.
+func WithDeleteConcurrency(n int) DeleteOption {
+	return func(o *deleteOptions) {
+		o.concurrency = n
+	}
+}
+
+// WithDeleteDryRun reports how many items would be deleted without deleting them.
+func WithDeleteDryRun(dryRun bool) DeleteOption {
+	return func(o *deleteOptions) {
+		o.dryRun = dryRun
+	}
+}
+
+// DeleteAllTracesInProject deletes every trace in a project and returns the
+// number of traces deleted.
+//
+// All trace IDs are collected before any deletes are issued so that deleting
+// does not disturb pagination. On error, the returned count reflects the
+// traces deleted before the failure.
+func (c *Client) DeleteAllTracesInProject(ctx context.Context, projectIdentifier string, opts ...DeleteOption) (int, error) {
+	options := defaultDeleteOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+	if options.concurrency < 1 {
+		options.concurrency = 1
+	}
+
+	traceIDs, err := c.listTraceIDs(ctx, projectIdentifier, options.batchSize)
+	if err != nil {
+		return 0, err
+	}
+	if options.dryRun {
+		return len(traceIDs), nil
+	}
+
+	var (
+		deleted  atomic.Int64
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sem := make(chan struct{}, options.concurrency)
+	for _, traceID := range traceIDs {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := c.DeleteTrace(ctx, id); err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			deleted.Add(1)
+		}(traceID)
+	}
+	wg.Wait()
+
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	return int(deleted.Load()), firstErr
+}
+
+// listTraceIDs pages through a project's spans and returns the unique trace IDs.
+func (c *Client) listTraceIDs(ctx context.Context, projectIdentifier string, pageSize int) ([]string, error) {
+	ctx = withRequestIDRecorder(ctx)
+	params := api.GetSpansParams{
+		ProjectIdentifier: projectIdentifier,
+	}
+	if pageSize > 0 {
+		params.Limit.SetTo(pageSize)
+	}
+
+	seen := make(map[string]struct{})
+	var traceIDs []string
+	for {
+		res, err := c.apiClient.GetSpans(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, ok := res.(*api.SpansResponseBody)
+		if !ok {
+			if _, notFound := res.(*api.GetSpansNotFound); notFound {
+				return nil, ErrProjectNotFound
+			}
+			return nil, c.unexpectedResponseError(ctx)
+		}
+
+		for i := range resp.Data {
+			id := resp.Data[i].Context.TraceID
+			if _, dup := seen[id]; dup {
+				continue
+			}
+			seen[id] = struct{}{}
+			traceIDs = append(traceIDs, id)
+		}
+
+		if resp.NextCursor.Null || resp.NextCursor.Value == "" {
+			return traceIDs, nil
+		}
+		params.Cursor.SetTo(resp.NextCursor.Value)
+	}
+}
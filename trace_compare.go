@@ -0,0 +1,256 @@
+package phoenix
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	phoenixotel "github.com/agentplexus/go-phoenix/otel"
+)
+
+// TraceComparison summarizes how span set B differs from span set A, e.g.
+// the spans produced by two prompt variants in an A/B test. Deltas are B − A.
+type TraceComparison struct {
+	CountA int
+	CountB int
+
+	MeanDurationMsA     float64
+	MeanDurationMsB     float64
+	MeanDurationDeltaMs float64
+
+	// TokenUsageDelta is the difference in total token counts.
+	TokenUsageDelta TokenUsage
+
+	// AnnotationDeltas maps annotation names present in both sets to the
+	// difference in their mean scores. Annotations must be loaded with
+	// Client.AttachSpanAnnotations before comparing.
+	AnnotationDeltas map[string]float64
+
+	// AttributeDiffs maps the names of spans present in both sets to how the
+	// attributes of the first span with that name in B differ from those of
+	// the first span with that name in A. Names whose attributes are the same
+	// are omitted.
+	AttributeDiffs map[string]*phoenixotel.AttributeDiff
+
+	// WilcoxonPValue is the two-sided p-value of a Wilcoxon rank-sum
+	// (Mann-Whitney U) test on span durations, using the normal
+	// approximation with tie correction. It is 1 when either set is empty.
+	WilcoxonPValue float64
+}
+
+// CompareTraces compares two sets of spans.
+func CompareTraces(a, b []*Span) *TraceComparison {
+	durA, durB := spanDurationsMs(a), spanDurationsMs(b)
+	usageA, usageB := sumTokenUsage(a), sumTokenUsage(b)
+
+	c := &TraceComparison{
+		CountA:          len(a),
+		CountB:          len(b),
+		MeanDurationMsA: mean(durA),
+		MeanDurationMsB: mean(durB),
+		TokenUsageDelta: TokenUsage{
+			PromptTokens:     usageB.PromptTokens - usageA.PromptTokens,
+			CompletionTokens: usageB.CompletionTokens - usageA.CompletionTokens,
+			TotalTokens:      usageB.TotalTokens - usageA.TotalTokens,
+		},
+		AnnotationDeltas: make(map[string]float64),
+		AttributeDiffs:   make(map[string]*phoenixotel.AttributeDiff),
+		WilcoxonPValue:   rankSumPValue(durA, durB),
+	}
+	c.MeanDurationDeltaMs = c.MeanDurationMsB - c.MeanDurationMsA
+
+	scoresA, scoresB := meanAnnotationScores(a), meanAnnotationScores(b)
+	for name, scoreA := range scoresA {
+		if scoreB, ok := scoresB[name]; ok {
+			c.AnnotationDeltas[name] = scoreB - scoreA
+		}
+	}
+
+	spansA, spansB := firstSpansByName(a), firstSpansByName(b)
+	for name, spanA := range spansA {
+		spanB, ok := spansB[name]
+		if !ok {
+			continue
+		}
+		diff := phoenixotel.DiffSpanAttributes(phoenixotel.MarshalAttributes("", spanA.Attributes), phoenixotel.MarshalAttributes("", spanB.Attributes))
+		if !diff.Empty() {
+			c.AttributeDiffs[name] = diff
+		}
+	}
+
+	return c
+}
+
+// FormatComparisonReport renders a comparison as a plain-text report.
+func FormatComparisonReport(c *TraceComparison) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Spans:        A=%d  B=%d\n", c.CountA, c.CountB)
+	fmt.Fprintf(&sb, "Mean latency: A=%.1fms  B=%.1fms  delta=%+.1fms (p=%.4f)\n",
+		c.MeanDurationMsA, c.MeanDurationMsB, c.MeanDurationDeltaMs, c.WilcoxonPValue)
+	fmt.Fprintf(&sb, "Tokens delta: prompt=%+d  completion=%+d  total=%+d\n",
+		c.TokenUsageDelta.PromptTokens, c.TokenUsageDelta.CompletionTokens, c.TokenUsageDelta.TotalTokens)
+
+	if len(c.AnnotationDeltas) > 0 {
+		names := make([]string, 0, len(c.AnnotationDeltas))
+		for name := range c.AnnotationDeltas {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		sb.WriteString("Annotation score deltas:\n")
+		for _, name := range names {
+			fmt.Fprintf(&sb, "  %s: %+.3f\n", name, c.AnnotationDeltas[name])
+		}
+	}
+
+	if len(c.AttributeDiffs) > 0 {
+		names := make([]string, 0, len(c.AttributeDiffs))
+		for name := range c.AttributeDiffs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		sb.WriteString("Attribute changes:\n")
+		for _, name := range names {
+			fmt.Fprintf(&sb, "  %s:\n", name)
+			for _, line := range strings.SplitAfter(c.AttributeDiffs[name].Text(), "\n") {
+				if line != "" {
+					sb.WriteString("    " + line)
+				}
+			}
+		}
+	}
+	return sb.String()
+}
+
+func spanDurationsMs(spans []*Span) []float64 {
+	durations := make([]float64, 0, len(spans))
+	for _, s := range spans {
+		durations = append(durations, float64(s.EndTime.Sub(s.StartTime).Microseconds())/1000)
+	}
+	return durations
+}
+
+// firstSpansByName maps span names to the first span with that name.
+func firstSpansByName(spans []*Span) map[string]*Span {
+	byName := make(map[string]*Span, len(spans))
+	for _, s := range spans {
+		if _, ok := byName[s.Name]; !ok {
+			byName[s.Name] = s
+		}
+	}
+	return byName
+}
+
+func sumTokenUsage(spans []*Span) TokenUsage {
+	var total TokenUsage
+	for _, s := range spans {
+		usage := s.TokenUsage()
+		total.PromptTokens += usage.PromptTokens
+		total.CompletionTokens += usage.CompletionTokens
+		total.TotalTokens += usage.TotalTokens
+	}
+	return total
+}
+
+// attributeInt returns a numeric attribute as an int, or 0 if it is absent
+// or not a number.
+func attributeInt(attrs map[string]any, key string) int {
+	v, ok := lookupAttribute(attrs, key)
+	if !ok {
+		return 0
+	}
+	switch n := v.(type) {
+	case float64:
+		return int(n)
+	case int:
+		return n
+	case int64:
+		return int(n)
+	default:
+		return 0
+	}
+}
+
+func meanAnnotationScores(spans []*Span) map[string]float64 {
+	sums := make(map[string]float64)
+	counts := make(map[string]int)
+	for _, s := range spans {
+		for _, a := range s.Annotations {
+			sums[a.Name] += a.Score
+			counts[a.Name]++
+		}
+	}
+	for name, sum := range sums {
+		sums[name] = sum / float64(counts[name])
+	}
+	return sums
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// rankSumPValue returns the two-sided p-value of the Wilcoxon rank-sum test
+// for samples a and b, using the normal approximation with tie correction.
+func rankSumPValue(a, b []float64) float64 {
+	n1, n2 := len(a), len(b)
+	if n1 == 0 || n2 == 0 {
+		return 1
+	}
+
+	type sample struct {
+		value float64
+		fromA bool
+	}
+	samples := make([]sample, 0, n1+n2)
+	for _, v := range a {
+		samples = append(samples, sample{v, true})
+	}
+	for _, v := range b {
+		samples = append(samples, sample{v, false})
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i].value < samples[j].value })
+
+	// Assign average ranks to ties and accumulate the tie correction term.
+	var rankSumA, tieTerm float64
+	for i := 0; i < len(samples); {
+		j := i
+		for j < len(samples) && samples[j].value == samples[i].value {
+			j++
+		}
+		rank := float64(i+j+1) / 2 // mean of 1-based ranks i+1..j
+		for k := i; k < j; k++ {
+			if samples[k].fromA {
+				rankSumA += rank
+			}
+		}
+		t := float64(j - i)
+		tieTerm += t*t*t - t
+		i = j
+	}
+
+	fn1, fn2 := float64(n1), float64(n2)
+	n := fn1 + fn2
+	u := rankSumA - fn1*(fn1+1)/2
+	meanU := fn1 * fn2 / 2
+	variance := fn1 * fn2 / 12 * ((n + 1) - tieTerm/(n*(n-1)))
+	if variance <= 0 {
+		return 1
+	}
+
+	// Continuity correction toward the mean.
+	z := (math.Abs(u-meanU) - 0.5) / math.Sqrt(variance)
+	if z < 0 {
+		z = 0
+	}
+	return math.Erfc(z / math.Sqrt2)
+}
@@ -0,0 +1,140 @@
+package phoenix
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRankSumPValue(t *testing.T) {
+	// Expected values match R's wilcox.test(a, b, exact = FALSE, correct = TRUE).
+	tests := []struct {
+		name string
+		a, b []float64
+		want float64
+	}{
+		{"separated", []float64{1, 2, 3, 4, 5}, []float64{6, 7, 8, 9, 10}, 0.012186},
+		{"separated reversed", []float64{6, 7, 8, 9, 10}, []float64{1, 2, 3, 4, 5}, 0.012186},
+		{"ties", []float64{1, 2, 2, 3, 4}, []float64{2, 3, 5, 5, 6}, 0.110492},
+		{"identical samples", []float64{1, 2, 3}, []float64{1, 2, 3}, 1},
+		{"all tied", []float64{4, 4}, []float64{4, 4, 4}, 1},
+		{"empty sample", nil, []float64{1, 2}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rankSumPValue(tt.a, tt.b); math.Abs(got-tt.want) > 1e-6 {
+				t.Errorf("rankSumPValue = %.6f, want %.6f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareTracesAttributeDiffs(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	span := func(name string, attrs map[string]any) *Span {
+		return &Span{Name: name, StartTime: start, EndTime: start.Add(time.Second), Attributes: attrs}
+	}
+	a := []*Span{
+		span("llm", map[string]any{
+			"llm":      map[string]any{"model_name": "gpt-4o", "token_count": map[string]any{"total": 10.0}},
+			"metadata": map[string]any{"variant": "a"},
+			"tags":     []any{"x", "y"},
+		}),
+		span("tool", map[string]any{"tool.name": "search"}),
+		span("only-a", map[string]any{"k": "v"}),
+	}
+	b := []*Span{
+		span("llm", map[string]any{
+			"llm.model_name":  "gpt-4o-mini",
+			"llm.token_count": map[string]any{"total": 10.0},
+			"tags":            []any{"x", "y"},
+			"retries":         1.0,
+		}),
+		span("tool", map[string]any{"tool": map[string]any{"name": "search"}}),
+		// Later spans with the same name are not compared.
+		span("tool", map[string]any{"tool.name": "fetch"}),
+		span("only-b", map[string]any{"k": "v"}),
+	}
+
+	c := CompareTraces(a, b)
+	if len(c.AttributeDiffs) != 1 {
+		t.Fatalf("AttributeDiffs = %v, want only llm", c.AttributeDiffs)
+	}
+	diff := c.AttributeDiffs["llm"]
+	if diff == nil {
+		t.Fatal("no attribute diff for llm")
+	}
+	if change, ok := diff.Changed["llm.model_name"]; !ok || change.From.AsString() != "gpt-4o" || change.To.AsString() != "gpt-4o-mini" {
+		t.Errorf("Changed = %v, want llm.model_name gpt-4o -> gpt-4o-mini", diff.Changed)
+	}
+	if _, ok := diff.Removed["metadata.variant"]; !ok || len(diff.Removed) != 1 {
+		t.Errorf("Removed = %v, want metadata.variant", diff.Removed)
+	}
+	if v, ok := diff.Added["retries"]; !ok || v.AsFloat64() != 1 || len(diff.Added) != 1 {
+		t.Errorf("Added = %v, want retries", diff.Added)
+	}
+
+	report := FormatComparisonReport(c)
+	want := "Attribute changes:\n" +
+		"  llm:\n" +
+		"    ~ llm.model_name: gpt-4o -> gpt-4o-mini\n" +
+		"    - metadata.variant: a\n" +
+		"    + retries: 1\n"
+	if !strings.HasSuffix(report, want) {
+		t.Errorf("report = %q, want suffix %q", report, want)
+	}
+
+	if report := FormatComparisonReport(CompareTraces(a[1:2], b[1:2])); strings.Contains(report, "Attribute changes") {
+		t.Errorf("report for identical attributes = %q, want no attribute section", report)
+	}
+}
+
+func TestCompareTracesTokenAndAnnotationDeltas(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	span := func(prompt, completion int, annotations ...*Annotation) *Span {
+		return &Span{
+			Name:      "llm",
+			StartTime: start,
+			EndTime:   start.Add(time.Second),
+			Attributes: map[string]any{
+				"llm.token_count.prompt":     float64(prompt),
+				"llm.token_count.completion": float64(completion),
+				"llm.token_count.total":      float64(prompt + completion),
+			},
+			Annotations: annotations,
+		}
+	}
+	score := func(name string, score float64) *Annotation {
+		return &Annotation{Name: name, Score: score}
+	}
+	a := []*Span{
+		span(100, 20, score("quality", 0.5), score("only-a", 1)),
+		span(50, 10, score("quality", 0.7)),
+	}
+	b := []*Span{
+		span(80, 40, score("quality", 0.9), score("only-b", 1)),
+		// Spans without token counts or annotations add nothing.
+		{Name: "tool", StartTime: start, EndTime: start.Add(time.Second)},
+	}
+
+	c := CompareTraces(a, b)
+	want := TokenUsage{PromptTokens: -70, CompletionTokens: 10, TotalTokens: -60}
+	if c.TokenUsageDelta != want {
+		t.Errorf("TokenUsageDelta = %+v, want %+v", c.TokenUsageDelta, want)
+	}
+	// Only names annotated in both sets are compared: mean 0.9 - mean 0.6.
+	if len(c.AnnotationDeltas) != 1 || math.Abs(c.AnnotationDeltas["quality"]-0.3) > 1e-9 {
+		t.Errorf("AnnotationDeltas = %v, want quality +0.3", c.AnnotationDeltas)
+	}
+
+	report := FormatComparisonReport(c)
+	for _, line := range []string{
+		"Tokens delta: prompt=-70  completion=+10  total=-60\n",
+		"Annotation score deltas:\n  quality: +0.300\n",
+	} {
+		if !strings.Contains(report, line) {
+			t.Errorf("report = %q, want it to contain %q", report, line)
+		}
+	}
+}
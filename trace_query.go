@@ -0,0 +1,168 @@
+package phoenix
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	phoenixotel "github.com/agentplexus/go-phoenix/otel"
+)
+
+// Trace is a trace as represented by its root span.
+type Trace struct {
+	TraceID   string
+	RootSpan  *Span
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// Duration returns the duration of the trace's root span.
+func (t *Trace) Duration() time.Duration {
+	return t.EndTime.Sub(t.StartTime)
+}
+
+// TraceQueryOption is a functional option for GetProjectTraces.
+type TraceQueryOption func(*traceQueryOptions)
+
+type traceQueryOptions struct {
+	start, end  time.Time
+	tag         string
+	minDuration time.Duration
+}
+
+// WithTraceTimeRange only returns traces whose root span started at or
+// after start and before end. A zero start or end leaves that side of the
+// range open.
+func WithTraceTimeRange(start, end time.Time) TraceQueryOption {
+	return func(o *traceQueryOptions) {
+		o.start = start
+		o.end = end
+	}
+}
+
+// WithTagFilter only returns traces carrying tag, either as a tag.tags
+// attribute on the root span or as a tag added with AddTraceTag.
+func WithTagFilter(tag string) TraceQueryOption {
+	return func(o *traceQueryOptions) {
+		o.tag = tag
+	}
+}
+
+// WithMinDuration only returns traces whose root span took at least d.
+func WithMinDuration(d time.Duration) TraceQueryOption {
+	return func(o *traceQueryOptions) {
+		o.minDuration = d
+	}
+}
+
+// GetProjectTraces returns every trace in a project matching opts, most
+// recent first.
+//
+// Phoenix has no traces endpoint, so this pages through all spans of the
+// project in the time range, keeping only root spans. Every page of spans is
+// fetched and all matching traces are held in memory at once, which can be
+// slow and memory-hungry for large projects; narrow the query with
+// WithTraceTimeRange, or use GetSpans to process one page at a time.
+func (c *Client) GetProjectTraces(ctx context.Context, projectIdentifier string, opts ...TraceQueryOption) ([]*Trace, error) {
+	options := &traceQueryOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	var traces []*Trace
+	cursor := ""
+	for {
+		spans, next, err := c.GetSpans(ctx, projectIdentifier,
+			WithSpanCursor(cursor),
+			WithSpanTimeRange(options.start, options.end),
+		)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range spans {
+			if s.ParentID != "" {
+				continue
+			}
+			t := &Trace{TraceID: s.TraceID, RootSpan: s, StartTime: s.StartTime, EndTime: s.EndTime}
+			if t.Duration() < options.minDuration {
+				continue
+			}
+			traces = append(traces, t)
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	if options.tag != "" {
+		var err error
+		if traces, err = c.filterTracesByTag(ctx, projectIdentifier, traces, options.tag); err != nil {
+			return nil, err
+		}
+	}
+
+	slices.SortStableFunc(traces, func(a, b *Trace) int {
+		return b.StartTime.Compare(a.StartTime)
+	})
+	return traces, nil
+}
+
+// filterTracesByTag keeps the traces carrying tag, looking up annotation
+// tags only for traces whose root span does not have it as an attribute.
+func (c *Client) filterTracesByTag(ctx context.Context, projectIdentifier string, traces []*Trace, tag string) ([]*Trace, error) {
+	var lookup []string
+	for _, t := range traces {
+		if !spanHasTag(t.RootSpan, tag) {
+			lookup = append(lookup, t.TraceID)
+		}
+	}
+
+	tagged := make(map[string]bool)
+	for chunk := range slices.Chunk(lookup, annotationExportChunkSize) {
+		tags, err := c.traceTags(ctx, projectIdentifier, chunk)
+		if err != nil {
+			return nil, err
+		}
+		for id, t := range tags {
+			if slices.Contains(t, tag) {
+				tagged[id] = true
+			}
+		}
+	}
+
+	matches := traces[:0]
+	for _, t := range traces {
+		if tagged[t.TraceID] || spanHasTag(t.RootSpan, tag) {
+			matches = append(matches, t)
+		}
+	}
+	return matches, nil
+}
+
+// spanHasTag reports whether the span's tag.tags attribute holds tag. The
+// attribute may be a list, a JSON-encoded array or a single tag.
+func spanHasTag(s *Span, tag string) bool {
+	v, ok := lookupAttribute(s.Attributes, phoenixotel.TagsKey)
+	if !ok {
+		return false
+	}
+	switch tags := v.(type) {
+	case []any:
+		for _, t := range tags {
+			if attributeString(t) == tag {
+				return true
+			}
+		}
+		return false
+	case string:
+		var list []string
+		if json.Unmarshal([]byte(tags), &list) == nil {
+			return slices.Contains(list, tag)
+		}
+		return tags == tag
+	default:
+		return attributeString(v) == tag
+	}
+}
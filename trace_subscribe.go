@@ -0,0 +1,182 @@
+package phoenix
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// Defaults for SubscribeToTraces.
+const (
+	// DefaultEventPollInterval is how often SubscribeToTraces polls Phoenix.
+	DefaultEventPollInterval = 5 * time.Second
+
+	// traceEventWindow is how far back each poll looks for root spans.
+	// Changes to traces that started earlier are not reported.
+	traceEventWindow = 15 * time.Minute
+
+	// maxEventPollBackoff bounds the delay between polls after failures.
+	maxEventPollBackoff = time.Minute
+)
+
+// TraceEventType is the kind of change reported by a TraceEvent.
+type TraceEventType string
+
+const (
+	// TraceEventCreated reports a trace whose root span was not seen before.
+	TraceEventCreated TraceEventType = "created"
+	// TraceEventUpdated reports a trace whose root span changed its end
+	// time or status.
+	TraceEventUpdated TraceEventType = "updated"
+	// TraceEventDeleted reports a trace whose root span is no longer
+	// returned by Phoenix.
+	TraceEventDeleted TraceEventType = "deleted"
+)
+
+// TraceEvent is a change to a trace, sent by SubscribeToTraces.
+type TraceEvent struct {
+	Type         TraceEventType
+	TraceID      string
+	RootSpanName string
+}
+
+// SubscribeOption is a functional option for SubscribeToTraces.
+type SubscribeOption func(*subscribeOptions)
+
+type subscribeOptions struct {
+	types        []TraceEventType
+	pollInterval time.Duration
+}
+
+// WithEventFilter only sends events of the given types. By default, events
+// of all types are sent.
+func WithEventFilter(types ...TraceEventType) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.types = append(o.types, types...)
+	}
+}
+
+// WithEventPollInterval sets how often SubscribeToTraces polls Phoenix.
+// Values of zero or less use DefaultEventPollInterval.
+func WithEventPollInterval(d time.Duration) SubscribeOption {
+	return func(o *subscribeOptions) {
+		if d > 0 {
+			o.pollInterval = d
+		}
+	}
+}
+
+// SubscribeToTraces sends an event on the returned channel for each trace
+// created, updated or deleted in a project after the call. The channel is
+// closed when ctx is done.
+//
+// Phoenix has no event stream, so changes are detected by polling the root
+// spans of the project, as GetProjectTraces does, and comparing them with
+// the previous poll. Only traces that started within the last 15 minutes
+// are tracked. Every poll pages through all spans, not only root spans,
+// that started in that window, so on a busy project each poll can take
+// many requests; raise the interval with WithEventPollInterval if that is
+// too costly. When a poll fails, the next one is delayed with exponential
+// backoff, up to a minute, until Phoenix can be reached again. The project
+// is checked before SubscribeToTraces returns; errors doing so are returned
+// directly.
+func (c *Client) SubscribeToTraces(ctx context.Context, projectIdentifier string, opts ...SubscribeOption) (<-chan *TraceEvent, error) {
+	options := &subscribeOptions{pollInterval: DefaultEventPollInterval}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	since := time.Now()
+	if _, _, err := c.GetSpans(ctx, projectIdentifier, WithSpanLimit(1), WithSpanTimeRange(since, time.Time{})); err != nil {
+		return nil, err
+	}
+
+	events := make(chan *TraceEvent)
+	go func() {
+		defer close(events)
+
+		backoff := ExponentialBackoff(options.pollInterval, max(options.pollInterval, maxEventPollBackoff))
+		known := make(map[string]*Trace)
+		failures := 0
+		for {
+			delay := options.pollInterval
+			if err := c.pollTraceEvents(ctx, projectIdentifier, since, known, options, events); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				failures++
+				delay = backoff(failures)
+			} else {
+				failures = 0
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+		}
+	}()
+	return events, nil
+}
+
+// pollTraceEvents fetches the root spans that started since the later of
+// since and the start of the event window, sends an event for each change
+// from known, and updates known.
+func (c *Client) pollTraceEvents(ctx context.Context, projectIdentifier string, since time.Time, known map[string]*Trace, options *subscribeOptions, events chan<- *TraceEvent) error {
+	start := time.Now().Add(-traceEventWindow)
+	if start.Before(since) {
+		start = since
+	}
+	traces, err := c.GetProjectTraces(ctx, projectIdentifier, WithTraceTimeRange(start, time.Time{}))
+	if err != nil {
+		return err
+	}
+
+	send := func(typ TraceEventType, t *Trace) bool {
+		if len(options.types) > 0 && !slices.Contains(options.types, typ) {
+			return true
+		}
+		select {
+		case events <- &TraceEvent{Type: typ, TraceID: t.TraceID, RootSpanName: t.RootSpan.Name}:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	seen := make(map[string]bool, len(traces))
+	for _, t := range traces {
+		seen[t.TraceID] = true
+		prev, ok := known[t.TraceID]
+		known[t.TraceID] = t
+		switch {
+		case !ok:
+			if !send(TraceEventCreated, t) {
+				return ctx.Err()
+			}
+		case rootSpanChanged(prev.RootSpan, t.RootSpan):
+			if !send(TraceEventUpdated, t) {
+				return ctx.Err()
+			}
+		}
+	}
+	for id, t := range known {
+		if seen[id] {
+			continue
+		}
+		delete(known, id)
+		// Traces that left the window were not deleted.
+		if t.StartTime.Before(start) {
+			continue
+		}
+		if !send(TraceEventDeleted, t) {
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func rootSpanChanged(a, b *Span) bool {
+	return !a.EndTime.Equal(b.EndTime) || a.StatusCode != b.StatusCode || a.StatusMessage != b.StatusMessage
+}
@@ -0,0 +1,159 @@
+package phoenix
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// rootSpanJSON is a root span that started at start, as returned by the API.
+func rootSpanJSON(traceID string, start time.Time, status string) map[string]any {
+	span := spanJSON(traceID, "root-"+traceID, nil)
+	span["start_time"] = start.Format(time.RFC3339Nano)
+	span["end_time"] = start.Add(time.Second).Format(time.RFC3339Nano)
+	span["status_code"] = status
+	return span
+}
+
+func TestPollTraceEvents(t *testing.T) {
+	start := time.Now()
+	var spans []map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/projects/{project}/spans", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, pageJSON(spans, ""))
+	})
+	c := newTestClient(t, mux)
+
+	known := make(map[string]*Trace)
+	poll := func(t *testing.T, options *subscribeOptions) []TraceEvent {
+		t.Helper()
+		events := make(chan *TraceEvent, 10)
+		if err := c.pollTraceEvents(t.Context(), "test", start.Add(-time.Minute), known, options, events); err != nil {
+			t.Fatalf("pollTraceEvents: %v", err)
+		}
+		close(events)
+		var got []TraceEvent
+		for e := range events {
+			got = append(got, *e)
+		}
+		return got
+	}
+
+	tests := []struct {
+		name    string
+		spans   []map[string]any
+		options *subscribeOptions
+		want    []TraceEvent
+	}{
+		{
+			name:  "created",
+			spans: []map[string]any{rootSpanJSON("t1", start, "UNSET")},
+			want:  []TraceEvent{{Type: TraceEventCreated, TraceID: "t1", RootSpanName: "span-root-t1"}},
+		},
+		{
+			name:  "unchanged",
+			spans: []map[string]any{rootSpanJSON("t1", start, "UNSET")},
+		},
+		{
+			name: "updated and created",
+			spans: []map[string]any{
+				rootSpanJSON("t1", start, "OK"),
+				rootSpanJSON("t2", start.Add(time.Millisecond), "OK"),
+			},
+			want: []TraceEvent{
+				{Type: TraceEventCreated, TraceID: "t2", RootSpanName: "span-root-t2"},
+				{Type: TraceEventUpdated, TraceID: "t1", RootSpanName: "span-root-t1"},
+			},
+		},
+		{
+			name: "child spans ignored",
+			spans: func() []map[string]any {
+				child := spanJSON("t1", "child", nil)
+				child["parent_id"] = "root-t1"
+				return []map[string]any{rootSpanJSON("t1", start, "OK"), rootSpanJSON("t2", start.Add(time.Millisecond), "OK"), child}
+			}(),
+		},
+		{
+			name:  "deleted",
+			spans: []map[string]any{rootSpanJSON("t1", start, "OK")},
+			want:  []TraceEvent{{Type: TraceEventDeleted, TraceID: "t2", RootSpanName: "span-root-t2"}},
+		},
+		{
+			name:    "filtered",
+			spans:   []map[string]any{rootSpanJSON("t1", start, "ERROR"), rootSpanJSON("t3", start, "OK")},
+			options: &subscribeOptions{types: []TraceEventType{TraceEventUpdated}},
+			want:    []TraceEvent{{Type: TraceEventUpdated, TraceID: "t1", RootSpanName: "span-root-t1"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spans = tt.spans
+			options := tt.options
+			if options == nil {
+				options = &subscribeOptions{}
+			}
+			got := poll(t, options)
+			if len(got) != len(tt.want) {
+				t.Fatalf("events = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("event %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSubscribeToTracesBackoff(t *testing.T) {
+	const (
+		interval = 20 * time.Millisecond
+		failures = 3
+	)
+	var (
+		mu    sync.Mutex
+		polls []time.Time
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/projects/{project}/spans", func(w http.ResponseWriter, r *http.Request) {
+		// The project check fetches a single span.
+		if r.URL.Query().Get("limit") == "1" {
+			writeJSON(t, w, pageJSON([]map[string]any(nil), ""))
+			return
+		}
+		mu.Lock()
+		polls = append(polls, time.Now())
+		n := len(polls)
+		mu.Unlock()
+		if n <= failures {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		writeJSON(t, w, pageJSON([]map[string]any{rootSpanJSON("t1", time.Now(), "OK")}, ""))
+	})
+	c := newTestClient(t, mux)
+
+	events, err := c.SubscribeToTraces(t.Context(), "test", WithEventPollInterval(interval))
+	if err != nil {
+		t.Fatalf("SubscribeToTraces: %v", err)
+	}
+	select {
+	case e := <-events:
+		if e.Type != TraceEventCreated || e.TraceID != "t1" {
+			t.Errorf("event = %+v, want created t1", e)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event after Phoenix recovered")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	// Polls after the first failures wait 1, 2 and 4 intervals.
+	for i := 1; i <= failures; i++ {
+		want := interval << (i - 1)
+		if gap := polls[i].Sub(polls[i-1]); gap < want {
+			t.Errorf("poll %d came %v after the previous one, want at least %v", i, gap, want)
+		}
+	}
+}
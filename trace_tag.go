@@ -0,0 +1,131 @@
+package phoenix
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/agentplexus/go-phoenix/internal/api"
+)
+
+// TraceTagsAnnotationName is the trace annotation name used to store tags.
+const TraceTagsAnnotationName = "__tags__"
+
+// AddTraceTag tags a trace after the fact.
+//
+// Tags set as OTEL attributes while tracing cannot be changed through the
+// REST API, so these tags are stored as trace annotations named
+// TraceTagsAnnotationName, one per tag, with the tag as label and
+// identifier. Adding a tag that is already present has no effect.
+func (c *Client) AddTraceTag(ctx context.Context, traceID, tag string) error {
+	return c.setTraceTag(ctx, traceID, tag, true)
+}
+
+// RemoveTraceTag removes a tag added with AddTraceTag.
+//
+// Phoenix cannot delete annotations, so the tag annotation is overwritten
+// with a score of 0, which GetTraceTags treats as removed.
+func (c *Client) RemoveTraceTag(ctx context.Context, traceID, tag string) error {
+	return c.setTraceTag(ctx, traceID, tag, false)
+}
+
+// GetTraceTags returns the tags of a trace in the client's project, sorted.
+func (c *Client) GetTraceTags(ctx context.Context, traceID string) ([]string, error) {
+	tags, err := c.traceTags(ctx, c.config.ProjectName, []string{traceID})
+	if err != nil {
+		return nil, err
+	}
+	return tags[traceID], nil
+}
+
+// SearchTracesByTag returns the root spans of traces in a project that carry
+// the given tag.
+//
+// Filtering is client-side: each call fetches one page of spans with opts,
+// looks up the tags of every trace in it, and returns the matching root
+// spans along with the cursor for the next page. A page may therefore hold
+// fewer results than the requested limit, or none.
+func (c *Client) SearchTracesByTag(ctx context.Context, projectID, tag string, opts ...SpanOption) ([]*Span, string, error) {
+	spans, nextCursor, err := c.GetSpans(ctx, projectID, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var roots []*Span
+	var traceIDs []string
+	for _, s := range spans {
+		if s.ParentID == "" {
+			roots = append(roots, s)
+			traceIDs = append(traceIDs, s.TraceID)
+		}
+	}
+
+	tagsByTrace := make(map[string][]string, len(traceIDs))
+	for chunk := range slices.Chunk(traceIDs, annotationExportChunkSize) {
+		tags, err := c.traceTags(ctx, projectID, chunk)
+		if err != nil {
+			return nil, "", err
+		}
+		for id, t := range tags {
+			tagsByTrace[id] = t
+		}
+	}
+
+	matches := make([]*Span, 0, len(roots))
+	for _, s := range roots {
+		if slices.Contains(tagsByTrace[s.TraceID], tag) {
+			matches = append(matches, s)
+		}
+	}
+	return matches, nextCursor, nil
+}
+
+func (c *Client) setTraceTag(ctx context.Context, traceID, tag string, present bool) error {
+	if traceID == "" || tag == "" {
+		return fmt.Errorf("%w: trace ID and tag are required", ErrInvalidInput)
+	}
+
+	score := 0.0
+	if present {
+		score = 1.0
+	}
+	result := api.AnnotationResult{}
+	result.SetScore(api.OptNilFloat64{Value: score, Set: true})
+	result.SetLabel(api.OptNilString{Value: tag, Set: true})
+
+	data := api.TraceAnnotationData{
+		TraceID:       traceID,
+		Name:          TraceTagsAnnotationName,
+		AnnotatorKind: api.TraceAnnotationDataAnnotatorKindCODE,
+		Result:        api.OptAnnotationResult{Value: result, Set: true},
+	}
+	data.Identifier.SetTo(tag)
+
+	_, err := c.apiClient.AnnotateTraces(ctx, &api.AnnotateTracesRequestBody{
+		Data: []api.TraceAnnotationData{data},
+	}, api.AnnotateTracesParams{})
+	return err
+}
+
+// traceTags returns the active tags of each trace, sorted.
+func (c *Client) traceTags(ctx context.Context, projectIdentifier string, traceIDs []string) (map[string][]string, error) {
+	annotations, err := c.listTraceAnnotations(ctx, projectIdentifier, traceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make(map[string][]string)
+	for _, a := range annotations {
+		if a.Name != TraceTagsAnnotationName || a.Score <= 0 || a.Label == "" {
+			continue
+		}
+		if !slices.Contains(tags[a.TraceID], a.Label) {
+			tags[a.TraceID] = append(tags[a.TraceID], a.Label)
+		}
+	}
+	for _, t := range tags {
+		sort.Strings(t)
+	}
+	return tags, nil
+}
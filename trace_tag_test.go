@@ -0,0 +1,94 @@
+package phoenix
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+// tagsMux serves a root span for each of traces t0 to t5 and their tag
+// annotations in pages of 10. Traces t0 to t4 are tagged a and b; t5 has
+// twelve tags followed by gold, so gold is only seen past the first page.
+func tagsMux(t *testing.T, lists *int) *http.ServeMux {
+	var annotations []map[string]any
+	tag := func(traceID, label string) {
+		id := fmt.Sprintf("a%d", len(annotations))
+		annotations = append(annotations, traceAnnotationJSON(id, traceID, TraceTagsAnnotationName, label, 1))
+	}
+	for i := range 5 {
+		tag(fmt.Sprintf("t%d", i), "a")
+		tag(fmt.Sprintf("t%d", i), "b")
+	}
+	for i := range 12 {
+		tag("t5", fmt.Sprintf("tag%02d", i))
+	}
+	tag("t5", "gold")
+
+	mux := traceAnnotationsMux(t, annotations, 10, lists)
+	mux.HandleFunc("GET /v1/projects/{project}/spans", func(w http.ResponseWriter, r *http.Request) {
+		spans := make([]map[string]any, 0, 6)
+		for i := range 6 {
+			spans = append(spans, spanJSON(fmt.Sprintf("t%d", i), fmt.Sprintf("s%d", i), nil))
+		}
+		writeJSON(t, w, pageJSON(spans, ""))
+	})
+	return mux
+}
+
+func TestGetTraceTagsPages(t *testing.T) {
+	var lists int
+	c := newTestClient(t, tagsMux(t, &lists))
+
+	tags, err := c.GetTraceTags(t.Context(), "t5")
+	if err != nil {
+		t.Fatalf("GetTraceTags: %v", err)
+	}
+	if len(tags) != 13 || tags[0] != "gold" || tags[12] != "tag11" {
+		t.Errorf("GetTraceTags = %v, want gold and tag00 to tag11", tags)
+	}
+	if lists != 2 {
+		t.Errorf("listed %d pages, want 2", lists)
+	}
+}
+
+func TestSearchTracesByTagPages(t *testing.T) {
+	var lists int
+	c := newTestClient(t, tagsMux(t, &lists))
+
+	spans, _, err := c.SearchTracesByTag(t.Context(), "test", "gold")
+	if err != nil {
+		t.Fatalf("SearchTracesByTag: %v", err)
+	}
+	if len(spans) != 1 || spans[0].TraceID != "t5" {
+		t.Errorf("SearchTracesByTag = %v, want the root span of t5", spans)
+	}
+
+	spans, _, err = c.SearchTracesByTag(t.Context(), "test", "b")
+	if err != nil {
+		t.Fatalf("SearchTracesByTag: %v", err)
+	}
+	if len(spans) != 5 {
+		t.Errorf("SearchTracesByTag(b) returned %d spans, want 5", len(spans))
+	}
+}
+
+func TestGetProjectTracesTagFilterPages(t *testing.T) {
+	var lists int
+	c := newTestClient(t, tagsMux(t, &lists))
+
+	traces, err := c.GetProjectTraces(t.Context(), "test", WithTagFilter("gold"))
+	if err != nil {
+		t.Fatalf("GetProjectTraces: %v", err)
+	}
+	ids := make([]string, 0, len(traces))
+	for _, tr := range traces {
+		ids = append(ids, tr.TraceID)
+	}
+	if !slices.Equal(ids, []string{"t5"}) {
+		t.Errorf("GetProjectTraces = %v, want [t5]", ids)
+	}
+	if lists != 3 {
+		t.Errorf("listed %d pages of annotations, want 3", lists)
+	}
+}
@@ -0,0 +1,113 @@
+package phoenix
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// traceDeleteMux serves two pages of spans for traces t0 to t(n-1), with
+// two spans per trace, and handles trace deletes with deleteTrace.
+func traceDeleteMux(t *testing.T, n int, limits *[]string, deleteTrace http.HandlerFunc) *http.ServeMux {
+	spans := make([]map[string]any, 0, 2*n)
+	for i := range n {
+		spans = append(spans,
+			spanJSON(fmt.Sprintf("t%d", i), fmt.Sprintf("s%d-root", i), nil),
+			spanJSON(fmt.Sprintf("t%d", i), fmt.Sprintf("s%d-child", i), nil))
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/projects/{project}/spans", func(w http.ResponseWriter, r *http.Request) {
+		*limits = append(*limits, r.URL.Query().Get("limit"))
+		if r.URL.Query().Get("cursor") == "" {
+			writeJSON(t, w, pageJSON(spans[:n], "page2"))
+			return
+		}
+		writeJSON(t, w, pageJSON(spans[n:], ""))
+	})
+	mux.HandleFunc("DELETE /v1/traces/{id}", deleteTrace)
+	return mux
+}
+
+func TestDeleteAllTracesInProjectDryRun(t *testing.T) {
+	var limits []string
+	var deletes atomic.Int32
+	c := newTestClient(t, traceDeleteMux(t, 5, &limits, func(w http.ResponseWriter, r *http.Request) {
+		deletes.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	n, err := c.DeleteAllTracesInProject(t.Context(), "test", WithDeleteDryRun(true), WithDeleteBatchSize(7))
+	if err != nil {
+		t.Fatalf("DeleteAllTracesInProject: %v", err)
+	}
+	if n != 5 {
+		t.Errorf("DeleteAllTracesInProject = %d, want 5 traces", n)
+	}
+	if deletes.Load() != 0 {
+		t.Errorf("dry run sent %d deletes", deletes.Load())
+	}
+	if len(limits) != 2 || limits[0] != "7" || limits[1] != "7" {
+		t.Errorf("listed spans with limits %v, want two pages of 7", limits)
+	}
+}
+
+func TestDeleteAllTracesInProjectConcurrency(t *testing.T) {
+	var limits []string
+	var inFlight, maxInFlight atomic.Int32
+	var mu sync.Mutex
+	deleted := make(map[string]bool)
+	c := newTestClient(t, traceDeleteMux(t, 20, &limits, func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		deleted[r.PathValue("id")] = true
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	n, err := c.DeleteAllTracesInProject(t.Context(), "test", WithDeleteConcurrency(3))
+	if err != nil {
+		t.Fatalf("DeleteAllTracesInProject: %v", err)
+	}
+	if n != 20 || len(deleted) != 20 {
+		t.Errorf("deleted %d traces (%d on the server), want 20", n, len(deleted))
+	}
+	if m := maxInFlight.Load(); m > 3 {
+		t.Errorf("%d deletes in flight, want at most 3", m)
+	}
+}
+
+func TestDeleteAllTracesInProjectError(t *testing.T) {
+	var limits []string
+	var deletes []string
+	c := newTestClient(t, traceDeleteMux(t, 20, &limits, func(w http.ResponseWriter, r *http.Request) {
+		deletes = append(deletes, r.PathValue("id"))
+		if r.PathValue("id") == "t2" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	// With one delete at a time, the failure stops the remaining deletes.
+	n, err := c.DeleteAllTracesInProject(t.Context(), "test", WithDeleteConcurrency(1))
+	if err == nil {
+		t.Fatal("DeleteAllTracesInProject succeeded, want the delete error")
+	}
+	if n != 2 {
+		t.Errorf("DeleteAllTracesInProject = %d, want the 2 traces deleted before the failure", n)
+	}
+	if len(deletes) != 3 {
+		t.Errorf("sent deletes for %v, want none after t2", deletes)
+	}
+}